@@ -19,6 +19,11 @@ resource "n8n_credential" "http_basic" {
     username = "myusername"
     password = "mypassword"
   }
+
+  # Create the replacement before deleting the old credential
+  lifecycle {
+    create_before_destroy = true
+  }
 }
 
 # Example: OAuth2 API credential
@@ -34,6 +39,11 @@ resource "n8n_credential" "oauth2" {
   }
 
   nodes_access = ["httpRequest"]
+
+  timeouts {
+    create = "2m"
+    delete = "2m"
+  }
 }
 
 # Example: HTTP Header Auth credential
@@ -44,4 +54,79 @@ resource "n8n_credential" "http_header" {
     name  = "Authorization"
     value = "Bearer your-token-here"
   }
+
+  # Recreate the credential whenever the token is rotated
+  rotation_triggers = {
+    token_version = "1"
+  }
+}
+
+# Example: Microsoft Teams OAuth2 credential
+resource "n8n_credential" "microsoft_teams" {
+  name = "example-microsoft-teams"
+
+  microsoft_oauth2 {
+    service       = "teams"
+    tenant_id     = "00000000-0000-0000-0000-000000000000"
+    client_id     = "your-client-id"
+    client_secret = "your-client-secret"
+  }
+}
+
+# Example: Azure OpenAI credential
+resource "n8n_credential" "azure_openai" {
+  name = "example-azure-openai"
+
+  azure_openai {
+    api_key       = var.azure_openai_api_key
+    resource_name = "contoso-openai"
+  }
+}
+
+# Example: MQTT broker credential
+resource "n8n_credential" "mqtt" {
+  name = "example-mqtt"
+
+  mqtt {
+    host     = "broker.example.com"
+    port     = 8883
+    ssl      = true
+    username = "sensors"
+    password = "your-password"
+  }
+}
+
+# Example: Kafka credential with SASL authentication
+resource "n8n_credential" "kafka" {
+  name = "example-kafka"
+
+  kafka {
+    client_id      = "n8n"
+    brokers        = "kafka1.example.com:9093,kafka2.example.com:9093"
+    sasl_mechanism = "scram-sha-512"
+    username       = "n8n"
+    password       = "your-password"
+  }
+}
+
+# Example: Salesforce credential using the JWT bearer flow
+resource "n8n_credential" "salesforce" {
+  name = "example-salesforce"
+
+  salesforce {
+    client_id   = "your-consumer-key"
+    username    = "integration@example.com"
+    private_key = file("salesforce.key")
+  }
+}
+
+# Example: client TLS certificate for mutual TLS upstreams
+resource "n8n_credential" "tls_certificate" {
+  name = "example-client-certificate"
+
+  tls_certificate {
+    client_cert = file("client.crt")
+    client_key  = file("client.key")
+    ca          = file("ca.crt")
+  }
 }
@@ -9,3 +9,9 @@ variable "n8n_api_key" {
   type        = string
   sensitive   = true
 }
+
+variable "azure_openai_api_key" {
+  description = "The API key of the Azure OpenAI resource"
+  type        = string
+  sensitive   = true
+}
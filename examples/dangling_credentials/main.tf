@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    n8n = {
+      source = "artus-engineering/n8n"
+    }
+  }
+}
+
+provider "n8n" {
+  host    = var.n8n_host
+  api_key = var.n8n_api_key
+}
+
+# Example: Fail the plan when nodes of the workflows kept in Git use
+# credentials that neither exist on the instance nor are managed here
+resource "n8n_credential" "api" {
+  name = "example-api"
+
+  header_auth {
+    name  = "Authorization"
+    value = "Bearer your-token-here"
+  }
+}
+
+data "n8n_dangling_credentials" "workflows" {
+  workflows      = [for file in fileset("${path.module}/workflows", "*.json") : file("${path.module}/workflows/${file}")]
+  credential_ids = [n8n_credential.api.id]
+}
@@ -0,0 +1,35 @@
+terraform {
+  required_providers {
+    n8n = {
+      source = "artus-engineering/n8n"
+    }
+  }
+}
+
+provider "n8n" {
+  host    = var.n8n_host
+  api_key = var.n8n_api_key
+
+  # Recent n8n versions only report enterprise features to signed-in users
+  enable_internal_api = true
+  email               = var.n8n_email
+  password            = var.n8n_password
+}
+
+data "n8n_instance_features" "this" {}
+
+# Example: Only share the credential when the license allows sharing
+resource "n8n_credential" "api" {
+  name = "api"
+
+  header_auth {
+    name  = "Authorization"
+    value = "Bearer ${var.api_token}"
+  }
+
+  shared_with = data.n8n_instance_features.this.sharing ? [var.team_project_id] : null
+}
+
+output "instance_features" {
+  value = data.n8n_instance_features.this
+}
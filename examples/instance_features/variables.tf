@@ -0,0 +1,33 @@
+variable "n8n_host" {
+  description = "The n8n instance host URL"
+  type        = string
+  default     = "http://localhost:5678"
+}
+
+variable "n8n_api_key" {
+  description = "The API key for authenticating with n8n"
+  type        = string
+  sensitive   = true
+}
+
+variable "n8n_email" {
+  description = "The email of the n8n user used for the internal API"
+  type        = string
+}
+
+variable "n8n_password" {
+  description = "The password of the n8n user used for the internal API"
+  type        = string
+  sensitive   = true
+}
+
+variable "api_token" {
+  description = "The token of the API the credential authenticates with"
+  type        = string
+  sensitive   = true
+}
+
+variable "team_project_id" {
+  description = "The ID of the team project to share the credential with"
+  type        = string
+}
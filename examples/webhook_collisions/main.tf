@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    n8n = {
+      source = "artus-engineering/n8n"
+    }
+  }
+}
+
+provider "n8n" {
+  host    = var.n8n_host
+  api_key = var.n8n_api_key
+}
+
+# Example: Fail the plan when the webhook triggers of the workflows kept in Git
+# collide with each other or with an active workflow on the instance
+data "n8n_webhook_collisions" "workflows" {
+  workflows = [for file in fileset("${path.module}/workflows", "*.json") : file("${path.module}/workflows/${file}")]
+
+  lifecycle {
+    postcondition {
+      condition     = length(self.collisions) == 0
+      error_message = "Webhook paths collide: ${join("; ", self.collisions)}"
+    }
+  }
+}
@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    n8n = {
+      source = "artus-engineering/n8n"
+    }
+  }
+}
+
+provider "n8n" {
+  host    = var.n8n_host
+  api_key = var.n8n_api_key
+}
+
+# Example: Manage a workflow exported from the n8n editor and kept in Git
+resource "n8n_workflow" "sync" {
+  name       = "Sync customers"
+  definition = file("${path.module}/sync.json")
+  active     = true
+}
+
+output "workflow_version" {
+  value = n8n_workflow.sync.version_id
+}
@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    n8n = {
+      source = "artus-engineering/n8n"
+    }
+  }
+}
+
+provider "n8n" {
+  host    = var.n8n_host
+  api_key = var.n8n_api_key
+}
+
+# Example: Snapshot all workflows as a compressed document
+data "n8n_workflow_backup" "all" {
+  compress       = true
+  retention_days = 30
+}
+
+# Example: Snapshot only the production workflows tagged for billing
+data "n8n_workflow_backup" "billing" {
+  filter {
+    name_regex = "^prod-"
+    tag        = "billing"
+  }
+}
+
+output "workflow_backup_sha256" {
+  value = data.n8n_workflow_backup.all.sha256
+}
@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    n8n = {
+      source = "artus-engineering/n8n"
+    }
+  }
+}
+
+provider "n8n" {
+  host    = var.n8n_host
+  api_key = var.n8n_api_key
+}
+
+# Example: Export workflows to compare them with the copies kept in Git
+data "n8n_workflow_export" "production" {
+  workflow_ids     = ["aBcD1234eFgH5678", "iJkL9012mNoP3456"]
+  ignore_ui_layout = true
+}
+
+output "workflow_definitions" {
+  value = data.n8n_workflow_export.production.definitions
+}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// auditLog appends a JSON record of every mutating API request to a file.
+type auditLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+// auditRecord is one line of the audit log.
+type auditRecord struct {
+	Timestamp    string `json:"timestamp"`
+	Operation    string `json:"operation"`
+	ResourceType string `json:"resource_type"`
+	ResourceID   string `json:"resource_id,omitempty"`
+	Method       string `json:"method"`
+	Path         string `json:"path"`
+	RequestID    string `json:"request_id,omitempty"`
+	Outcome      string `json:"outcome"`
+	StatusCode   int    `json:"status_code,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// Outcomes of audited requests.
+const (
+	auditOutcomeSuccess = "success"
+	auditOutcomeFailure = "failure"
+)
+
+// WithAuditLog appends a JSON record of every API request that may change the
+// instance, e.g. creating a credential or activating a workflow, to the file
+// at path, one record per line. Records are written once the request has
+// completed, including its retries, and hold the timestamp, operation,
+// resource type and ID, and outcome. The file is created if needed.
+func WithAuditLog(path string) Option {
+	return func(c *Client) error {
+		// Fail at configure time rather than on the first mutation.
+		//nolint:gosec // G304: Writing to a user-configured path is the purpose of the audit log
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("error opening audit log: %w", err)
+		}
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("error opening audit log: %w", err)
+		}
+
+		c.audit = &auditLog{path: path}
+		return nil
+	}
+}
+
+// auditRequest records the outcome of a request when the audit log is
+// enabled and the request may change the instance. Authentication requests
+// are not recorded. respBody is the response body, or out holds the decoded
+// response; either is used to find the ID of created objects.
+func (c *Client) auditRequest(req *http.Request, respBody []byte, out interface{}, err error) {
+	if c.audit == nil {
+		return
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return
+	}
+
+	resourceType, resourceID, action := auditTarget(req.URL.Path)
+	if resourceType == "" || resourceType == "login" {
+		return
+	}
+
+	record := auditRecord{
+		Timestamp:    time.Now().UTC().Format(time.RFC3339Nano),
+		Operation:    auditOperation(req.Method, action),
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Method:       req.Method,
+		Path:         req.URL.Path,
+		RequestID:    req.Header.Get(RequestIDHeader),
+		Outcome:      auditOutcomeSuccess,
+	}
+	if err != nil {
+		record.Outcome = auditOutcomeFailure
+		record.Error = err.Error()
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			record.StatusCode = apiErr.StatusCode
+		}
+	} else if record.ResourceID == "" {
+		record.ResourceID = responseObjectID(respBody, out)
+	}
+
+	if err := c.audit.write(record); err != nil {
+		tflog.Error(req.Context(), "Could not write audit log record", map[string]interface{}{
+			"path":  c.audit.path,
+			"error": err.Error(),
+		})
+	}
+}
+
+// write appends the record to the audit log. The file is opened for every
+// record, so records of concurrent provider processes are not interleaved.
+func (a *auditLog) write(record auditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	//nolint:gosec // G304: Writing to a user-configured path is the purpose of the audit log
+	file, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// auditTarget splits a public or internal API path such as
+// /api/v1/workflows/7/activate into the resource type, ID and action.
+func auditTarget(path string) (resourceType, resourceID, action string) {
+	for _, prefix := range []string{"/api/" + apiVersion + "/", "/rest/"} {
+		if _, rest, ok := strings.Cut(path, prefix); ok {
+			segments := strings.SplitN(rest, "/", 3)
+			resourceType = segments[0]
+			if len(segments) > 1 {
+				resourceID = segments[1]
+			}
+			if len(segments) > 2 {
+				action = segments[2]
+			}
+			return resourceType, resourceID, action
+		}
+	}
+	return "", "", ""
+}
+
+// auditOperation names the operation of a request: the action of the path,
+// such as "activate" or "transfer", or else the operation of the method.
+func auditOperation(method, action string) string {
+	if action != "" {
+		return action
+	}
+	switch method {
+	case http.MethodPost:
+		return "create"
+	case http.MethodDelete:
+		return "delete"
+	default:
+		return "update"
+	}
+}
+
+// responseObjectID returns the ID of the object in a response, which the
+// internal API wraps in a data field.
+func responseObjectID(respBody []byte, out interface{}) string {
+	if respBody == nil && out != nil {
+		encoded, err := json.Marshal(out)
+		if err != nil {
+			return ""
+		}
+		respBody = encoded
+	}
+
+	var object struct {
+		ID   json.RawMessage `json:"id"`
+		Data struct {
+			ID json.RawMessage `json:"id"`
+		} `json:"data"`
+	}
+	if json.Unmarshal(respBody, &object) != nil {
+		return ""
+	}
+
+	id := object.ID
+	if id == nil {
+		id = object.Data.ID
+	}
+	return strings.Trim(string(id), `"`)
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+)
+
+func TestWithAuditLog(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/credentials", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+	mux.HandleFunc("POST /api/v1/credentials", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"42","name":"api","type":"httpBasicAuth"}`))
+	})
+	mux.HandleFunc("DELETE /api/v1/credentials/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	})
+	mux.HandleFunc("PUT /api/v1/credentials/{id}/transfer", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithRetry(0, 0, 0), WithAuditLog(path))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.ListCredentials(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.CreateCredential(ctx, &models.Credential{Name: "api", Type: "httpBasicAuth"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.TransferCredential(ctx, "42", "project-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_ = client.DeleteCredential(ctx, "7")
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer file.Close()
+
+	var records []auditRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record auditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Expected a JSON record per line, got %q: %v", scanner.Text(), err)
+		}
+		if record.Timestamp == "" {
+			t.Errorf("Expected a timestamp, got %+v", record)
+		}
+		records = append(records, record)
+	}
+
+	expected := []auditRecord{
+		{Operation: "create", ResourceType: "credentials", ResourceID: "42", Method: "POST", Outcome: auditOutcomeSuccess},
+		{Operation: "transfer", ResourceType: "credentials", ResourceID: "42", Method: "PUT", Outcome: auditOutcomeSuccess},
+		{Operation: "delete", ResourceType: "credentials", ResourceID: "7", Method: "DELETE", Outcome: auditOutcomeFailure, StatusCode: http.StatusNotFound},
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("Expected %d records for the mutations only, got %+v", len(expected), records)
+	}
+	for i, record := range records {
+		want := expected[i]
+		if record.Operation != want.Operation || record.ResourceType != want.ResourceType || record.ResourceID != want.ResourceID ||
+			record.Method != want.Method || record.Outcome != want.Outcome || record.StatusCode != want.StatusCode {
+			t.Errorf("Record %d: expected %+v, got %+v", i, want, record)
+		}
+	}
+	if records[2].Error == "" {
+		t.Errorf("Expected the error of the failed request to be recorded")
+	}
+}
+
+func TestWithAuditLogRejectsUnwritablePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "audit.log")
+	if _, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false), WithAuditLog(path)); err == nil {
+		t.Error("Expected an error for an audit log in a missing directory")
+	}
+}
+
+func TestAuditTarget(t *testing.T) {
+	tests := []struct {
+		path, resourceType, resourceID, action string
+	}{
+		{"/api/v1/workflows", "workflows", "", ""},
+		{"/api/v1/workflows/7/activate", "workflows", "7", "activate"},
+		{"/n8n/rest/credentials/42/share", "credentials", "42", "share"},
+		{"/healthz", "", "", ""},
+	}
+
+	for _, test := range tests {
+		resourceType, resourceID, action := auditTarget(test.path)
+		if resourceType != test.resourceType || resourceID != test.resourceID || action != test.action {
+			t.Errorf("auditTarget(%q) = %q, %q, %q", test.path, resourceType, resourceID, action)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+)
+
+// listCacheTTL is how long a credential list is reused. It is short enough
+// that the cache only spans a single plan or refresh.
+const listCacheTTL = 30 * time.Second
+
+// credentialCache holds the most recent credential list so that refreshing
+// many credential resources lists the credentials only once. The mutex is
+// held while listing, so concurrent lookups wait for a single request.
+type credentialCache struct {
+	mu          sync.Mutex
+	credentials []models.Credential
+	fetchedAt   time.Time
+}
+
+// cachedCredentials returns the cached credential list, listing the
+// credentials when the cache is empty or expired.
+func (c *Client) cachedCredentials(ctx context.Context) ([]models.Credential, error) {
+	c.credentialCache.mu.Lock()
+	defer c.credentialCache.mu.Unlock()
+
+	if c.credentialCache.credentials != nil && time.Since(c.credentialCache.fetchedAt) < listCacheTTL {
+		return c.credentialCache.credentials, nil
+	}
+
+	credentials, err := c.ListCredentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if credentials == nil {
+		credentials = []models.Credential{}
+	}
+
+	c.credentialCache.credentials = credentials
+	c.credentialCache.fetchedAt = time.Now()
+	return credentials, nil
+}
+
+// invalidateCredentialCache drops the cached credential list after a change.
+func (c *Client) invalidateCredentialCache() {
+	c.credentialCache.mu.Lock()
+	defer c.credentialCache.mu.Unlock()
+
+	c.credentialCache.credentials = nil
+}
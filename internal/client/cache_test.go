@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestGetCredentialSharesCredentialList(t *testing.T) {
+	var mu sync.Mutex
+	lists := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/credentials", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		lists++
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"data":[{"id":"1"},{"id":"2"},{"id":"3"}]}`))
+	})
+	mux.HandleFunc("GET /api/v1/credentials/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	})
+	mux.HandleFunc("DELETE /api/v1/credentials/{id}", func(w http.ResponseWriter, r *http.Request) {})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for _, id := range []string{"1", "2", "3", "1", "2", "3"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetCredential(context.Background(), id); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if lists != 1 {
+		t.Errorf("Expected 1 list request, got %d", lists)
+	}
+
+	// Changes invalidate the cached list.
+	if err := client.DeleteCredential(context.Background(), "3"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.GetCredential(context.Background(), "1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if lists != 2 {
+		t.Errorf("Expected 2 list requests, got %d", lists)
+	}
+}
@@ -2,177 +2,477 @@ package client
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
 )
 
 const (
-	defaultTimeout = 30 * time.Second
+	// DefaultTimeout is the timeout applied to API requests whose context has
+	// no deadline.
+	DefaultTimeout = 30 * time.Second
 	apiVersion     = "v1"
 )
 
+// ErrNotFound is returned when the requested object does not exist in n8n.
+var ErrNotFound = errors.New("not found")
+
 // Client handles communication with the n8n API.
 type Client struct {
 	Host     string
 	APIKey   string
 	Insecure bool
 	client   *http.Client
+	doer     Doer
+
+	internal  *internalAPI
+	retry     retryPolicy
+	headers   http.Header
+	userAgent string
+
+	sessionKey       *sessionAPIKey
+	version          *Version
+	readOnly         bool
+	strictReads      bool
+	compressRequests bool
+
+	credentialCache    credentialCache
+	enterpriseSettings enterpriseSettingsCache
+	slots              chan struct{}
+	hooks              []RequestHook
+	operationTimeouts  map[Operation]time.Duration
+	audit              *auditLog
+	transcript         *transcript
+	pageSize           int
+	webhookBaseURL     string
 }
 
+// Option configures optional client behavior.
+type Option func(*Client) error
+
 // NewClient creates a new n8n API client.
-func NewClient(host, apiKey *string, insecure *bool) (*Client, error) {
+func NewClient(host, apiKey *string, insecure *bool, opts ...Option) (*Client, error) {
 	if host == nil || *host == "" {
 		return nil, fmt.Errorf("host is required")
 	}
-	if apiKey == nil || *apiKey == "" {
-		return nil, fmt.Errorf("api_key is required")
+
+	transport := newTransport(insecure != nil && *insecure)
+
+	socketPath, isSocket, err := unixSocketPath(*host)
+	if err != nil {
+		return nil, err
 	}
 
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			//nolint:gosec // G402: InsecureSkipVerify is configurable by user for testing/development
-			InsecureSkipVerify: insecure != nil && *insecure,
-		},
+	baseURL := unixBaseURL
+	if isSocket {
+		dialUnixSocket(transport, socketPath)
+	} else {
+		baseURL, err = normalizeHost(*host)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	httpClient := &http.Client{
-		Transport: tr,
-		Timeout:   defaultTimeout,
+		Transport: transport,
 	}
 
-	return &Client{
-		Host:     *host,
-		APIKey:   *apiKey,
+	c := &Client{
+		Host:     baseURL,
 		Insecure: insecure != nil && *insecure,
 		client:   httpClient,
-	}, nil
+	}
+
+	if apiKey != nil {
+		c.APIKey = *apiKey
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	// Session authentication creates an API key when the public API is first used.
+	if c.APIKey == "" && c.sessionKey == nil {
+		return nil, fmt.Errorf("api_key is required")
+	}
+
+	return c, nil
 }
 
-// doRequest performs an HTTP request to the n8n API.
-func (c *Client) doRequest(method, endpoint string, body interface{}) ([]byte, error) {
+// doRequest performs an HTTP request to the n8n public API.
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, body interface{}) ([]byte, error) {
+	var respBody []byte
+	err := c.withAPIKey(ctx, func() error {
+		req, err := c.newAPIRequest(ctx, method, endpoint, body)
+		if err != nil {
+			return err
+		}
+
+		respBody, err = c.execute(req)
+		return err
+	})
+	return respBody, err
+}
+
+// doRequestDecode performs an HTTP request to the n8n public API and decodes
+// the response into out while it is received, instead of buffering it first.
+// It is meant for large payloads such as workflow definitions.
+func (c *Client) doRequestDecode(ctx context.Context, method, endpoint string, body, out interface{}) error {
+	return c.withAPIKey(ctx, func() error {
+		req, err := c.newAPIRequest(ctx, method, endpoint, body)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.executeDecode(req, out)
+		return err
+	})
+}
+
+// newAPIRequest creates a request to the n8n public API.
+func (c *Client) newAPIRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Request, error) {
+	if err := c.checkWritable(method, endpoint); err != nil {
+		return nil, err
+	}
+
+	apiKey, err := c.publicAPIKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if method != http.MethodGet {
+		c.invalidateCredentialCache()
+	}
+
 	url := fmt.Sprintf("%s/api/%s/%s", c.Host, apiVersion, endpoint)
 
+	req, err := newRequest(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-N8N-API-KEY", apiKey)
+
+	if err := c.compressRequest(req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// newRequest creates an HTTP request with an optional JSON body.
+func newRequest(ctx context.Context, method, url string, body interface{}) (*http.Request, error) {
 	var reqBody io.Reader
 	if body != nil {
 		jsonData, err := json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("error marshaling request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonData)
+		reqBody = bytes.NewReader(jsonData)
 	}
 
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-N8N-API-KEY", c.APIKey)
 
-	resp, err := c.client.Do(req)
+	return req, nil
+}
+
+// execute sends the request, retrying transient failures according to the
+// retry policy, and returns the response body for successful responses.
+func (c *Client) execute(req *http.Request) ([]byte, error) {
+	return c.executeDecode(req, nil)
+}
+
+// executeDecode is execute, except that successful responses are decoded into
+// out as they are received when out is not nil.
+func (c *Client) executeDecode(req *http.Request, out interface{}) ([]byte, error) {
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	for name, values := range c.headers {
+		req.Header[name] = values
+	}
+	requestID := setRequestID(req)
+
+	respBody, err := c.executeAttempts(req, out)
+	err = withRequestID(err, requestID)
+	c.auditRequest(req, respBody, out, err)
+	return respBody, err
+}
+
+// executeAttempts sends the request until it succeeds, fails permanently or
+// runs out of retries. The retries of calls that succeed are recorded in the
+// retry report of the request context, if any.
+func (c *Client) executeAttempts(req *http.Request, out interface{}) ([]byte, error) {
+	var retries []retryAttempt
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("error resetting request body: %w", err)
+			}
+			req.Body = body
+		}
+
+		respBody, statusCode, err := c.send(req, out)
+		if err == nil {
+			retryReportFromContext(req.Context()).record(retries)
+		}
+		if attempt >= c.retry.maxRetries || retriesDisabled(req.Context()) || !shouldRetry(req.Method, statusCode, err) {
+			return respBody, err
+		}
+
+		wait, ok := c.retry.wait(attempt, err)
+		if !ok {
+			return respBody, err
+		}
+		retries = append(retries, retryAttempt{reason: retryReason(statusCode), wait: wait})
+
+		select {
+		case <-req.Context().Done():
+			return nil, fmt.Errorf("error making request: %w", req.Context().Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+// send performs a single attempt of the request, reporting it to the request
+// hooks. The status code is zero when no response was received. When out is
+// not nil, successful responses are decoded into it instead of returned.
+func (c *Client) send(req *http.Request, out interface{}) ([]byte, int, error) {
+	req, finish := c.startHooks(req)
+
+	respBody, statusCode, err := c.sendAttempt(req, out)
+	finish(statusCode, err)
+
+	return respBody, statusCode, err
+}
+
+// sendAttempt performs a single attempt of the request. Requests whose context
+// has no deadline time out after DefaultTimeout.
+func (c *Client) sendAttempt(req *http.Request, out interface{}) ([]byte, int, error) {
+	if _, ok := req.Context().Deadline(); !ok {
+		ctx, cancel := context.WithTimeout(req.Context(), DefaultTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	release, err := c.acquire(req.Context())
+	if err != nil {
+		return nil, 0, err
+	}
+	defer release()
+
+	c.logRequest(req.Context(), req)
+
+	resp, err := c.do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error making request: %w", err)
+		c.recordExchange(req, nil, nil, err)
+		return nil, 0, fmt.Errorf("error making request: %w", err)
 	}
 	defer func() {
 		//nolint:errcheck // Error closing response body is not critical
 		_ = resp.Body.Close()
 	}()
 
+	// Responses are read in full rather than streamed into out while a
+	// transcript is recorded, so their body can be added to it.
+	success := resp.StatusCode >= 200 && resp.StatusCode < 300
+	if success && out != nil && c.transcript == nil {
+		c.logResponse(req.Context(), req, resp, nil)
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return nil, resp.StatusCode, fmt.Errorf("error unmarshaling response: %w", err)
+		}
+		return nil, resp.StatusCode, nil
+	}
+
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %w", err)
+		c.recordExchange(req, resp, nil, err)
+		return nil, resp.StatusCode, fmt.Errorf("error reading response body: %w", err)
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+	c.logResponse(req.Context(), req, resp, respBody)
+	c.recordExchange(req, resp, respBody, nil)
+
+	if !success {
+		return nil, resp.StatusCode, newAPIError(resp.StatusCode, resp.Header, respBody, c.IsCloud())
 	}
 
-	return respBody, nil
-}
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return nil, resp.StatusCode, fmt.Errorf("error unmarshaling response: %w", err)
+		}
+		return nil, resp.StatusCode, nil
+	}
 
-// Credential represents an n8n credential.
-type Credential struct {
-	ID          string                 `json:"id,omitempty"`
-	Name        string                 `json:"name"`
-	Type        string                 `json:"type"`
-	Data        map[string]interface{} `json:"data"`
-	NodesAccess []NodeAccess           `json:"nodesAccess,omitempty"`
+	return respBody, resp.StatusCode, nil
 }
 
-// NodeAccess defines which nodes can access the credential.
-type NodeAccess struct {
-	NodeType string `json:"nodeType"`
+// Ping checks that the API is reachable and accepts the API key by listing a
+// single credential. Clients authenticating with a user session log in
+// instead, so that checking the connection creates no API key.
+func (c *Client) Ping(ctx context.Context) error {
+	if c.APIKey == "" && c.sessionKey != nil {
+		return c.login(ctx)
+	}
+
+	_, err := c.doRequest(ctx, "GET", "credentials?limit=1", nil)
+	return err
 }
 
-// CreateCredential creates a new credential in n8n.
-func (c *Client) CreateCredential(credential *Credential) (*Credential, error) {
-	body := map[string]interface{}{
-		"name": credential.Name,
-		"type": credential.Type,
-		"data": credential.Data,
-	}
+// CreateCredential creates a new credential in n8n. Create requests are not
+// retried blindly, since a request that failed in transit may still have
+// created the credential. Instead, the credential is looked up by name and
+// type and adopted when it was created by the failed request.
+func (c *Client) CreateCredential(ctx context.Context, credential *models.Credential) (*models.Credential, error) {
+	body := models.NewCredentialCreateRequest(credential)
+	started := time.Now()
+
+	for attempt := 0; ; attempt++ {
+		respBody, err := c.doRequest(ctx, "POST", "credentials", body)
+		if err == nil {
+			var createdCredential models.Credential
+			if err := json.Unmarshal(respBody, &createdCredential); err != nil {
+				return nil, fmt.Errorf("error unmarshaling response: %w", err)
+			}
+			return &createdCredential, nil
+		}
+
+		if attempt >= c.retry.maxRetries || ctx.Err() != nil || !mayHaveSucceeded(err) {
+			return nil, err
+		}
+
+		existing, lookupErr := c.findCreatedCredential(ctx, credential, started)
+		if lookupErr != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return existing, nil
+		}
 
-	if len(credential.NodesAccess) > 0 {
-		body["nodesAccess"] = credential.NodesAccess
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(c.retry.backoff(attempt)):
+		}
 	}
+}
 
-	respBody, err := c.doRequest("POST", "credentials", body)
+// createdAtTolerance allows for clock skew between the provider and n8n when
+// matching credentials by creation time.
+const createdAtTolerance = time.Minute
+
+// findCreatedCredential returns the credential with the name and type of the
+// given credential that was created since the create request started, or nil
+// when there is no such credential. Several candidates are reported as an
+// error, since it is unclear which one to adopt.
+func (c *Client) findCreatedCredential(ctx context.Context, credential *models.Credential, started time.Time) (*models.Credential, error) {
+	credentials, err := c.ListCredentials(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var createdCredential Credential
-	if err := json.Unmarshal(respBody, &createdCredential); err != nil {
-		return nil, fmt.Errorf("error unmarshaling response: %w", err)
+	var found *models.Credential
+	for i := range credentials {
+		candidate := &credentials[i]
+		if candidate.Name != credential.Name || candidate.Type != credential.Type {
+			continue
+		}
+
+		createdAt, err := time.Parse(time.RFC3339, candidate.CreatedAt)
+		if err != nil || createdAt.Before(started.Add(-createdAtTolerance)) {
+			continue
+		}
+
+		if found != nil {
+			return nil, fmt.Errorf("found several %s credentials named %q created since the request started", credential.Type, credential.Name)
+		}
+		found = candidate
 	}
 
-	return &createdCredential, nil
+	return found, nil
 }
 
 // ListCredentialsResponse represents the response from listing credentials.
 type ListCredentialsResponse struct {
-	Data []Credential `json:"data"`
+	Data       []models.Credential `json:"data"`
+	NextCursor string              `json:"nextCursor"`
 }
 
-// ListCredentials retrieves all credentials.
-func (c *Client) ListCredentials() ([]Credential, error) {
-	respBody, err := c.doRequest("GET", "credentials", nil)
-	if err != nil {
-		return nil, err
+// ListCredentials retrieves all credentials, following pagination.
+func (c *Client) ListCredentials(ctx context.Context) ([]models.Credential, error) {
+	if c.sessionReads() {
+		return c.listInternalCredentials(ctx)
 	}
 
-	var response ListCredentialsResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
-		// Try to unmarshal as a direct array if the response doesn't have a "data" wrapper
-		var credentials []Credential
-		if err2 := json.Unmarshal(respBody, &credentials); err2 != nil {
-			return nil, fmt.Errorf("error unmarshaling response: %w", err)
+	var credentials []models.Credential
+
+	err := c.listPages(ctx, "credentials", func(pageEndpoint string) (string, error) {
+		respBody, err := c.doRequest(ctx, "GET", pageEndpoint, nil)
+		if err != nil {
+			return "", err
+		}
+
+		var response ListCredentialsResponse
+		if err := json.Unmarshal(respBody, &response); err != nil {
+			// Try to unmarshal as a direct array if the response doesn't have a "data" wrapper
+			var page []models.Credential
+			if err2 := json.Unmarshal(respBody, &page); err2 != nil {
+				return "", fmt.Errorf("error unmarshaling response: %w", err)
+			}
+			credentials = append(credentials, page...)
+			return "", nil
 		}
-		return credentials, nil
+
+		credentials = append(credentials, response.Data...)
+		return response.NextCursor, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return response.Data, nil
+	return credentials, nil
 }
 
 // GetCredential retrieves a credential by ID.
 // Since n8n API may not support direct GET by ID, we list all credentials and find the matching one.
-func (c *Client) GetCredential(id string) (*Credential, error) {
+// ErrNotFound is returned only when the credential is missing from a successful list.
+func (c *Client) GetCredential(ctx context.Context, id string) (*models.Credential, error) {
 	// First, try direct GET (in case the API supports it)
-	respBody, err := c.doRequest("GET", fmt.Sprintf("credentials/%s", id), nil)
+	respBody, err := c.doRequest(ctx, "GET", fmt.Sprintf("credentials/%s", id), nil)
 	if err == nil {
-		var credential Credential
+		var credential models.Credential
 		if err := json.Unmarshal(respBody, &credential); err != nil {
 			return nil, fmt.Errorf("error unmarshaling response: %w", err)
 		}
 		return &credential, nil
 	}
 
-	// If direct GET fails, fall back to listing and filtering
-	credentials, err := c.ListCredentials()
+	// The internal API can read single credentials on instances where the
+	// public API cannot.
+	if c.internal != nil {
+		credential, err := c.getInternalCredential(ctx, id)
+		if err == nil {
+			return credential, nil
+		}
+	}
+
+	// If direct GET fails, fall back to listing and filtering. The list is
+	// shared by all lookups of a refresh.
+	credentials, err := c.cachedCredentials(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("error listing credentials: %w", err)
 	}
@@ -183,32 +483,72 @@ func (c *Client) GetCredential(id string) (*Credential, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("credential with ID %s not found", id)
+	// The list is authoritative, so the credential no longer exists.
+	return nil, fmt.Errorf("credential with ID %s %w", id, ErrNotFound)
 }
 
-// UpdateCredential updates an existing credential by deleting and recreating it.
-// Note: The n8n API does not support PUT or PATCH for credentials, so we must
-// delete and recreate. This will result in a new credential ID.
-// WARNING: If workflows reference this credential by ID, they will need to be updated.
-func (c *Client) UpdateCredential(id string, credential *Credential) (*Credential, error) {
-	// Delete the old credential
-	err := c.DeleteCredential(id)
-	if err != nil {
-		return nil, fmt.Errorf("failed to delete old credential before update: %w", err)
+// UpdateCredential updates an existing credential. Instances that support it
+// update the credential in place. Otherwise, since older versions of the n8n
+// API do not support PATCH for credentials, the credential is recreated,
+// which results in a new credential ID.
+// The new credential is created before the old one is deleted, so a failed
+// create leaves the old credential in place. If the old credential cannot be
+// deleted, the new credential is returned together with the error.
+// WARNING: If workflows reference a recreated credential by ID, they will need to be updated.
+func (c *Client) UpdateCredential(ctx context.Context, id string, credential *models.Credential) (*models.Credential, error) {
+	if c.UpdatesCredentialsInPlace() {
+		return c.patchCredential(ctx, id, credential)
 	}
 
 	// Create a new credential with the updated data
 	// This will generate a new ID
-	newCredential, err := c.CreateCredential(credential)
+	newCredential, err := c.CreateCredential(ctx, credential)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create new credential after delete: %w", err)
+		return nil, fmt.Errorf("failed to create new credential: %w", err)
+	}
+
+	// Delete the old credential, unless it is already gone
+	var notFound *NotFoundError
+	if err := c.DeleteCredential(ctx, id); err != nil && !errors.As(err, &notFound) {
+		return newCredential, fmt.Errorf("failed to delete old credential %s after creating %s: %w", id, newCredential.ID, err)
 	}
 
 	return newCredential, nil
 }
 
+// patchCredential updates a credential in place.
+func (c *Client) patchCredential(ctx context.Context, id string, credential *models.Credential) (*models.Credential, error) {
+	body := models.NewCredentialCreateRequest(credential)
+
+	respBody, err := c.doRequest(ctx, "PATCH", fmt.Sprintf("credentials/%s", id), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedCredential models.Credential
+	if err := json.Unmarshal(respBody, &updatedCredential); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response: %w", err)
+	}
+
+	return &updatedCredential, nil
+}
+
+// TransferCredential moves a credential to another project.
+func (c *Client) TransferCredential(ctx context.Context, id, projectID string) error {
+	if err := c.RequireFeature(FeatureCredentialTransfer); err != nil {
+		return err
+	}
+
+	body := models.CredentialTransferRequest{
+		DestinationProjectID: projectID,
+	}
+
+	_, err := c.doRequest(ctx, "PUT", fmt.Sprintf("credentials/%s/transfer", id), body)
+	return err
+}
+
 // DeleteCredential deletes a credential by ID.
-func (c *Client) DeleteCredential(id string) error {
-	_, err := c.doRequest("DELETE", fmt.Sprintf("credentials/%s", id), nil)
+func (c *Client) DeleteCredential(ctx context.Context, id string) error {
+	_, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("credentials/%s", id), nil)
 	return err
 }
@@ -1,7 +1,15 @@
 package client
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
 )
 
 func TestNewClient(t *testing.T) {
@@ -77,6 +85,119 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestGetCredentialNotFound(t *testing.T) {
+	tests := []struct {
+		name         string
+		listStatus   int
+		wantNotFound bool
+	}{
+		{
+			name:         "missing from list",
+			listStatus:   http.StatusOK,
+			wantNotFound: true,
+		},
+		{
+			name:         "list not supported",
+			listStatus:   http.StatusMethodNotAllowed,
+			wantNotFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /api/v1/credentials/{id}", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			})
+			mux.HandleFunc("GET /api/v1/credentials", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.listStatus)
+				_, _ = w.Write([]byte(`{"data":[{"id":"1","name":"other","type":"httpBasicAuth"}]}`))
+			})
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			_, err = client.GetCredential(context.Background(), "42")
+			if err == nil {
+				t.Fatalf("Expected error but got none")
+			}
+			if errors.Is(err, ErrNotFound) != tt.wantNotFound {
+				t.Errorf("Expected not found %v, got error: %v", tt.wantNotFound, err)
+			}
+		})
+	}
+}
+
+func TestTransferCredential(t *testing.T) {
+	var gotMethod, gotPath, gotProject string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Unexpected error decoding body: %v", err)
+		}
+		gotProject = body["destinationProjectId"]
+	}))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := client.TransferCredential(context.Background(), "42", "project-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if gotMethod != http.MethodPut || gotPath != "/api/v1/credentials/42/transfer" {
+		t.Errorf("Unexpected request %s %s", gotMethod, gotPath)
+	}
+	if gotProject != "project-1" {
+		t.Errorf("Expected destinationProjectId project-1, got %s", gotProject)
+	}
+}
+
+func TestUpdateCredentialCreatesBeforeDelete(t *testing.T) {
+	var calls []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/credentials", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "create")
+		_, _ = w.Write([]byte(`{"id":"43","name":"example","type":"httpBasicAuth"}`))
+	})
+	mux.HandleFunc("DELETE /api/v1/credentials/{id}", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "delete "+r.PathValue("id"))
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	credential, err := client.UpdateCredential(context.Background(), "42", &models.Credential{Name: "example", Type: "httpBasicAuth"})
+	if err == nil {
+		t.Errorf("Expected error when old credential cannot be deleted")
+	}
+	if credential == nil || credential.ID != "43" {
+		t.Errorf("Expected new credential to be returned, got %+v", credential)
+	}
+	if len(calls) != 2 || calls[0] != "create" || calls[1] != "delete 42" {
+		t.Errorf("Unexpected call order: %v", calls)
+	}
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -84,3 +205,94 @@ func stringPtr(s string) *string {
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+func TestRequestHonorsContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithRetry(0, time.Millisecond, time.Millisecond))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = client.ListCredentials(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestCreateCredentialAdoptsCredentialCreatedByFailedRequest(t *testing.T) {
+	creates := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/credentials", func(w http.ResponseWriter, r *http.Request) {
+		creates++
+		w.WriteHeader(http.StatusGatewayTimeout)
+	})
+	mux.HandleFunc("GET /api/v1/credentials", func(w http.ResponseWriter, r *http.Request) {
+		createdAt := time.Now().UTC().Format(time.RFC3339)
+		_, _ = w.Write([]byte(`{"data":[
+			{"id":"1","name":"example","type":"httpBasicAuth","createdAt":"2020-01-01T00:00:00.000Z"},
+			{"id":"2","name":"example","type":"httpBasicAuth","createdAt":"` + createdAt + `"}
+		]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithRetry(3, time.Millisecond, time.Millisecond))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	credential, err := client.CreateCredential(context.Background(), &models.Credential{Name: "example", Type: "httpBasicAuth"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if credential.ID != "2" {
+		t.Errorf("Expected to adopt credential 2, got %s", credential.ID)
+	}
+	if creates != 1 {
+		t.Errorf("Expected 1 create request, got %d", creates)
+	}
+}
+
+func TestCreateCredentialRetriesWhenNothingWasCreated(t *testing.T) {
+	creates := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/credentials", func(w http.ResponseWriter, r *http.Request) {
+		creates++
+		if creates == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"3","name":"example","type":"httpBasicAuth"}`))
+	})
+	mux.HandleFunc("GET /api/v1/credentials", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithRetry(3, time.Millisecond, time.Millisecond))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	credential, err := client.CreateCredential(context.Background(), &models.Credential{Name: "example", Type: "httpBasicAuth"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if credential.ID != "3" || creates != 2 {
+		t.Errorf("Expected credential 3 after 2 create requests, got %s after %d", credential.ID, creates)
+	}
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// cloudDomain is the domain of n8n Cloud workspaces, e.g. acme.app.n8n.cloud.
+const cloudDomain = ".app.n8n.cloud"
+
+// normalizeHost turns the host into the base URL of the instance. It accepts
+// hosts without a scheme, trailing slashes and a trailing API path. For n8n
+// Cloud, any editor URL copied from the browser is reduced to the workspace.
+func normalizeHost(host string) (string, error) {
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+
+	parsed, err := url.Parse(host)
+	if err != nil {
+		return "", fmt.Errorf("invalid host: %w", err)
+	}
+	if parsed.Host == "" {
+		return "", fmt.Errorf("invalid host %q: missing hostname", host)
+	}
+
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+
+	if isCloudHost(parsed.Hostname()) {
+		parsed.Path = ""
+	} else {
+		parsed.Path = strings.TrimSuffix(strings.TrimRight(parsed.Path, "/"), "/api/"+apiVersion)
+		parsed.Path = strings.TrimRight(parsed.Path, "/")
+	}
+	parsed.RawPath = ""
+
+	return parsed.String(), nil
+}
+
+// isCloudHost reports whether the hostname belongs to an n8n Cloud workspace.
+func isCloudHost(hostname string) bool {
+	return strings.HasSuffix(strings.ToLower(hostname), cloudDomain)
+}
+
+// IsCloud reports whether the client talks to an n8n Cloud workspace.
+func (c *Client) IsCloud() bool {
+	parsed, err := url.Parse(c.Host)
+	if err != nil {
+		return false
+	}
+	return isCloudHost(parsed.Hostname())
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestNormalizeHost(t *testing.T) {
+	tests := map[string]string{
+		"https://n8n.example.com":                         "https://n8n.example.com",
+		"https://n8n.example.com/":                        "https://n8n.example.com",
+		"https://n8n.example.com/api/v1/":                 "https://n8n.example.com",
+		"http://localhost:5678":                           "http://localhost:5678",
+		"https://example.com/n8n/":                        "https://example.com/n8n",
+		"n8n.example.com":                                 "https://n8n.example.com",
+		"acme.app.n8n.cloud":                              "https://acme.app.n8n.cloud",
+		"https://acme.app.n8n.cloud/home/workflows?x=1#y": "https://acme.app.n8n.cloud",
+	}
+
+	for host, want := range tests {
+		got, err := normalizeHost(host)
+		if err != nil {
+			t.Errorf("normalizeHost(%q) unexpected error: %v", host, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("normalizeHost(%q) = %q, want %q", host, got, want)
+		}
+	}
+}
+
+func TestIsCloud(t *testing.T) {
+	cloud, err := NewClient(stringPtr("https://ACME.app.n8n.cloud/home"), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !cloud.IsCloud() {
+		t.Errorf("Expected n8n Cloud workspace to be detected")
+	}
+
+	selfHosted, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if selfHosted.IsCloud() {
+		t.Errorf("Expected self-hosted instance not to be detected as n8n Cloud")
+	}
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// compressMinSize is the smallest request body that is compressed. Smaller
+// bodies, such as most credentials, gain little from compression.
+const compressMinSize = 16 * 1024
+
+// WithRequestCompression gzips large request bodies, e.g. big workflow
+// definitions, which speeds up applies over slow links. Responses are always
+// requested and decoded with gzip transparently by the HTTP transport.
+func WithRequestCompression() Option {
+	return func(c *Client) error {
+		c.compressRequests = true
+		return nil
+	}
+}
+
+// compressRequest gzips the request body when request compression is enabled
+// and the body is large enough.
+func (c *Client) compressRequest(req *http.Request) error {
+	if !c.compressRequests || req.GetBody == nil || req.ContentLength < compressMinSize {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return fmt.Errorf("error reading request body: %w", err)
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := io.Copy(writer, body); err != nil {
+		return fmt.Errorf("error compressing request body: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("error compressing request body: %w", err)
+	}
+
+	compressed := buf.Bytes()
+	req.Body = io.NopCloser(bytes.NewReader(compressed))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(compressed)), nil
+	}
+	req.ContentLength = int64(len(compressed))
+	req.Header.Set("Content-Encoding", "gzip")
+
+	return nil
+}
+
+// decompressBody returns the uncompressed content of a request body for
+// logging.
+func decompressBody(header http.Header, content []byte) ([]byte, error) {
+	if header.Get("Content-Encoding") != "gzip" {
+		return content, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(reader)
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+)
+
+func TestWithRequestCompression(t *testing.T) {
+	var encodings []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+
+		body := io.Reader(r.Body)
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			reader, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			body = reader
+		}
+
+		var credential models.Credential
+		if err := json.NewDecoder(body).Decode(&credential); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"id":"1","name":"` + credential.Name + `"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithRequestCompression())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	small := &models.Credential{Name: "small", Type: "httpHeaderAuth", Data: map[string]interface{}{"value": "token"}}
+	large := &models.Credential{Name: "large", Type: "httpHeaderAuth", Data: map[string]interface{}{"value": strings.Repeat("x", compressMinSize)}}
+
+	for _, credential := range []*models.Credential{small, large} {
+		created, err := client.CreateCredential(context.Background(), credential)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if created.Name != credential.Name {
+			t.Errorf("Expected %s, got %s", credential.Name, created.Name)
+		}
+	}
+
+	if len(encodings) != 2 || encodings[0] != "" || encodings[1] != "gzip" {
+		t.Errorf("Expected only the large body to be compressed, got %q", encodings)
+	}
+}
+
+func TestResponsesAreDecompressed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			t.Errorf("Expected gzip to be accepted, got %q", r.Header.Get("Accept-Encoding"))
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		writer := gzip.NewWriter(w)
+		_, _ = writer.Write([]byte(`{"data":[{"id":"1","name":"example"}]}`))
+		_ = writer.Close()
+	}))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	workflows, err := client.ListWorkflows(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(workflows) != 1 || workflows[0].Name != "example" {
+		t.Errorf("Unexpected workflows: %+v", workflows)
+	}
+}
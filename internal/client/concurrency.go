@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// WithMaxConcurrency limits the number of requests in flight at the same time,
+// independently of Terraform's -parallelism. n8n instances backed by SQLite
+// fail under many concurrent writes.
+func WithMaxConcurrency(maxConcurrency int) Option {
+	return func(c *Client) error {
+		if maxConcurrency < 1 {
+			return fmt.Errorf("max_concurrent_requests must be at least 1")
+		}
+
+		c.slots = make(chan struct{}, maxConcurrency)
+		return nil
+	}
+}
+
+// acquire waits for a free request slot. It returns a function releasing the
+// slot, or the context error when the context ends first.
+func (c *Client) acquire(ctx context.Context) (func(), error) {
+	if c.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case c.slots <- struct{}{}:
+		return func() { <-c.slots }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("error waiting for a free request slot: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Doer sends HTTP requests. *http.Client implements it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// WithDoer sends all requests through the given Doer instead of the client's
+// own HTTP client, e.g. to record requests in tests or to reuse an existing
+// instrumented client. The Doer is responsible for its transport settings,
+// so options configuring the transport cannot be combined with it, and for
+// keeping cookies when the internal API is used.
+func WithDoer(doer Doer) Option {
+	return func(c *Client) error {
+		if doer == nil {
+			return fmt.Errorf("doer must not be nil")
+		}
+
+		c.doer = doer
+		return nil
+	}
+}
+
+// do sends the request through the configured Doer.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.doer != nil {
+		return c.doer.Do(req)
+	}
+	return c.client.Do(req)
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+)
+
+// recordingDoer records requests and answers them with canned responses.
+type recordingDoer struct {
+	requests  []*http.Request
+	bodies    []string
+	responses []*http.Response
+}
+
+func (d *recordingDoer) Do(req *http.Request) (*http.Response, error) {
+	body := ""
+	if req.Body != nil {
+		content, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = string(content)
+	}
+
+	d.requests = append(d.requests, req)
+	d.bodies = append(d.bodies, body)
+
+	resp := d.responses[0]
+	d.responses = d.responses[1:]
+	return resp, nil
+}
+
+func response(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestWithDoerBuildsRequests(t *testing.T) {
+	doer := &recordingDoer{responses: []*http.Response{
+		response(http.StatusOK, `{"id":"42","name":"example","type":"httpBasicAuth"}`),
+	}}
+
+	client, err := NewClient(stringPtr("https://n8n.example.com/"), stringPtr("test-api-key"), boolPtr(false),
+		WithDoer(doer), WithHeaders(map[string]string{"X-Tenant": "acme"}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	credential := &models.Credential{Name: "example", Type: "httpBasicAuth", Data: map[string]interface{}{"user": "admin"}}
+	if _, err := client.CreateCredential(context.Background(), credential); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(doer.requests) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(doer.requests))
+	}
+
+	req := doer.requests[0]
+	if req.Method != http.MethodPost || req.URL.String() != "https://n8n.example.com/api/v1/credentials" {
+		t.Errorf("Unexpected request %s %s", req.Method, req.URL)
+	}
+	if req.Header.Get("X-N8N-API-KEY") != "test-api-key" {
+		t.Errorf("Expected API key header, got %q", req.Header.Get("X-N8N-API-KEY"))
+	}
+	if req.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("Expected JSON content type, got %q", req.Header.Get("Content-Type"))
+	}
+	if req.Header.Get("X-Tenant") != "acme" {
+		t.Errorf("Expected extra header, got %q", req.Header.Get("X-Tenant"))
+	}
+	if doer.bodies[0] != `{"name":"example","type":"httpBasicAuth","data":{"user":"admin"}}` {
+		t.Errorf("Unexpected body: %s", doer.bodies[0])
+	}
+}
+
+func TestWithDoerMapsErrors(t *testing.T) {
+	doer := &recordingDoer{responses: []*http.Response{
+		response(http.StatusConflict, `{"message":"already exists"}`),
+	}}
+
+	client, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false), WithDoer(doer))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	err = client.TransferCredential(context.Background(), "42", "project-1")
+
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) || conflict.Message != "already exists" {
+		t.Errorf("Expected conflict error, got %v", err)
+	}
+}
+
+func TestWithDoerRejectsTransportOptions(t *testing.T) {
+	if _, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false),
+		WithDoer(&recordingDoer{}), WithProxy("http://proxy.example.com:3128")); err == nil {
+		t.Errorf("Expected error when combining a Doer with a proxy")
+	}
+	if _, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false), WithDoer(nil)); err == nil {
+		t.Errorf("Expected error for a nil Doer")
+	}
+}
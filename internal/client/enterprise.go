@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ErrEnterpriseSettingsUnavailable is returned when the instance does not
+// report its enterprise features, which recent n8n versions only do for
+// authenticated users of the internal API.
+var ErrEnterpriseSettingsUnavailable = errors.New("the instance settings do not report enterprise features")
+
+// GetEnterpriseSettings reads which enterprise features are licensed and
+// enabled from the frontend settings. The settings are read through the
+// internal API session when it is enabled, and without authentication
+// otherwise.
+func (c *Client) GetEnterpriseSettings(ctx context.Context) (*models.EnterpriseSettings, error) {
+	var settings settingsData
+	if c.internal != nil {
+		if err := c.getInternal(ctx, "settings", &settings); err != nil {
+			return nil, fmt.Errorf("error reading instance settings: %w", err)
+		}
+	} else {
+		req, err := newRequest(ctx, http.MethodGet, fmt.Sprintf("%s/rest/settings", c.Host), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var response settingsResponse
+		if _, err := c.executeDecode(req, &response); err != nil {
+			return nil, fmt.Errorf("error reading instance settings: %w", err)
+		}
+		settings = response.Data
+	}
+
+	if settings.Enterprise == nil {
+		return nil, ErrEnterpriseSettingsUnavailable
+	}
+	return settings.Enterprise, nil
+}
+
+// EnterpriseFeature is an n8n feature that requires an enterprise license.
+type EnterpriseFeature string
+
+// Enterprise features resources may depend on.
+const (
+	FeatureSharing         EnterpriseFeature = "sharing"
+	FeatureTeamProjects    EnterpriseFeature = "team projects"
+	FeatureVariables       EnterpriseFeature = "variables"
+	FeatureExternalSecrets EnterpriseFeature = "external secrets"
+	FeatureSourceControl   EnterpriseFeature = "source control"
+	FeatureSAML            EnterpriseFeature = "SAML"
+	FeatureLogStreaming    EnterpriseFeature = "log streaming"
+)
+
+// enabled reports whether the settings report the feature as licensed and
+// enabled. Unknown features are assumed to be enabled, so the API decides.
+func (f EnterpriseFeature) enabled(settings *models.EnterpriseSettings) bool {
+	switch f {
+	case FeatureSharing:
+		return settings.Sharing
+	case FeatureTeamProjects:
+		return settings.TeamProjects()
+	case FeatureVariables:
+		return settings.Variables
+	case FeatureExternalSecrets:
+		return settings.ExternalSecrets
+	case FeatureSourceControl:
+		return settings.SourceControl
+	case FeatureSAML:
+		return settings.SAML
+	case FeatureLogStreaming:
+		return settings.LogStreaming
+	default:
+		return true
+	}
+}
+
+// FeatureNotLicensedError is returned when an operation needs an enterprise
+// feature the instance does not have.
+type FeatureNotLicensedError struct {
+	Feature EnterpriseFeature
+}
+
+// Error implements the error interface.
+func (e *FeatureNotLicensedError) Error() string {
+	return fmt.Sprintf("feature %s not licensed on this instance", e.Feature)
+}
+
+// enterpriseSettingsCache holds the enterprise settings once read, so that
+// checking features for many resources reads the settings only once. Failed
+// reads are not cached, except when the instance does not report its
+// features at all.
+type enterpriseSettingsCache struct {
+	mu       sync.Mutex
+	settings *models.EnterpriseSettings
+	read     bool
+}
+
+// CheckEnterpriseFeature returns a *FeatureNotLicensedError when the instance
+// reports the feature as not licensed or disabled, so resources can fail
+// before making the requests n8n would reject. When the instance does not
+// report its features, e.g. without the internal API on recent versions, the
+// feature is assumed to be available and the API decides, as with Supports.
+func (c *Client) CheckEnterpriseFeature(ctx context.Context, feature EnterpriseFeature) error {
+	c.enterpriseSettings.mu.Lock()
+	defer c.enterpriseSettings.mu.Unlock()
+
+	if !c.enterpriseSettings.read {
+		settings, err := c.GetEnterpriseSettings(ctx)
+		if err != nil {
+			tflog.Debug(ctx, "Could not read enterprise features, leaving the check to the API", map[string]interface{}{
+				"feature": string(feature),
+				"error":   err.Error(),
+			})
+		}
+		// Other errors, such as a cancelled request or an unavailable
+		// instance, are retried on the next check.
+		if err == nil || errors.Is(err, ErrEnterpriseSettingsUnavailable) {
+			c.enterpriseSettings.settings = settings
+			c.enterpriseSettings.read = true
+		}
+	}
+
+	settings := c.enterpriseSettings.settings
+	if settings != nil && !feature.enabled(settings) {
+		return &FeatureNotLicensedError{Feature: feature}
+	}
+	return nil
+}
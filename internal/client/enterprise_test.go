@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetEnterpriseSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/settings" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"data":{"versionCli":"1.80.0","enterprise":{"sharing":true,"saml":true,"variables":true,"projects":{"team":{"limit":-1}}}}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	settings, err := client.GetEnterpriseSettings(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !settings.Sharing || !settings.Variables || settings.SourceControl || settings.ExternalSecrets {
+		t.Errorf("Unexpected settings: %+v", settings)
+	}
+	if !settings.TeamProjects() || !settings.SSO() {
+		t.Errorf("Expected unlimited team projects and SSO, got %+v", settings)
+	}
+}
+
+func TestGetEnterpriseSettingsUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"versionCli":"1.100.0"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.GetEnterpriseSettings(context.Background()); !errors.Is(err, ErrEnterpriseSettingsUnavailable) {
+		t.Errorf("Expected ErrEnterpriseSettingsUnavailable, got %v", err)
+	}
+}
+
+func TestCheckEnterpriseFeature(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/settings" {
+			requests.Add(1)
+		}
+		_, _ = w.Write([]byte(`{"data":{"versionCli":"1.80.0","enterprise":{"sharing":false,"variables":true}}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	before := requests.Load()
+
+	err = client.CheckEnterpriseFeature(context.Background(), FeatureSharing)
+	var notLicensed *FeatureNotLicensedError
+	if !errors.As(err, &notLicensed) || notLicensed.Feature != FeatureSharing {
+		t.Fatalf("Expected FeatureNotLicensedError for sharing, got %v", err)
+	}
+	if err.Error() != "feature sharing not licensed on this instance" {
+		t.Errorf("Unexpected error message: %s", err.Error())
+	}
+
+	if err := client.CheckEnterpriseFeature(context.Background(), FeatureVariables); err != nil {
+		t.Errorf("Expected variables to be licensed, got %v", err)
+	}
+	if got := requests.Load() - before; got != 1 {
+		t.Errorf("Expected the settings to be read once, got %d requests", got)
+	}
+}
+
+func TestCheckEnterpriseFeatureUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"versionCli":"1.100.0"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := client.CheckEnterpriseFeature(context.Background(), FeatureSharing); err != nil {
+		t.Errorf("Expected the check to be left to the API, got %v", err)
+	}
+}
+
+func TestCheckEnterpriseFeatureRetriesFailedRead(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"message":"unavailable"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"versionCli":"1.80.0","enterprise":{"sharing":false}}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := client.CheckEnterpriseFeature(context.Background(), FeatureSharing); err != nil {
+		t.Fatalf("Expected the check to be left to the API after a failed read, got %v", err)
+	}
+
+	err = client.CheckEnterpriseFeature(context.Background(), FeatureSharing)
+	var notLicensed *FeatureNotLicensedError
+	if !errors.As(err, &notLicensed) {
+		t.Errorf("Expected the settings to be read again, got %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected 2 requests, got %d", got)
+	}
+}
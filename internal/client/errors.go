@@ -0,0 +1,115 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// APIError is returned when n8n responds with a non-2xx status code. Responses
+// with a status code that callers commonly branch on are returned as one of
+// the more specific error types, which all unwrap to *APIError.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Message is the message of the n8n error body, or the raw body when it
+	// is not a JSON error object.
+	Message string
+	// Hint is the remediation hint of the n8n error body, if any.
+	Hint string
+	// Body is the raw response body.
+	Body string
+	// RequestID is the ID sent in the RequestIDHeader of the failed call.
+	RequestID string
+
+	cloud bool
+}
+
+// Error implements the error interface. The message and hint of n8n error
+// bodies are rendered without the stack trace and other fields n8n adds.
+func (e *APIError) Error() string {
+	detail := e.Message
+	if e.Hint != "" {
+		detail = fmt.Sprintf("%s (hint: %s)", detail, e.Hint)
+	}
+
+	if e.RequestID != "" {
+		detail = fmt.Sprintf("%s (request ID: %s)", detail, e.RequestID)
+	}
+
+	if e.cloud {
+		return fmt.Sprintf("API error (status %d) from n8n Cloud workspace: %s", e.StatusCode, detail)
+	}
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, detail)
+}
+
+// NotFoundError is returned for 404 responses.
+type NotFoundError struct{ *APIError }
+
+// Unwrap returns the underlying *APIError.
+func (e *NotFoundError) Unwrap() error { return e.APIError }
+
+// ConflictError is returned for 409 responses.
+type ConflictError struct{ *APIError }
+
+// Unwrap returns the underlying *APIError.
+func (e *ConflictError) Unwrap() error { return e.APIError }
+
+// UnauthorizedError is returned for 401 responses, usually caused by an
+// invalid or expired API key.
+type UnauthorizedError struct{ *APIError }
+
+// Unwrap returns the underlying *APIError.
+func (e *UnauthorizedError) Unwrap() error { return e.APIError }
+
+// RateLimitedError is returned for 429 responses.
+type RateLimitedError struct {
+	*APIError
+	// RetryAfter is the wait requested by the Retry-After header, or zero
+	// when the response did not include one.
+	RetryAfter time.Duration
+}
+
+// Unwrap returns the underlying *APIError.
+func (e *RateLimitedError) Unwrap() error { return e.APIError }
+
+// apiErrorBody is the error object returned by the n8n API. The internal API
+// and newer versions of the public API also return a hint and, in
+// development mode, the stack trace, which is not used.
+type apiErrorBody struct {
+	Message string `json:"message"`
+	Hint    string `json:"hint"`
+}
+
+// newAPIError builds the error for a non-2xx response.
+func newAPIError(statusCode int, header http.Header, body []byte, cloud bool) error {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Message:    string(body),
+		Body:       string(body),
+		cloud:      cloud,
+	}
+
+	var parsed apiErrorBody
+	if err := json.Unmarshal(body, &parsed); err == nil && parsed.Message != "" {
+		apiErr.Message = parsed.Message
+		apiErr.Hint = parsed.Hint
+	}
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return &NotFoundError{apiErr}
+	case http.StatusConflict:
+		return &ConflictError{apiErr}
+	case http.StatusUnauthorized:
+		return &UnauthorizedError{apiErr}
+	case http.StatusTooManyRequests:
+		return &RateLimitedError{
+			APIError:   apiErr,
+			RetryAfter: parseRetryAfter(header.Get("Retry-After"), time.Now()),
+		}
+	default:
+		return apiErr
+	}
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCode  int
+		body        string
+		wantMessage string
+		check       func(error) bool
+	}{
+		{
+			name:        "not found",
+			statusCode:  http.StatusNotFound,
+			body:        `{"message":"Not Found"}`,
+			wantMessage: "Not Found",
+			check:       func(err error) bool { var target *NotFoundError; return errors.As(err, &target) },
+		},
+		{
+			name:        "conflict",
+			statusCode:  http.StatusConflict,
+			body:        `{"message":"Credential already exists"}`,
+			wantMessage: "Credential already exists",
+			check:       func(err error) bool { var target *ConflictError; return errors.As(err, &target) },
+		},
+		{
+			name:        "unauthorized",
+			statusCode:  http.StatusUnauthorized,
+			body:        `{"message":"'X-N8N-API-KEY' header required"}`,
+			wantMessage: "'X-N8N-API-KEY' header required",
+			check:       func(err error) bool { var target *UnauthorizedError; return errors.As(err, &target) },
+		},
+		{
+			name:        "rate limited",
+			statusCode:  http.StatusTooManyRequests,
+			body:        "Too Many Requests",
+			wantMessage: "Too Many Requests",
+			check:       func(err error) bool { var target *RateLimitedError; return errors.As(err, &target) },
+		},
+		{
+			name:        "other status",
+			statusCode:  http.StatusBadRequest,
+			body:        `{"message":"request/body must have required property 'type'"}`,
+			wantMessage: "request/body must have required property 'type'",
+			check:       func(err error) bool { var target *NotFoundError; return !errors.As(err, &target) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newAPIError(tt.statusCode, http.Header{}, []byte(tt.body), false)
+
+			if !tt.check(err) {
+				t.Errorf("Unexpected error type %T", err)
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Expected *APIError, got %T", err)
+			}
+			if apiErr.StatusCode != tt.statusCode {
+				t.Errorf("Expected status %d, got %d", tt.statusCode, apiErr.StatusCode)
+			}
+			if apiErr.Message != tt.wantMessage {
+				t.Errorf("Expected message %q, got %q", tt.wantMessage, apiErr.Message)
+			}
+			if apiErr.Body != tt.body {
+				t.Errorf("Expected body %q, got %q", tt.body, apiErr.Body)
+			}
+		})
+	}
+}
+
+func TestDeleteCredentialReturnsNotFoundError(t *testing.T) {
+	var requestID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = r.Header.Get(RequestIDHeader)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	err = client.DeleteCredential(context.Background(), "42")
+
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Expected *NotFoundError, got %v", err)
+	}
+	if notFound.Error() != `API error (status 404): Not Found (request ID: `+requestID+`)` {
+		t.Errorf("Unexpected message: %s", notFound.Error())
+	}
+}
+
+func TestAPIErrorRendersMessageAndHint(t *testing.T) {
+	body := `{"code":400,"message":"'scope' must be a string","hint":"Check the scope of the OAuth2 credential","stacktrace":"Error: at CredentialsService.validate (/usr/local/lib/node_modules/n8n/dist/...)"}`
+
+	err := newAPIError(http.StatusBadRequest, http.Header{}, []byte(body), false)
+
+	expected := "API error (status 400): 'scope' must be a string (hint: Check the scope of the OAuth2 credential)"
+	if err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %T", err)
+	}
+	if apiErr.Body != body {
+		t.Errorf("Expected the raw body to be kept, got %q", apiErr.Body)
+	}
+
+	plain := newAPIError(http.StatusBadGateway, http.Header{}, []byte("<html>Bad Gateway</html>"), false)
+	if plain.Error() != "API error (status 502): <html>Bad Gateway</html>" {
+		t.Errorf("Expected bodies that are not JSON to be rendered as is, got %q", plain.Error())
+	}
+}
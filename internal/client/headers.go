@@ -0,0 +1,46 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ReservedHeaders lists the headers the client sets itself. Overriding them
+// would break authentication, request encoding or request correlation.
+var ReservedHeaders = []string{
+	"X-N8N-API-KEY",
+	"Content-Type",
+	"Content-Encoding",
+	"Cookie",
+	"User-Agent",
+	RequestIDHeader,
+}
+
+// IsReservedHeader reports whether the header is set by the client and cannot
+// be overridden.
+func IsReservedHeader(name string) bool {
+	for _, reserved := range ReservedHeaders {
+		if http.CanonicalHeaderKey(name) == http.CanonicalHeaderKey(reserved) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithHeaders attaches the given headers to every request, e.g. for
+// instances behind Cloudflare Access or a WAF. Reserved headers are rejected.
+func WithHeaders(headers map[string]string) Option {
+	return func(c *Client) error {
+		if c.headers == nil {
+			c.headers = make(http.Header, len(headers))
+		}
+
+		for name, value := range headers {
+			if IsReservedHeader(name) {
+				return fmt.Errorf("header %s is set by the provider and cannot be overridden", name)
+			}
+			c.headers.Set(name, value)
+		}
+		return nil
+	}
+}
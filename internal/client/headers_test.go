@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithHeaders(t *testing.T) {
+	var received http.Header
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithHeaders(map[string]string{
+		"cf-access-token": "token",
+		"X-Tenant":        "acme",
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.ListCredentials(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if received.Get("Cf-Access-Token") != "token" || received.Get("X-Tenant") != "acme" {
+		t.Errorf("Expected extra headers to be sent, got %v", received)
+	}
+	if received.Get("X-N8N-API-KEY") != "test-api-key" {
+		t.Errorf("Expected API key header to be kept, got %v", received)
+	}
+}
+
+func TestWithHeadersRejectsReservedHeaders(t *testing.T) {
+	for _, name := range []string{"x-n8n-api-key", "content-type", "Content-Encoding", "cookie", "User-Agent", "x-request-id"} {
+		_, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false), WithHeaders(map[string]string{
+			name: "other",
+		}))
+		if err == nil {
+			t.Errorf("Expected error when overriding the %s header", name)
+		}
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != "terraform-provider-n8n/1.2.3" {
+			t.Errorf("Unexpected User-Agent %q", r.Header.Get("User-Agent"))
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithUserAgent("terraform-provider-n8n/1.2.3"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.ListCredentials(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithUserAgent(" ")); err == nil {
+		t.Errorf("Expected error for an empty user agent")
+	}
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// RequestHook is called before every attempt of an API request, e.g. to start
+// an OpenTelemetry span named after req.Method and req.URL.Path. The returned
+// context replaces the request context for the attempt, so a hook can carry
+// its span and inject trace headers into req. The returned function is called
+// with the status code, zero when no response was received, and the error of
+// the attempt once it completes.
+type RequestHook func(ctx context.Context, req *http.Request) (context.Context, func(statusCode int, err error))
+
+// WithRequestHook adds a hook observing every request attempt. The provider
+// uses one to log the duration of each attempt; it does not depend on a
+// tracing SDK, so callers embedding the client, such as CI tooling exporting
+// traces, add their own.
+func WithRequestHook(hook RequestHook) Option {
+	return func(c *Client) error {
+		c.hooks = append(c.hooks, hook)
+		return nil
+	}
+}
+
+// startHooks runs the request hooks for an attempt and returns the request to
+// send and a function to call with its outcome.
+func (c *Client) startHooks(req *http.Request) (*http.Request, func(statusCode int, err error)) {
+	if len(c.hooks) == 0 {
+		return req, func(int, error) {}
+	}
+
+	ctx := req.Context()
+	finishers := make([]func(int, error), 0, len(c.hooks))
+	for _, hook := range c.hooks {
+		var finish func(int, error)
+		ctx, finish = hook(ctx, req)
+		if finish != nil {
+			finishers = append(finishers, finish)
+		}
+	}
+
+	return req.WithContext(ctx), func(statusCode int, err error) {
+		for i := len(finishers) - 1; i >= 0; i-- {
+			finishers[i](statusCode, err)
+		}
+	}
+}
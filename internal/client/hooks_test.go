@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithRequestHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Traceparent") == "" {
+			t.Errorf("Expected the hook to inject a trace header")
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	var started, finished []string
+	var finishedStatus int
+
+	hook := func(ctx context.Context, req *http.Request) (context.Context, func(int, error)) {
+		started = append(started, req.Method+" "+req.URL.Path)
+		req.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		return ctx, func(statusCode int, err error) {
+			finished = append(finished, req.Method+" "+req.URL.Path)
+			finishedStatus = statusCode
+			if err == nil {
+				t.Errorf("Expected the error of the attempt")
+			}
+		}
+	}
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithRequestHook(hook))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	_ = client.DeleteCredential(context.Background(), "42")
+
+	if len(started) != 1 || started[0] != "DELETE /api/v1/credentials/42" {
+		t.Errorf("Unexpected started requests: %v", started)
+	}
+	if len(finished) != 1 || finishedStatus != http.StatusNotFound {
+		t.Errorf("Unexpected finished requests: %v (status %d)", finished, finishedStatus)
+	}
+}
@@ -0,0 +1,193 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/cookiejar"
+	"sync"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+)
+
+// internalAPI holds the session state for n8n's internal REST API.
+//
+// The internal API (/rest) backs the n8n editor UI. It is not versioned and may
+// change between n8n releases, so it is only used when explicitly enabled and
+// only for operations the public API does not cover.
+type internalAPI struct {
+	email    string
+	password string
+
+	mu       sync.Mutex
+	loggedIn bool
+}
+
+// WithInternalAPI enables the internal REST API using session authentication
+// with the given user email and password.
+func WithInternalAPI(email, password string) Option {
+	return func(c *Client) error {
+		if email == "" || password == "" {
+			return fmt.Errorf("email and password are required to use the internal API")
+		}
+
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return fmt.Errorf("error creating cookie jar: %w", err)
+		}
+		c.client.Jar = jar
+
+		c.internal = &internalAPI{
+			email:    email,
+			password: password,
+		}
+		return nil
+	}
+}
+
+// InternalAPIEnabled reports whether the internal REST API may be used.
+func (c *Client) InternalAPIEnabled() bool {
+	return c.internal != nil
+}
+
+// login starts a session on the internal API. The session cookie is kept in the
+// client's cookie jar.
+func (c *Client) login(ctx context.Context) error {
+	c.internal.mu.Lock()
+	defer c.internal.mu.Unlock()
+
+	if c.internal.loggedIn {
+		return nil
+	}
+
+	body := loginRequest{
+		Email:              c.internal.email,
+		EmailOrLdapLoginID: c.internal.email,
+		Password:           c.internal.password,
+	}
+
+	req, err := newRequest(ctx, "POST", fmt.Sprintf("%s/rest/login", c.Host), body)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.execute(req); err != nil {
+		return fmt.Errorf("error logging in to internal API: %w", err)
+	}
+
+	c.internal.loggedIn = true
+	return nil
+}
+
+// logout forgets the internal API session, so the next request logs in again.
+func (c *Client) logout() {
+	c.internal.mu.Lock()
+	defer c.internal.mu.Unlock()
+
+	c.internal.loggedIn = false
+}
+
+// withSession runs do with an internal API session. When the session has
+// expired, e.g. because n8n restarted or the cookie timed out, it logs in
+// again and runs do once more.
+func (c *Client) withSession(ctx context.Context, do func() error) error {
+	if err := c.login(ctx); err != nil {
+		return err
+	}
+
+	err := do()
+	var unauthorized *UnauthorizedError
+	if !errors.As(err, &unauthorized) {
+		return err
+	}
+
+	c.logout()
+	if err := c.login(ctx); err != nil {
+		return err
+	}
+	return do()
+}
+
+// loginRequest is the request body for starting an internal API session.
+// Older n8n versions expect "email", newer ones "emailOrLdapLoginId".
+type loginRequest struct {
+	Email              string `json:"email"`
+	EmailOrLdapLoginID string `json:"emailOrLdapLoginId"`
+	Password           string `json:"password"`
+}
+
+// doInternalRequest performs an HTTP request to the n8n internal REST API.
+func (c *Client) doInternalRequest(ctx context.Context, method, endpoint string, body interface{}) ([]byte, error) {
+	if c.internal == nil {
+		return nil, fmt.Errorf("internal API is not enabled")
+	}
+
+	if err := c.checkWritable(method, endpoint); err != nil {
+		return nil, err
+	}
+
+	var respBody []byte
+	err := c.withSession(ctx, func() error {
+		req, err := newRequest(ctx, method, fmt.Sprintf("%s/rest/%s", c.Host, endpoint), body)
+		if err != nil {
+			return err
+		}
+
+		respBody, err = c.execute(req)
+		return err
+	})
+	return respBody, err
+}
+
+// internalResponse wraps responses from the internal REST API.
+type internalResponse struct {
+	Data json.RawMessage `json:"data"`
+}
+
+// getInternal performs a GET request against the internal API and decodes the
+// wrapped response data into out.
+func (c *Client) getInternal(ctx context.Context, endpoint string, out interface{}) error {
+	respBody, err := c.doInternalRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	var response internalResponse
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return fmt.Errorf("error unmarshaling response: %w", err)
+	}
+
+	if err := json.Unmarshal(response.Data, out); err != nil {
+		return fmt.Errorf("error unmarshaling response: %w", err)
+	}
+	return nil
+}
+
+// getInternalCredential retrieves a credential by ID through the internal REST API.
+func (c *Client) getInternalCredential(ctx context.Context, id string) (*models.Credential, error) {
+	var credential models.Credential
+	if err := c.getInternal(ctx, fmt.Sprintf("credentials/%s", id), &credential); err != nil {
+		return nil, err
+	}
+
+	return &credential, nil
+}
+
+// ShareCredential shares a credential with exactly the given projects, removing
+// any other shares. Users are shared with through their personal project.
+func (c *Client) ShareCredential(ctx context.Context, id string, projectIDs []string) error {
+	if c.internal == nil {
+		return fmt.Errorf("sharing credentials requires the internal API, set enable_internal_api in the provider configuration")
+	}
+
+	body := models.CredentialShareRequest{
+		ShareWithIDs: projectIDs,
+	}
+	if body.ShareWithIDs == nil {
+		body.ShareWithIDs = []string{}
+	}
+
+	_, err := c.doInternalRequest(ctx, "PUT", fmt.Sprintf("credentials/%s/share", id), body)
+	return err
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithInternalAPIRequiresCredentials(t *testing.T) {
+	_, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false), WithInternalAPI("", ""))
+	if err == nil {
+		t.Errorf("Expected error but got none")
+	}
+}
+
+func TestGetCredentialInternalAPIFallback(t *testing.T) {
+	logins := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/credentials/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	})
+	mux.HandleFunc("POST /rest/login", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Unexpected error decoding login body: %v", err)
+		}
+		if body["emailOrLdapLoginId"] != "owner@example.com" || body["password"] != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		logins++
+		http.SetCookie(w, &http.Cookie{Name: "n8n-auth", Value: "session", Path: "/"})
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET /rest/credentials/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := r.Cookie("n8n-auth"); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":"` + r.PathValue("id") + `","name":"example","type":"httpBasicAuth"}}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithInternalAPI("owner@example.com", "secret"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		credential, err := client.GetCredential(context.Background(), "42")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if credential.ID != "42" || credential.Name != "example" {
+			t.Errorf("Unexpected credential: %+v", credential)
+		}
+	}
+
+	if logins != 1 {
+		t.Errorf("Expected 1 login, got %d", logins)
+	}
+}
+
+func TestShareCredential(t *testing.T) {
+	var gotIDs []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /rest/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "n8n-auth", Value: "session", Path: "/"})
+	})
+	mux.HandleFunc("PUT /rest/credentials/{id}/share", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string][]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Unexpected error decoding body: %v", err)
+		}
+		gotIDs = body["shareWithIds"]
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	withoutInternal, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := withoutInternal.ShareCredential(context.Background(), "42", []string{"project-1"}); err == nil {
+		t.Errorf("Expected error without internal API but got none")
+	}
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithInternalAPI("owner@example.com", "secret"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := client.ShareCredential(context.Background(), "42", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if gotIDs == nil || len(gotIDs) != 0 {
+		t.Errorf("Expected empty share list to be sent, got %v", gotIDs)
+	}
+
+	if err := client.ShareCredential(context.Background(), "42", []string{"project-1", "project-2"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(gotIDs) != 2 || gotIDs[0] != "project-1" {
+		t.Errorf("Unexpected share list: %v", gotIDs)
+	}
+}
+
+func TestInternalAPISessionRenewal(t *testing.T) {
+	logins := 0
+	session := ""
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /rest/login", func(w http.ResponseWriter, r *http.Request) {
+		logins++
+		session = fmt.Sprintf("session-%d", logins)
+		http.SetCookie(w, &http.Cookie{Name: "n8n-auth", Value: session, Path: "/"})
+	})
+	mux.HandleFunc("GET /rest/credentials/{id}", func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("n8n-auth")
+		if err != nil || cookie.Value != session {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":"` + r.PathValue("id") + `","name":"example","type":"httpBasicAuth"}}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithInternalAPI("owner@example.com", "secret"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.getInternalCredential(context.Background(), "42"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Expire the session, as when n8n restarts.
+	session = "expired"
+
+	credential, err := client.getInternalCredential(context.Background(), "42")
+	if err != nil {
+		t.Fatalf("Expected the session to be renewed, got %v", err)
+	}
+	if credential.ID != "42" {
+		t.Errorf("Unexpected credential: %+v", credential)
+	}
+	if logins != 2 {
+		t.Errorf("Expected 2 logins, got %d", logins)
+	}
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redacted replaces secrets in logged requests and responses.
+const redacted = "***"
+
+// redactedHeaders lists headers that always carry secrets.
+var redactedHeaders = []string{"X-N8N-API-KEY", "Authorization", "Cookie", "Set-Cookie"}
+
+// redactedFields lists JSON fields whose values are secrets wherever they
+// appear in a body.
+var redactedFields = map[string]bool{
+	"password":  true,
+	"apiKey":    true,
+	"rawApiKey": true,
+}
+
+// logRequest logs the request at TRACE level with secrets redacted.
+func (c *Client) logRequest(ctx context.Context, req *http.Request) {
+	fields := map[string]interface{}{
+		"method":     req.Method,
+		"url":        req.URL.String(),
+		"request_id": req.Header.Get(RequestIDHeader),
+		"headers":    c.redactHeaders(req.Header),
+	}
+
+	if body, ok := requestBody(req); ok {
+		fields["body"] = redactBody(body, false)
+	}
+
+	tflog.Trace(ctx, "Sending n8n API request", fields)
+}
+
+// requestBody returns the uncompressed body of the request, if it can be read
+// again.
+func requestBody(req *http.Request) ([]byte, bool) {
+	if req.GetBody == nil {
+		return nil, false
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	content, err := io.ReadAll(body)
+	if err == nil {
+		content, err = decompressBody(req.Header, content)
+	}
+	return content, err == nil
+}
+
+// logResponse logs the response at TRACE level with secrets redacted. The body
+// of streamed responses, passed as nil, is not logged.
+func (c *Client) logResponse(ctx context.Context, req *http.Request, resp *http.Response, body []byte) {
+	fields := map[string]interface{}{
+		"method":     req.Method,
+		"url":        req.URL.String(),
+		"request_id": req.Header.Get(RequestIDHeader),
+		"status":     resp.StatusCode,
+		"headers":    c.redactHeaders(resp.Header),
+	}
+	if body != nil {
+		fields["body"] = redactBody(body, true)
+	}
+
+	tflog.Trace(ctx, "Received n8n API response", fields)
+}
+
+// redactHeaders renders the headers for logging. Headers carrying secrets and
+// headers configured with WithHeaders, which are sensitive in the provider
+// schema, are redacted.
+func (c *Client) redactHeaders(header http.Header) map[string]string {
+	rendered := make(map[string]string, len(header))
+	for name, values := range header {
+		rendered[name] = strings.Join(values, ", ")
+	}
+
+	for _, name := range redactedHeaders {
+		if header.Get(name) != "" {
+			rendered[http.CanonicalHeaderKey(name)] = redacted
+		}
+	}
+	for name := range c.headers {
+		if _, ok := rendered[name]; ok {
+			rendered[name] = redacted
+		}
+	}
+
+	return rendered
+}
+
+// redactBody renders a JSON body for logging with secrets redacted. Objects in
+// "data" fields hold credential secrets, except for the top-level "data" of
+// responses, which wraps the response of the internal API. Bodies that are
+// not JSON are logged as is.
+func redactBody(body []byte, response bool) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return string(body)
+	}
+
+	if object, ok := value.(map[string]interface{}); ok && response {
+		for key, field := range object {
+			if key == "data" {
+				object[key] = redactValue(field)
+			} else {
+				object[key] = redactField(key, field)
+			}
+		}
+	} else {
+		value = redactValue(value)
+	}
+
+	redactedBody, err := json.Marshal(value)
+	if err != nil {
+		return redacted
+	}
+	return string(redactedBody)
+}
+
+// redactValue redacts secrets in a decoded JSON value.
+func redactValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, field := range typed {
+			typed[key] = redactField(key, field)
+		}
+		return typed
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = redactValue(item)
+		}
+		return typed
+	default:
+		return value
+	}
+}
+
+// redactField redacts the value of a JSON object field when the field holds a
+// secret.
+func redactField(key string, value interface{}) interface{} {
+	if redactedFields[key] {
+		return redacted
+	}
+	if _, ok := value.(map[string]interface{}); ok && key == "data" {
+		return redacted
+	}
+	return redactValue(value)
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		response bool
+		want     string
+	}{
+		{
+			name: "credential request",
+			body: `{"name":"api","type":"httpBasicAuth","data":{"user":"admin","password":"secret"}}`,
+			want: `{"data":"***","name":"api","type":"httpBasicAuth"}`,
+		},
+		{
+			name: "login request",
+			body: `{"email":"admin@example.com","password":"secret"}`,
+			want: `{"email":"admin@example.com","password":"***"}`,
+		},
+		{
+			name:     "internal API response",
+			body:     `{"data":{"id":"42","name":"api","data":{"password":"secret"}}}`,
+			response: true,
+			want:     `{"data":{"data":"***","id":"42","name":"api"}}`,
+		},
+		{
+			name:     "list response",
+			body:     `{"data":[{"id":"42","name":"api"}],"nextCursor":null}`,
+			response: true,
+			want:     `{"data":[{"id":"42","name":"api"}],"nextCursor":null}`,
+		},
+		{
+			name:     "API key response",
+			body:     `{"data":{"id":"1","rawApiKey":"n8n_api_secret"}}`,
+			response: true,
+			want:     `{"data":{"id":"1","rawApiKey":"***"}}`,
+		},
+		{
+			name:     "not JSON",
+			body:     "Bad Gateway",
+			response: true,
+			want:     "Bad Gateway",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactBody([]byte(tt.body), tt.response); got != tt.want {
+				t.Errorf("redactBody() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	client, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false),
+		WithHeaders(map[string]string{"CF-Access-Client-Secret": "secret"}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	header := http.Header{}
+	header.Set("X-N8N-API-KEY", "test-api-key")
+	header.Set("CF-Access-Client-Secret", "secret")
+	header.Set("Content-Type", "application/json")
+
+	rendered := client.redactHeaders(header)
+	if rendered["X-N8n-Api-Key"] != redacted {
+		t.Errorf("Expected API key to be redacted, got %q", rendered["X-N8n-Api-Key"])
+	}
+	if rendered["Cf-Access-Client-Secret"] != redacted {
+		t.Errorf("Expected extra header to be redacted, got %q", rendered["Cf-Access-Client-Secret"])
+	}
+	if rendered["Content-Type"] != "application/json" {
+		t.Errorf("Expected Content-Type to be logged, got %q", rendered["Content-Type"])
+	}
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+)
+
+// nodeType is the subset of a node type description the provider inspects.
+type nodeType struct {
+	Name string `json:"name"`
+}
+
+// ListNodeTypes returns the names of the node types installed on the
+// instance, including those of community packages, e.g.
+// n8n-nodes-base.httpRequest. The list is served to the editor, so it
+// requires the internal API session.
+func (c *Client) ListNodeTypes(ctx context.Context) ([]string, error) {
+	if c.internal == nil {
+		return nil, fmt.Errorf("listing node types requires the internal API, set enable_internal_api in the provider configuration")
+	}
+
+	var nodeTypes []nodeType
+	err := c.withSession(ctx, func() error {
+		req, err := newRequest(ctx, "GET", fmt.Sprintf("%s/types/nodes.json", c.Host), nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.executeDecode(req, &nodeTypes)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing node types: %w", err)
+	}
+
+	names := make([]string, len(nodeTypes))
+	for i, t := range nodeTypes {
+		names[i] = t.Name
+	}
+	return names, nil
+}
+
+// MissingNodeTypes returns the node types used by the workflow that are not
+// installed on the instance, sorted and without duplicates, so a workflow
+// can be checked before it is uploaded.
+func (c *Client) MissingNodeTypes(ctx context.Context, workflow *models.Workflow) ([]string, error) {
+	nodes, err := workflow.ParseNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	installed, err := c.ListNodeTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	available := make(map[string]bool, len(installed))
+	for _, name := range installed {
+		available[name] = true
+	}
+
+	missing := make(map[string]bool)
+	for _, node := range nodes {
+		if !available[node.Type] {
+			missing[node.Type] = true
+		}
+	}
+
+	types := make([]string, 0, len(missing))
+	for name := range missing {
+		types = append(types, name)
+	}
+	sort.Strings(types)
+	return types, nil
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+)
+
+func TestMissingNodeTypes(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /rest/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "n8n-auth", Value: "session", Path: "/"})
+	})
+	mux.HandleFunc("GET /types/nodes.json", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := r.Cookie("n8n-auth"); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"name":"n8n-nodes-base.manualTrigger","displayName":"Manual Trigger"},
+			{"name":"n8n-nodes-base.httpRequest","displayName":"HTTP Request"}
+		]`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	withoutInternal, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := withoutInternal.ListNodeTypes(context.Background()); err == nil {
+		t.Errorf("Expected error without internal API but got none")
+	}
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithInternalAPI("owner@example.com", "secret"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	workflow := &models.Workflow{
+		ID: "1",
+		Nodes: json.RawMessage(`[
+			{"name":"Start","type":"n8n-nodes-base.manualTrigger"},
+			{"name":"Fetch","type":"n8n-nodes-base.httpRequest"},
+			{"name":"Create contact","type":"@acme/n8n-nodes-crm.contact"},
+			{"name":"Update contact","type":"@acme/n8n-nodes-crm.contact"},
+			{"name":"Ask","type":"@n8n/n8n-nodes-langchain.agent"}
+		]`),
+	}
+
+	missing, err := client.MissingNodeTypes(context.Background(), workflow)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"@acme/n8n-nodes-crm.contact", "@n8n/n8n-nodes-langchain.agent"}
+	if len(missing) != len(expected) || missing[0] != expected[0] || missing[1] != expected[1] {
+		t.Errorf("Expected %v, got %v", expected, missing)
+	}
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Operation names a long-running operation with its own timeout.
+type Operation string
+
+// Long-running operations whose requests are not bound to DefaultTimeout.
+const (
+	OperationActivateWorkflow Operation = "activate_workflow"
+)
+
+// defaultOperationTimeouts are the timeouts of long-running operations unless
+// overridden with WithOperationTimeout.
+var defaultOperationTimeouts = map[Operation]time.Duration{
+	OperationActivateWorkflow: 2 * time.Minute,
+}
+
+// pollInterval is the wait between checks for the completion of an operation.
+const pollInterval = time.Second
+
+// WithOperationTimeout overrides the timeout of a long-running operation.
+func WithOperationTimeout(operation Operation, timeout time.Duration) Option {
+	return func(c *Client) error {
+		if _, ok := defaultOperationTimeouts[operation]; !ok {
+			return fmt.Errorf("unknown operation %q", operation)
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("timeout of %s must be positive", operation)
+		}
+
+		if c.operationTimeouts == nil {
+			c.operationTimeouts = make(map[Operation]time.Duration)
+		}
+		c.operationTimeouts[operation] = timeout
+		return nil
+	}
+}
+
+// operationContext bounds the context by the timeout of the operation. A
+// deadline of the caller, e.g. from the resource timeouts, still applies.
+func (c *Client) operationContext(ctx context.Context, operation Operation) (context.Context, context.CancelFunc) {
+	timeout, ok := c.operationTimeouts[operation]
+	if !ok {
+		timeout = defaultOperationTimeouts[operation]
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// poll calls check until it reports completion, returns an error or the
+// context ends.
+func poll(ctx context.Context, check func() (bool, error)) error {
+	for {
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the operation to complete: %w", ctx.Err())
+		case <-time.After(pollInterval):
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestActivateWorkflowPollsUntilActive(t *testing.T) {
+	gets := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/workflows/1/activate", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"1","active":false}`))
+	})
+	mux.HandleFunc("GET /api/v1/workflows/1", func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		_, _ = w.Write([]byte(`{"id":"1","active":true}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	workflow, err := client.ActivateWorkflow(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !workflow.Active || gets != 1 {
+		t.Errorf("Expected active workflow after 1 poll, got active %v after %d polls", workflow.Active, gets)
+	}
+}
+
+func TestActivateWorkflowTimesOut(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/workflows/1/activate", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"1","active":false}`))
+	})
+	mux.HandleFunc("GET /api/v1/workflows/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"1","active":false}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false),
+		WithOperationTimeout(OperationActivateWorkflow, 50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.ActivateWorkflow(context.Background(), "1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestActivateWorkflowReportsActivationError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /rest/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "n8n-auth", Value: "session", Path: "/"})
+	})
+	mux.HandleFunc("POST /api/v1/workflows/{id}/activate", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"id":%q,"active":true}`, r.PathValue("id"))
+	})
+	mux.HandleFunc("GET /rest/active-workflows/error/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "broken" {
+			_, _ = w.Write([]byte(`{"data":{"id":"broken","error":"Credentials could not be found"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithInternalAPI("owner@example.com", "secret"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.ActivateWorkflow(context.Background(), "healthy"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	_, err = client.ActivateWorkflow(context.Background(), "broken")
+	if err == nil || !strings.Contains(err.Error(), "Credentials could not be found") {
+		t.Errorf("Expected the activation error to be reported, got %v", err)
+	}
+}
+
+func TestWithOperationTimeoutValidation(t *testing.T) {
+	if _, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false),
+		WithOperationTimeout("unknown", time.Minute)); err == nil {
+		t.Errorf("Expected error for an unknown operation")
+	}
+	if _, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false),
+		WithOperationTimeout(OperationActivateWorkflow, 0)); err == nil {
+		t.Errorf("Expected error for a zero timeout")
+	}
+}
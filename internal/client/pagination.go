@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultPageSize is the number of objects requested per page unless
+	// configured otherwise. It is the default of the public API.
+	DefaultPageSize = 100
+	// MaxPageSize is the largest page the public API returns.
+	MaxPageSize = 250
+)
+
+// pageSizeKey is the context key of the page size of a single list call.
+type pageSizeKey struct{}
+
+// WithPageSize sets the number of objects requested per page by list calls.
+// Larger pages need fewer requests on large instances, smaller pages keep
+// each response small.
+func WithPageSize(pageSize int) Option {
+	return func(c *Client) error {
+		if err := validatePageSize(pageSize); err != nil {
+			return err
+		}
+		c.pageSize = pageSize
+		return nil
+	}
+}
+
+// ContextWithPageSize returns a context whose list calls request pages of
+// the given size, overriding the page size of the client, e.g. for a single
+// data source.
+func ContextWithPageSize(ctx context.Context, pageSize int) (context.Context, error) {
+	if err := validatePageSize(pageSize); err != nil {
+		return ctx, err
+	}
+	return context.WithValue(ctx, pageSizeKey{}, pageSize), nil
+}
+
+// validatePageSize checks that the public API accepts the page size.
+func validatePageSize(pageSize int) error {
+	if pageSize < 1 || pageSize > MaxPageSize {
+		return fmt.Errorf("page size must be between 1 and %d, got %d", MaxPageSize, pageSize)
+	}
+	return nil
+}
+
+// listPageSize returns the page size for a list call.
+func (c *Client) listPageSize(ctx context.Context) int {
+	if pageSize, ok := ctx.Value(pageSizeKey{}).(int); ok {
+		return pageSize
+	}
+	if c.pageSize > 0 {
+		return c.pageSize
+	}
+	return DefaultPageSize
+}
+
+// listPages requests all pages of a list endpoint of the public API, following
+// the nextCursor of each page, with the page size of the client or context.
+// fetch is called with the endpoint of every page and returns the cursor of
+// the next page, or an empty string after the last page.
+func (c *Client) listPages(ctx context.Context, endpoint string, fetch func(pageEndpoint string) (string, error)) error {
+	pageSize := strconv.Itoa(c.listPageSize(ctx))
+
+	cursor := ""
+	for {
+		query := url.Values{}
+		query.Set("limit", pageSize)
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		separator := "?"
+		if strings.Contains(endpoint, "?") {
+			separator = "&"
+		}
+
+		next, err := fetch(endpoint + separator + query.Encode())
+		if err != nil {
+			return err
+		}
+		if next == "" || next == cursor {
+			return nil
+		}
+		cursor = next
+	}
+}
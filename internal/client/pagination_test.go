@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListCredentialsFollowsCursor(t *testing.T) {
+	var cursors []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/credentials", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "100" {
+			t.Errorf("Expected limit 100, got %q", r.URL.Query().Get("limit"))
+		}
+
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+
+		switch cursor {
+		case "":
+			_, _ = w.Write([]byte(`{"data":[{"id":"1"},{"id":"2"}],"nextCursor":"page-2"}`))
+		case "page-2":
+			_, _ = w.Write([]byte(`{"data":[{"id":"3"}],"nextCursor":null}`))
+		default:
+			t.Errorf("Unexpected cursor %q", cursor)
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	credentials, err := client.ListCredentials(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(credentials) != 3 || credentials[2].ID != "3" {
+		t.Errorf("Expected 3 credentials, got %+v", credentials)
+	}
+	if len(cursors) != 2 {
+		t.Errorf("Expected 2 pages, got %d", len(cursors))
+	}
+
+	// The fallback lookup of GetCredential finds credentials on later pages.
+	credential, err := client.GetCredential(context.Background(), "3")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if credential.ID != "3" {
+		t.Errorf("Expected credential 3, got %s", credential.ID)
+	}
+}
+
+func TestListWorkflowsFollowsCursor(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/workflows", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"data":[{"id":"1"}],"nextCursor":"next"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"2"}]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	workflows, err := client.ListWorkflows(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(workflows) != 2 {
+		t.Errorf("Expected 2 workflows, got %d", len(workflows))
+	}
+}
+
+func TestListPageSize(t *testing.T) {
+	var limits []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/workflows", func(w http.ResponseWriter, r *http.Request) {
+		limits = append(limits, r.URL.Query().Get("limit"))
+		_, _ = w.Write([]byte(`{"data":[],"nextCursor":null}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithPageSize(250))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.ListWorkflows(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx, err := ContextWithPageSize(context.Background(), 20)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.ListWorkflows(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(limits) != 2 || limits[0] != "250" || limits[1] != "20" {
+		t.Errorf("Expected the client and then the context page size, got %v", limits)
+	}
+
+	for _, pageSize := range []int{0, MaxPageSize + 1} {
+		if _, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithPageSize(pageSize)); err == nil {
+			t.Errorf("Expected an error for page size %d", pageSize)
+		}
+		if _, err := ContextWithPageSize(context.Background(), pageSize); err == nil {
+			t.Errorf("Expected an error for context page size %d", pageSize)
+		}
+	}
+}
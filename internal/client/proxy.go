@@ -0,0 +1,28 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// WithProxy sends all requests through the given proxy instead of the one
+// configured by the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func WithProxy(proxyURL string) Option {
+	return func(c *Client) error {
+		parsed, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		if parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("invalid proxy URL %q: scheme and host are required", proxyURL)
+		}
+
+		transport, err := c.transport()
+		if err != nil {
+			return err
+		}
+		transport.Proxy = http.ProxyURL(parsed)
+		return nil
+	}
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithProxy(t *testing.T) {
+	var proxiedHost string
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer proxy.Close()
+
+	client, err := NewClient(stringPtr("http://n8n.internal.example.com"), stringPtr("test-api-key"), boolPtr(false), WithProxy(proxy.URL))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.ListCredentials(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if proxiedHost != "n8n.internal.example.com" {
+		t.Errorf("Expected request to be sent through the proxy, got host %q", proxiedHost)
+	}
+}
+
+func TestWithProxyInvalid(t *testing.T) {
+	if _, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false), WithProxy("proxy.example.com")); err == nil {
+		t.Errorf("Expected error for proxy URL without scheme")
+	}
+}
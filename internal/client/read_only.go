@@ -0,0 +1,33 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrReadOnly is returned for mutating requests when the client is read-only.
+var ErrReadOnly = errors.New("the provider is configured as read_only")
+
+// WithReadOnly refuses all requests that could change the instance, so plans
+// can safely be run with credentials that must not modify anything.
+func WithReadOnly() Option {
+	return func(c *Client) error {
+		c.readOnly = true
+		return nil
+	}
+}
+
+// checkWritable returns ErrReadOnly for mutating methods on a read-only client.
+func (c *Client) checkWritable(method, endpoint string) error {
+	if !c.readOnly {
+		return nil
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return nil
+	default:
+		return fmt.Errorf("refusing %s %s: %w", method, endpoint, ErrReadOnly)
+	}
+}
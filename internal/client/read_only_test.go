@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+)
+
+func TestWithReadOnly(t *testing.T) {
+	var methods []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithReadOnly())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.ListCredentials(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.CreateCredential(context.Background(), &models.Credential{Name: "example"}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly on create, got %v", err)
+	}
+	if err := client.DeleteCredential(context.Background(), "42"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly on delete, got %v", err)
+	}
+
+	if len(methods) != 1 || methods[0] != http.MethodGet {
+		t.Errorf("Expected only the GET request to be sent, got %v", methods)
+	}
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// RequestIDHeader is the header carrying the ID generated for every API call.
+// Reverse proxies in front of n8n commonly log it, so failures reported in
+// diagnostics can be found in the server logs.
+const RequestIDHeader = "X-Request-Id"
+
+// setRequestID sets a new request ID on the request and returns it. Retries
+// of the call share the ID.
+func setRequestID(req *http.Request) string {
+	id := newRequestID()
+	req.Header.Set(RequestIDHeader, id)
+	return id
+}
+
+// newRequestID returns a random version 4 UUID.
+func newRequestID() string {
+	var id [16]byte
+	//nolint:errcheck // crypto/rand.Read never returns an error
+	_, _ = rand.Read(id[:])
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:])
+}
+
+// withRequestID attaches the request ID to the error of a failed call. API
+// errors carry it in RequestID; other errors, e.g. timeouts, are wrapped.
+func withRequestID(err error, id string) error {
+	if err == nil || id == "" {
+		return err
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		apiErr.RequestID = id
+		return err
+	}
+	return fmt.Errorf("%w (request ID: %s)", err, id)
+}
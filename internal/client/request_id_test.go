@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRequestIDSentAndReported(t *testing.T) {
+	var requestIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestIDs = append(requestIDs, r.Header.Get(RequestIDHeader))
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithRetry(1, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	_, err = client.ListCredentials(context.Background())
+
+	if len(requestIDs) != 2 || requestIDs[0] != requestIDs[1] {
+		t.Fatalf("Expected the retry to share the request ID, got %v", requestIDs)
+	}
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(requestIDs[0]) {
+		t.Errorf("Expected a UUID request ID, got %q", requestIDs[0])
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RequestID != requestIDs[0] {
+		t.Fatalf("Expected an API error with request ID %s, got %v", requestIDs[0], err)
+	}
+	if !strings.Contains(err.Error(), "request ID: "+requestIDs[0]) {
+		t.Errorf("Expected the request ID in the error, got %q", err.Error())
+	}
+
+	if _, err := client.ListCredentials(context.Background()); err == nil || requestIDs[2] == requestIDs[0] {
+		t.Errorf("Expected a new request ID per call, got %v", requestIDs)
+	}
+}
+
+func TestWithRequestIDWrapsOtherErrors(t *testing.T) {
+	err := withRequestID(context.DeadlineExceeded, "abc")
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.HasSuffix(err.Error(), "(request ID: abc)") {
+		t.Errorf("Expected the error wrapped with the request ID, got %v", err)
+	}
+	if withRequestID(nil, "abc") != nil {
+		t.Error("Expected no error for a successful call")
+	}
+}
@@ -0,0 +1,177 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Default retry policy applied by the provider unless configured otherwise.
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryMinWait = 1 * time.Second
+	DefaultRetryMaxWait = 30 * time.Second
+)
+
+// maxRetryAfter caps the wait requested by a Retry-After header. Rate limited
+// requests asking for a longer wait fail instead of stalling the apply.
+const maxRetryAfter = 2 * time.Minute
+
+// retryPolicy controls how transient failures are retried. The zero value
+// disables retries.
+type retryPolicy struct {
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+// WithRetry retries transient failures up to maxRetries times, waiting
+// exponentially longer between attempts, from minWait up to maxWait.
+func WithRetry(maxRetries int, minWait, maxWait time.Duration) Option {
+	return func(c *Client) error {
+		if maxRetries < 0 {
+			return fmt.Errorf("max_retries must not be negative")
+		}
+		if minWait > maxWait {
+			return fmt.Errorf("retry_min_wait (%s) must not be greater than retry_max_wait (%s)", minWait, maxWait)
+		}
+
+		c.retry = retryPolicy{
+			maxRetries: maxRetries,
+			minWait:    minWait,
+			maxWait:    maxWait,
+		}
+		return nil
+	}
+}
+
+// noRetryKey is the context key disabling retries.
+type noRetryKey struct{}
+
+// contextWithoutRetries returns a context whose requests are sent once,
+// whatever the retry policy of the client, e.g. for best effort requests.
+func contextWithoutRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey{}, true)
+}
+
+// retriesDisabled reports whether retries are disabled for the context.
+func retriesDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(noRetryKey{}).(bool)
+	return disabled
+}
+
+// backoff returns the wait before the retry following the given attempt.
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	wait := p.minWait
+	for i := 0; i < attempt && wait < p.maxWait; i++ {
+		wait *= 2
+	}
+	if wait > p.maxWait {
+		wait = p.maxWait
+	}
+	return wait
+}
+
+// jitteredBackoff returns a random wait between half and all of the backoff,
+// so clients failing at the same time don't retry in lockstep.
+func (p retryPolicy) jitteredBackoff(attempt int) time.Duration {
+	wait := p.backoff(attempt)
+	if wait <= 1 {
+		return wait
+	}
+	return wait/2 + rand.N(wait/2+1)
+}
+
+// wait returns the wait before the retry following the given attempt and
+// whether to retry at all. Rate limited responses wait as long as n8n asks
+// for in the Retry-After header, up to maxRetryAfter, or back off without
+// jitter when it doesn't say. Server errors and connection failures back off
+// with jitter.
+func (p retryPolicy) wait(attempt int, err error) (time.Duration, bool) {
+	var rateLimited *RateLimitedError
+	if errors.As(err, &rateLimited) {
+		if rateLimited.RetryAfter > maxRetryAfter {
+			return 0, false
+		}
+		if rateLimited.RetryAfter > 0 {
+			return rateLimited.RetryAfter, true
+		}
+		return p.backoff(attempt), true
+	}
+	return p.jitteredBackoff(attempt), true
+}
+
+// parseRetryAfter parses the value of a Retry-After header, given either as
+// seconds or as an HTTP date. It returns zero when the value is missing or
+// invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
+
+// shouldRetry reports whether a failed attempt is transient. Rate limited
+// requests, as returned by n8n Cloud, are always retried. Connection
+// failures, internal server errors, bad gateways and gateway timeouts may
+// happen after the request reached n8n, so they are only retried for
+// idempotent methods.
+func shouldRetry(method string, statusCode int, err error) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout, 0:
+		return err != nil && isIdempotent(method)
+	default:
+		return false
+	}
+}
+
+// mayHaveSucceeded reports whether a failed request may nevertheless have
+// been processed by n8n, because the connection failed, n8n failed after
+// processing it or a gateway gave up after the request was forwarded.
+func mayHaveSucceeded(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+			return true
+		}
+	}
+
+	return false
+}
+
+// isIdempotent reports whether repeating a request with the method is safe.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
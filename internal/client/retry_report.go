@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// retryReportKey is the context key of the retry report.
+type retryReportKey struct{}
+
+// RetryReport collects the retries of API calls that succeeded after being
+// retried, e.g. because n8n was rate limiting or briefly unavailable. Calls
+// that fail are reported through their error instead.
+type RetryReport struct {
+	mu      sync.Mutex
+	calls   int
+	retries int
+	wait    time.Duration
+	reasons map[string]int
+}
+
+// ContextWithRetryReport returns a context whose API calls record their
+// retries in the returned report, e.g. to warn about them once a resource
+// operation completes.
+func ContextWithRetryReport(ctx context.Context) (context.Context, *RetryReport) {
+	report := &RetryReport{reasons: make(map[string]int)}
+	return context.WithValue(ctx, retryReportKey{}, report), report
+}
+
+// retryReportFromContext returns the retry report of the context, or nil.
+func retryReportFromContext(ctx context.Context) *RetryReport {
+	report, _ := ctx.Value(retryReportKey{}).(*RetryReport)
+	return report
+}
+
+// retryAttempt is a retry of a call: the reason for it and the wait before it.
+type retryAttempt struct {
+	reason string
+	wait   time.Duration
+}
+
+// retryReason describes why an attempt was retried, e.g. "status 429" or
+// "connection failure" when no response was received.
+func retryReason(statusCode int) string {
+	if statusCode == 0 {
+		return "connection failure"
+	}
+	return fmt.Sprintf("status %d %s", statusCode, http.StatusText(statusCode))
+}
+
+// record adds the retries of a call that succeeded.
+func (r *RetryReport) record(retries []retryAttempt) {
+	if r == nil || len(retries) == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls++
+	for _, retry := range retries {
+		r.retries++
+		r.wait += retry.wait
+		r.reasons[retry.reason]++
+	}
+}
+
+// Retries returns the number of retries recorded.
+func (r *RetryReport) Retries() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.retries
+}
+
+// Summary describes the recorded retries, e.g. "2 API calls succeeded after
+// 3 retries, waiting 4s in total (status 429 Too Many Requests: 2,
+// status 503 Service Unavailable: 1)".
+func (r *RetryReport) Summary() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reasons := make([]string, 0, len(r.reasons))
+	for reason, count := range r.reasons {
+		reasons = append(reasons, fmt.Sprintf("%s: %d", reason, count))
+	}
+	sort.Strings(reasons)
+
+	return fmt.Sprintf("%d API %s succeeded after %d %s, waiting %s in total (%s)",
+		r.calls, plural(r.calls, "call", "calls"),
+		r.retries, plural(r.retries, "retry", "retries"),
+		r.wait.Round(time.Millisecond), strings.Join(reasons, ", "))
+}
+
+// plural returns singular when count is one, and plural otherwise.
+func plural(count int, singular, plural string) string {
+	if count == 1 {
+		return singular
+	}
+	return plural
+}
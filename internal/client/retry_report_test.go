@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestContextWithRetryReport(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch attempts {
+		case 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithRetry(3, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx, report := ContextWithRetryReport(context.Background())
+	if _, err := client.ListCredentials(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.ListCredentials(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if report.Retries() != 2 {
+		t.Fatalf("Expected 2 retries, got %d", report.Retries())
+	}
+	expected := "1 API call succeeded after 2 retries, waiting 0s in total " +
+		"(status 429 Too Many Requests: 1, status 503 Service Unavailable: 1)"
+	if report.Summary() != expected {
+		t.Errorf("Expected %q, got %q", expected, report.Summary())
+	}
+}
+
+func TestRetryReportIgnoresFailedCalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithRetry(2, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx, report := ContextWithRetryReport(context.Background())
+	if _, err := client.ListCredentials(ctx); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if report.Retries() != 0 {
+		t.Errorf("Expected the retries of failed calls to be left to their error, got %d", report.Retries())
+	}
+}
@@ -0,0 +1,226 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+)
+
+func TestExecuteRetriesTransientFailures(t *testing.T) {
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"1","name":"example","type":"httpBasicAuth"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithRetry(3, time.Millisecond, time.Millisecond))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.GetCredential(context.Background(), "1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestExecuteGivesUpAfterMaxRetries(t *testing.T) {
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithRetry(2, time.Millisecond, time.Millisecond))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := client.DeleteCredential(context.Background(), "1"); err == nil {
+		t.Errorf("Expected error but got none")
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestWithRetryValidation(t *testing.T) {
+	if _, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false), WithRetry(-1, 0, 0)); err == nil {
+		t.Errorf("Expected error for negative max retries")
+	}
+	if _, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false), WithRetry(1, time.Minute, time.Second)); err == nil {
+		t.Errorf("Expected error when min wait exceeds max wait")
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := retryPolicy{maxRetries: 5, minWait: time.Second, maxWait: 5 * time.Second}
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for attempt, expected := range want {
+		if got := policy.backoff(attempt); got != expected {
+			t.Errorf("backoff(%d) = %s, want %s", attempt, got, expected)
+		}
+	}
+}
+
+func TestRetryPolicyJitteredBackoff(t *testing.T) {
+	policy := retryPolicy{maxRetries: 5, minWait: time.Second, maxWait: 8 * time.Second}
+
+	for attempt := 0; attempt < 5; attempt++ {
+		backoff := policy.backoff(attempt)
+		for i := 0; i < 100; i++ {
+			if got := policy.jitteredBackoff(attempt); got < backoff/2 || got > backoff {
+				t.Fatalf("jitteredBackoff(%d) = %s, want between %s and %s", attempt, got, backoff/2, backoff)
+			}
+		}
+	}
+
+	if got := (retryPolicy{}).jitteredBackoff(0); got != 0 {
+		t.Errorf("Expected no wait without a minimum wait, got %s", got)
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	connectionError := errors.New("connection reset by peer")
+
+	tests := []struct {
+		name       string
+		method     string
+		statusCode int
+		err        error
+		want       bool
+	}{
+		{name: "service unavailable", method: http.MethodPost, statusCode: http.StatusServiceUnavailable, err: connectionError, want: true},
+		{name: "internal server error on GET", method: http.MethodGet, statusCode: http.StatusInternalServerError, err: connectionError, want: true},
+		{name: "internal server error on POST", method: http.MethodPost, statusCode: http.StatusInternalServerError, err: connectionError, want: false},
+		{name: "bad gateway on GET", method: http.MethodGet, statusCode: http.StatusBadGateway, err: connectionError, want: true},
+		{name: "bad gateway on POST", method: http.MethodPost, statusCode: http.StatusBadGateway, err: connectionError, want: false},
+		{name: "gateway timeout on GET", method: http.MethodGet, statusCode: http.StatusGatewayTimeout, err: connectionError, want: true},
+		{name: "gateway timeout on POST", method: http.MethodPost, statusCode: http.StatusGatewayTimeout, err: connectionError, want: false},
+		{name: "connection error on DELETE", method: http.MethodDelete, err: connectionError, want: true},
+		{name: "connection error on POST", method: http.MethodPost, err: connectionError, want: false},
+		{name: "bad request", method: http.MethodGet, statusCode: http.StatusBadRequest, err: connectionError, want: false},
+		{name: "success", method: http.MethodGet, statusCode: http.StatusOK, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldRetry(tt.method, tt.statusCode, tt.err); got != tt.want {
+				t.Errorf("shouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteStopsRetryingWhenContextIsCanceled(t *testing.T) {
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithRetry(3, time.Hour, time.Hour))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := client.DeleteCredential(ctx, "1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "", want: 0},
+		{value: "5", want: 5 * time.Second},
+		{value: "-1", want: 0},
+		{value: "Wed, 01 Jan 2025 12:00:30 GMT", want: 30 * time.Second},
+		{value: "Wed, 01 Jan 2025 11:59:00 GMT", want: 0},
+		{value: "soon", want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicyWaitHonorsRetryAfter(t *testing.T) {
+	policy := retryPolicy{maxRetries: 3, minWait: time.Second, maxWait: 5 * time.Second}
+
+	rateLimited := &RateLimitedError{APIError: &APIError{StatusCode: http.StatusTooManyRequests}, RetryAfter: time.Minute}
+	if wait, ok := policy.wait(0, rateLimited); !ok || wait != time.Minute {
+		t.Errorf("Expected to wait 1m, got %s (retry %v)", wait, ok)
+	}
+
+	rateLimited.RetryAfter = time.Hour
+	if _, ok := policy.wait(0, rateLimited); ok {
+		t.Errorf("Expected no retry when Retry-After exceeds the cap")
+	}
+
+	rateLimited.RetryAfter = 0
+	if wait, ok := policy.wait(1, rateLimited); !ok || wait != 2*time.Second {
+		t.Errorf("Expected to wait 2s, got %s (retry %v)", wait, ok)
+	}
+
+	if wait, ok := policy.wait(1, errors.New("connection reset by peer")); !ok || wait < time.Second || wait > 2*time.Second {
+		t.Errorf("Expected to wait between 1s and 2s, got %s (retry %v)", wait, ok)
+	}
+}
+
+func TestExecuteRetriesRateLimitedRequests(t *testing.T) {
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"1","name":"example","type":"httpBasicAuth"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithRetry(3, time.Millisecond, time.Millisecond))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.CreateCredential(context.Background(), &models.Credential{Name: "example", Type: "httpBasicAuth"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+}
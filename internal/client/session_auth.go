@@ -0,0 +1,239 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// sessionAPIKeyLabelPrefix starts the labels of the API keys the provider
+	// creates for itself.
+	sessionAPIKeyLabelPrefix = "terraform-provider-n8n"
+	// sessionAPIKeyLifetime is how long API keys created for session
+	// authentication remain valid. Keys outliving a long apply are replaced.
+	sessionAPIKeyLifetime = time.Hour
+)
+
+// errSessionAPIKeyReadOnly is returned for public API requests of read-only
+// clients authenticating with a user session, which cannot create an API key.
+var errSessionAPIKeyReadOnly = fmt.Errorf("the public API requires an API key, which is not created with session authentication: %w", ErrReadOnly)
+
+// sessionAPIKey is the API key a client authenticating with a user session
+// creates for the public API. The label is unique to the client, so runs
+// sharing a user never revoke each other's keys.
+type sessionAPIKey struct {
+	label string
+
+	mu  sync.Mutex
+	id  string
+	key string
+}
+
+// WithSessionAuth authenticates with a user email and password instead of an
+// API key, e.g. to bootstrap fresh instances. The client logs in to the
+// internal API and, on the first request to the public API, creates a
+// short-lived API key for itself. Read-only clients create no key and read
+// credentials through the internal API instead. The internal API is enabled
+// as a side effect.
+func WithSessionAuth(email, password string) Option {
+	return func(c *Client) error {
+		if err := WithInternalAPI(email, password)(c); err != nil {
+			return err
+		}
+
+		suffix := make([]byte, 4)
+		if _, err := rand.Read(suffix); err != nil {
+			return fmt.Errorf("error generating API key label: %w", err)
+		}
+		c.sessionKey = &sessionAPIKey{
+			label: sessionAPIKeyLabelPrefix + "-" + hex.EncodeToString(suffix),
+		}
+		return nil
+	}
+}
+
+// sessionReads reports whether the client reads through the internal API
+// because it has no API key and may not create one.
+func (c *Client) sessionReads() bool {
+	return c.APIKey == "" && c.sessionKey != nil && c.readOnly
+}
+
+// publicAPIKey returns the API key for public API requests, creating one on
+// the first request of clients authenticating with a user session.
+func (c *Client) publicAPIKey(ctx context.Context) (string, error) {
+	if c.APIKey != "" || c.sessionKey == nil {
+		return c.APIKey, nil
+	}
+	if c.readOnly {
+		return "", errSessionAPIKeyReadOnly
+	}
+
+	c.sessionKey.mu.Lock()
+	defer c.sessionKey.mu.Unlock()
+
+	if c.sessionKey.key == "" {
+		if err := c.createSessionAPIKey(ctx); err != nil {
+			return "", fmt.Errorf("error creating API key for session authentication: %w", err)
+		}
+	}
+	return c.sessionKey.key, nil
+}
+
+// withAPIKey runs do, which sends a public API request. When the API key the
+// client created for session authentication has expired, the key is replaced
+// and do runs once more.
+func (c *Client) withAPIKey(ctx context.Context, do func() error) error {
+	if c.APIKey != "" || c.sessionKey == nil {
+		return do()
+	}
+
+	c.sessionKey.mu.Lock()
+	used := c.sessionKey.key
+	c.sessionKey.mu.Unlock()
+
+	err := do()
+	var unauthorized *UnauthorizedError
+	if used == "" || !errors.As(err, &unauthorized) {
+		return err
+	}
+
+	if renewErr := c.renewSessionAPIKey(ctx, used); renewErr != nil {
+		tflog.Debug(ctx, "Could not replace the API key for session authentication", map[string]interface{}{
+			"error": renewErr.Error(),
+		})
+		return err
+	}
+	return do()
+}
+
+// renewSessionAPIKey deletes the expired key and creates a new one, unless
+// another request already replaced it.
+func (c *Client) renewSessionAPIKey(ctx context.Context, expired string) error {
+	c.sessionKey.mu.Lock()
+	defer c.sessionKey.mu.Unlock()
+
+	if c.sessionKey.key != expired {
+		return nil
+	}
+
+	if _, err := c.doInternalRequest(ctx, "DELETE", fmt.Sprintf("api-keys/%s", c.sessionKey.id), nil); err != nil {
+		tflog.Debug(ctx, "Could not delete the expired API key", map[string]interface{}{
+			"id":    c.sessionKey.id,
+			"error": err.Error(),
+		})
+	}
+	c.sessionKey.id, c.sessionKey.key = "", ""
+
+	return c.createSessionAPIKey(ctx)
+}
+
+// apiKeyResponse is an API key as returned by the internal API.
+type apiKeyResponse struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+	// APIKey is redacted on newer n8n versions, which return the key in
+	// RawAPIKey on creation instead.
+	APIKey    string `json:"apiKey"`
+	RawAPIKey string `json:"rawApiKey"`
+	// ExpiresAt is the Unix time at which the key expires, null for keys
+	// that never expire.
+	ExpiresAt *int64 `json:"expiresAt"`
+}
+
+// apiKeyCreateRequest is the request body for creating an API key.
+type apiKeyCreateRequest struct {
+	Label     string   `json:"label"`
+	Scopes    []string `json:"scopes,omitempty"`
+	ExpiresAt int64    `json:"expiresAt"`
+}
+
+// createSessionAPIKey creates the API key of the client. The caller holds the
+// lock of the session key.
+func (c *Client) createSessionAPIKey(ctx context.Context) error {
+	// Versions with scoped API keys require the scopes to be listed. Older
+	// versions do not know the endpoint and create unscoped keys.
+	var scopes []string
+	if err := c.getInternal(ctx, "api-keys/scopes", &scopes); err != nil {
+		scopes = nil
+	}
+
+	body := apiKeyCreateRequest{
+		Label:     c.sessionKey.label,
+		Scopes:    scopes,
+		ExpiresAt: time.Now().Add(sessionAPIKeyLifetime).Unix(),
+	}
+
+	respBody, err := c.doInternalRequest(ctx, "POST", "api-keys", body)
+	if err != nil {
+		return err
+	}
+
+	var response internalResponse
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return fmt.Errorf("error unmarshaling response: %w", err)
+	}
+
+	var created apiKeyResponse
+	if err := json.Unmarshal(response.Data, &created); err != nil {
+		return fmt.Errorf("error unmarshaling response: %w", err)
+	}
+
+	c.sessionKey.id = created.ID
+	c.sessionKey.key = created.RawAPIKey
+	if c.sessionKey.key == "" {
+		c.sessionKey.key = created.APIKey
+	}
+	return nil
+}
+
+// DeleteStaleSessionAPIKeys deletes the expired API keys earlier runs created
+// for session authentication, which n8n keeps listing after they expire. The
+// keys of runs still in progress have not expired and are kept. It returns
+// the number of deleted keys and does nothing for clients that do not
+// authenticate with a user session or may not change the instance.
+func (c *Client) DeleteStaleSessionAPIKeys(ctx context.Context) (int, error) {
+	if c.APIKey != "" || c.sessionKey == nil || c.readOnly {
+		return 0, nil
+	}
+
+	var keys []apiKeyResponse
+	if err := c.getInternal(ctx, "api-keys", &keys); err != nil {
+		return 0, fmt.Errorf("error listing API keys: %w", err)
+	}
+
+	now := time.Now().Unix()
+	deleted := 0
+	for _, key := range keys {
+		if !strings.HasPrefix(key.Label, sessionAPIKeyLabelPrefix+"-") || key.Label == c.sessionKey.label {
+			continue
+		}
+		if key.ExpiresAt == nil || *key.ExpiresAt > now {
+			continue
+		}
+
+		if _, err := c.doInternalRequest(ctx, "DELETE", fmt.Sprintf("api-keys/%s", key.ID), nil); err != nil {
+			return deleted, fmt.Errorf("error deleting API key %s: %w", key.Label, err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// listInternalCredentials lists the credentials through the internal API.
+func (c *Client) listInternalCredentials(ctx context.Context) ([]models.Credential, error) {
+	var credentials []models.Credential
+	if err := c.getInternal(ctx, "credentials", &credentials); err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
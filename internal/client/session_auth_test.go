@@ -0,0 +1,219 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sessionAuthServer fakes the login and API key endpoints of n8n. Only the
+// most recently created key is accepted by the public API.
+type sessionAuthServer struct {
+	logins  int
+	created []apiKeyCreateRequest
+	deleted []string
+	current string
+	// listed are the keys listed in addition to those created.
+	listed []apiKeyResponse
+}
+
+func (s *sessionAuthServer) mux(t *testing.T) *http.ServeMux {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /rest/login", func(w http.ResponseWriter, r *http.Request) {
+		s.logins++
+		http.SetCookie(w, &http.Cookie{Name: "n8n-auth", Value: "session", Path: "/"})
+	})
+	mux.HandleFunc("GET /rest/api-keys/scopes", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":["credential:create","credential:delete"]}`))
+	})
+	mux.HandleFunc("POST /rest/api-keys", func(w http.ResponseWriter, r *http.Request) {
+		var body apiKeyCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Unexpected error decoding body: %v", err)
+		}
+		s.created = append(s.created, body)
+		s.current = fmt.Sprintf("session-api-key-%d", len(s.created))
+		_, _ = fmt.Fprintf(w, `{"data":{"id":"key-%d","label":%q,"apiKey":"******","rawApiKey":%q}}`, len(s.created), body.Label, s.current)
+	})
+	mux.HandleFunc("GET /rest/api-keys", func(w http.ResponseWriter, r *http.Request) {
+		keys := append([]apiKeyResponse{}, s.listed...)
+		for i, created := range s.created {
+			expiresAt := created.ExpiresAt
+			keys = append(keys, apiKeyResponse{ID: fmt.Sprintf("key-%d", i+1), Label: created.Label, ExpiresAt: &expiresAt})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": keys})
+	})
+	mux.HandleFunc("DELETE /rest/api-keys/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.deleted = append(s.deleted, r.PathValue("id"))
+		_, _ = w.Write([]byte(`{"data":{"success":true}}`))
+	})
+	mux.HandleFunc("GET /api/v1/credentials", func(w http.ResponseWriter, r *http.Request) {
+		if s.current == "" || r.Header.Get("X-N8N-API-KEY") != s.current {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"unauthorized"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"1","name":"api","type":"httpBasicAuth"}]}`))
+	})
+	return mux
+}
+
+func TestWithSessionAuth(t *testing.T) {
+	fake := &sessionAuthServer{}
+	server := httptest.NewServer(fake.mux(t))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), nil, boolPtr(false), WithSessionAuth("owner@example.com", "secret"), WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Configuring the client and checking the connection create no key.
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fake.logins != 1 || len(fake.created) != 0 {
+		t.Fatalf("Expected a login without creating a key, got %d logins and %d keys", fake.logins, len(fake.created))
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.ListCredentials(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if len(fake.created) != 1 {
+		t.Fatalf("Expected 1 key to be created, got %d", len(fake.created))
+	}
+	created := fake.created[0]
+	if !strings.HasPrefix(created.Label, sessionAPIKeyLabelPrefix+"-") || len(created.Scopes) != 2 || created.ExpiresAt == 0 {
+		t.Errorf("Unexpected create request: %+v", created)
+	}
+	if len(fake.deleted) != 0 {
+		t.Errorf("Expected no keys to be deleted, got %v", fake.deleted)
+	}
+	if client.APIKey != "" {
+		t.Errorf("Expected the configured API key to stay empty, got %q", client.APIKey)
+	}
+
+	// Another client of the same user gets a label of its own.
+	other, err := NewClient(stringPtr(server.URL), nil, boolPtr(false), WithSessionAuth("owner@example.com", "secret"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if other.sessionKey.label == client.sessionKey.label {
+		t.Errorf("Expected unique labels, got %q twice", created.Label)
+	}
+}
+
+func TestWithSessionAuthRenewsExpiredKey(t *testing.T) {
+	fake := &sessionAuthServer{}
+	server := httptest.NewServer(fake.mux(t))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), nil, boolPtr(false), WithSessionAuth("owner@example.com", "secret"), WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.ListCredentials(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Expire the key.
+	fake.current = "expired"
+
+	if _, err := client.ListCredentials(context.Background()); err != nil {
+		t.Fatalf("Expected the key to be replaced, got %v", err)
+	}
+	if len(fake.created) != 2 {
+		t.Errorf("Expected 2 keys to be created, got %d", len(fake.created))
+	}
+	if len(fake.deleted) != 1 || fake.deleted[0] != "key-1" {
+		t.Errorf("Expected only the expired key to be deleted, got %v", fake.deleted)
+	}
+}
+
+func TestDeleteStaleSessionAPIKeys(t *testing.T) {
+	expired := time.Now().Add(-time.Minute).Unix()
+	valid := time.Now().Add(time.Hour).Unix()
+
+	fake := &sessionAuthServer{listed: []apiKeyResponse{
+		{ID: "stale", Label: sessionAPIKeyLabelPrefix + "-0a1b2c3d", ExpiresAt: &expired},
+		{ID: "running", Label: sessionAPIKeyLabelPrefix + "-4e5f6a7b", ExpiresAt: &valid},
+		{ID: "ci", Label: "ci", ExpiresAt: &expired},
+		{ID: "permanent", Label: sessionAPIKeyLabelPrefix + "-8c9d0e1f"},
+	}}
+	server := httptest.NewServer(fake.mux(t))
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), nil, boolPtr(false), WithSessionAuth("owner@example.com", "secret"), WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	deleted, err := client.DeleteStaleSessionAPIKeys(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if deleted != 1 || len(fake.deleted) != 1 || fake.deleted[0] != "stale" {
+		t.Errorf("Expected only the expired key of an earlier run to be deleted, got %d: %v", deleted, fake.deleted)
+	}
+
+	// Clients with an API key leave the keys of the user alone.
+	withKey, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithSessionAuth("owner@example.com", "secret"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if deleted, err := withKey.DeleteStaleSessionAPIKeys(context.Background()); err != nil || deleted != 0 {
+		t.Errorf("Expected no keys to be deleted, got %d, %v", deleted, err)
+	}
+}
+
+func TestWithSessionAuthReadOnly(t *testing.T) {
+	fake := &sessionAuthServer{}
+	mux := fake.mux(t)
+	mux.HandleFunc("GET /rest/credentials", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"id":"1","name":"api","type":"httpBasicAuth"}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), nil, boolPtr(false), WithSessionAuth("owner@example.com", "secret"), WithReadOnly())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	credentials, err := client.ListCredentials(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(credentials) != 1 || credentials[0].ID != "1" {
+		t.Errorf("Unexpected credentials: %+v", credentials)
+	}
+
+	if _, err := client.ListWorkflows(context.Background()); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly for the public API, got %v", err)
+	}
+	if len(fake.created) != 0 {
+		t.Errorf("Expected no key to be created, got %d", len(fake.created))
+	}
+}
+
+func TestWithSessionAuthKeepsAPIKey(t *testing.T) {
+	client, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false), WithSessionAuth("owner@example.com", "secret"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if client.APIKey != "test-api-key" {
+		t.Errorf("Expected configured API key to be used, got %q", client.APIKey)
+	}
+}
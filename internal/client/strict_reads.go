@@ -0,0 +1,16 @@
+package client
+
+// WithStrictReads makes resources fail refreshes when an object cannot be
+// read for any reason other than it being deleted, e.g. an expired API key or
+// an unavailable instance. By default, resources keep their state instead.
+func WithStrictReads() Option {
+	return func(c *Client) error {
+		c.strictReads = true
+		return nil
+	}
+}
+
+// StrictReads reports whether read failures must fail the refresh.
+func (c *Client) StrictReads() bool {
+	return c.strictReads
+}
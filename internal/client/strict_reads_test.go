@@ -0,0 +1,21 @@
+package client
+
+import "testing"
+
+func TestWithStrictReads(t *testing.T) {
+	client, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if client.StrictReads() {
+		t.Error("Expected strict reads to be disabled by default")
+	}
+
+	client, err = NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false), WithStrictReads())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !client.StrictReads() {
+		t.Error("Expected strict reads to be enabled")
+	}
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testKeyPair generates a self-signed PEM encoded certificate and key.
+func testKeyPair(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(certPEM), string(keyPEM)
+}
+
+func TestWithClientCertificate(t *testing.T) {
+	certPEM, keyPEM := testKeyPair(t)
+
+	var presented int
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presented = len(r.TLS.PeerCertificates)
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(true), WithClientCertificate(certPEM, keyPEM))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.ListCredentials(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if presented != 1 {
+		t.Errorf("Expected the client certificate to be presented, got %d certificates", presented)
+	}
+}
+
+func TestWithClientCertificateInvalid(t *testing.T) {
+	if _, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false), WithClientCertificate("", "")); err == nil {
+		t.Errorf("Expected error for missing certificate")
+	}
+	if _, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false), WithClientCertificate("not a cert", "not a key")); err == nil {
+		t.Errorf("Expected error for invalid certificate")
+	}
+}
+
+func TestWithServerCertificateSHA256(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	sum := sha256.Sum256(server.Certificate().Raw)
+	fingerprint := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	pinned, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithServerCertificateSHA256(fingerprint))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := pinned.ListCredentials(context.Background()); err != nil {
+		t.Errorf("Expected pinned certificate to be accepted, got %v", err)
+	}
+
+	wrong := strings.Repeat("00:", sha256.Size-1) + "00"
+	mismatched, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithServerCertificateSHA256(wrong))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := mismatched.ListCredentials(context.Background()); err == nil {
+		t.Errorf("Expected mismatched certificate to be rejected")
+	}
+
+	if _, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithServerCertificateSHA256("abc")); err == nil {
+		t.Errorf("Expected error for invalid fingerprint")
+	}
+}
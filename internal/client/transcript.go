@@ -0,0 +1,125 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// transcript appends every request and response exchanged with n8n to a
+// file, e.g. to attach to a bug report.
+type transcript struct {
+	path string
+	mu   sync.Mutex
+}
+
+// transcriptEntry is one line of the transcript: an attempt of a request and
+// its response, or the error when no response was received.
+type transcriptEntry struct {
+	Timestamp string              `json:"timestamp"`
+	RequestID string              `json:"request_id,omitempty"`
+	Request   transcriptMessage   `json:"request"`
+	Response  *transcriptResponse `json:"response,omitempty"`
+	Error     string              `json:"error,omitempty"`
+}
+
+// transcriptMessage is a request in the transcript.
+type transcriptMessage struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// transcriptResponse is a response in the transcript.
+type transcriptResponse struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers"`
+	Body       string            `json:"body,omitempty"`
+}
+
+// WithTranscript appends every attempt of every API request and its response
+// to the file at path as JSON, one exchange per line. Headers and bodies are
+// redacted as in the TRACE logs: API keys, session cookies, passwords,
+// configured headers and credential data never reach the file. The file is
+// created if needed.
+func WithTranscript(path string) Option {
+	return func(c *Client) error {
+		//nolint:gosec // G304: Writing to a user-configured path is the purpose of the transcript
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("error opening HTTP transcript: %w", err)
+		}
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("error opening HTTP transcript: %w", err)
+		}
+
+		c.transcript = &transcript{path: path}
+		return nil
+	}
+}
+
+// recordExchange adds an attempt to the transcript when it is enabled. resp
+// is nil when no response was received, in which case err is recorded.
+func (c *Client) recordExchange(req *http.Request, resp *http.Response, respBody []byte, err error) {
+	if c.transcript == nil {
+		return
+	}
+
+	entry := transcriptEntry{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		RequestID: req.Header.Get(RequestIDHeader),
+		Request: transcriptMessage{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Headers: c.redactHeaders(req.Header),
+		},
+	}
+	if body, ok := requestBody(req); ok {
+		entry.Request.Body = redactBody(body, false)
+	}
+	if resp != nil {
+		entry.Response = &transcriptResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    c.redactHeaders(resp.Header),
+			Body:       redactBody(respBody, true),
+		}
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	if err := c.transcript.write(entry); err != nil {
+		tflog.Error(req.Context(), "Could not write HTTP transcript", map[string]interface{}{
+			"path":  c.transcript.path,
+			"error": err.Error(),
+		})
+	}
+}
+
+// write appends the entry to the transcript.
+func (t *transcript) write(entry transcriptEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	//nolint:gosec // G304: Writing to a user-configured path is the purpose of the transcript
+	file, err := os.OpenFile(t.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+)
+
+func TestWithTranscript(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/credentials", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"42","name":"api","type":"httpBasicAuth"}`))
+	})
+	mux.HandleFunc("GET /api/v1/credentials/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "transcript.jsonl")
+	client, err := NewClient(stringPtr(server.URL), stringPtr("secret-api-key"), boolPtr(false), WithRetry(0, 0, 0), WithTranscript(path),
+		WithHeaders(map[string]string{"CF-Access-Client-Secret": "secret-header"}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	created, err := client.CreateCredential(ctx, &models.Credential{
+		Name: "api",
+		Type: "httpBasicAuth",
+		Data: map[string]interface{}{"user": "admin", "password": "secret-password"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if created.ID != "42" {
+		t.Errorf("Expected the response to be decoded, got %+v", created)
+	}
+	_, _ = client.GetCredential(ctx, "7")
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, secret := range []string{"secret-api-key", "secret-header", "secret-password", "admin"} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("Expected %q to be redacted from the transcript:\n%s", secret, content)
+		}
+	}
+
+	var entries []transcriptEntry
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	for scanner.Scan() {
+		var entry transcriptEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("Expected a JSON entry per line, got %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) < 2 {
+		t.Fatalf("Expected an entry per request, got %+v", entries)
+	}
+
+	first := entries[0]
+	if first.Request.Method != http.MethodPost || first.RequestID == "" || first.Response == nil || first.Response.StatusCode != http.StatusOK {
+		t.Errorf("Unexpected entry for the create request: %+v", first)
+	}
+	if !strings.Contains(first.Response.Body, `"id":"42"`) {
+		t.Errorf("Expected the response body in the transcript, got %q", first.Response.Body)
+	}
+
+	failed := entries[1]
+	if !strings.HasSuffix(failed.Request.URL, "/api/v1/credentials/7") || failed.Response == nil ||
+		failed.Response.StatusCode != http.StatusNotFound || !strings.Contains(failed.Response.Body, "Not Found") {
+		t.Errorf("Unexpected entry for the failed request: %+v", failed)
+	}
+}
+
+func TestWithTranscriptRejectsUnwritablePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "transcript.jsonl")
+	if _, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false), WithTranscript(path)); err == nil {
+		t.Error("Expected an error for a transcript in a missing directory")
+	}
+}
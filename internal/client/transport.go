@@ -0,0 +1,44 @@
+package client
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Transport settings tuned for applies that make many requests to a single
+// n8n host.
+const (
+	dialTimeout           = 10 * time.Second
+	keepAlive             = 30 * time.Second
+	tlsHandshakeTimeout   = 10 * time.Second
+	idleConnTimeout       = 90 * time.Second
+	maxIdleConnsPerHost   = 16
+	expectContinueTimeout = 1 * time.Second
+)
+
+// newTransport creates the HTTP transport of the client. Idle connections are
+// kept per host so consecutive requests reuse them, and HTTP/2 is attempted
+// even though a custom TLS configuration is set.
+func newTransport(insecure bool) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: keepAlive,
+	}
+
+	return &http.Transport{
+		Proxy:       http.ProxyFromEnvironment,
+		DialContext: dialer.DialContext,
+		TLSClientConfig: &tls.Config{
+			//nolint:gosec // G402: InsecureSkipVerify is configurable by user for testing/development
+			InsecureSkipVerify: insecure,
+		},
+		ForceAttemptHTTP2:     true,
+		TLSHandshakeTimeout:   tlsHandshakeTimeout,
+		MaxIdleConns:          maxIdleConnsPerHost,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		IdleConnTimeout:       idleConnTimeout,
+		ExpectContinueTimeout: expectContinueTimeout,
+	}
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestNewClientTunesTransport(t *testing.T) {
+	client, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(true))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	transport, err := client.transport()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !transport.ForceAttemptHTTP2 {
+		t.Errorf("Expected HTTP/2 to be attempted")
+	}
+	if transport.MaxIdleConnsPerHost != maxIdleConnsPerHost {
+		t.Errorf("Expected %d idle connections per host, got %d", maxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	}
+	if transport.TLSHandshakeTimeout != tlsHandshakeTimeout {
+		t.Errorf("Expected TLS handshake timeout %s, got %s", tlsHandshakeTimeout, transport.TLSHandshakeTimeout)
+	}
+	if transport.DialContext == nil {
+		t.Errorf("Expected a dialer with timeouts")
+	}
+	if !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Errorf("Expected insecure to be applied")
+	}
+}
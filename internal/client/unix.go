@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// unixScheme is the scheme of hosts referring to a unix domain socket, e.g.
+// unix:///var/run/n8n.sock.
+const unixScheme = "unix://"
+
+// unixBaseURL is the base URL of requests sent over a unix domain socket. The
+// hostname only fills the Host header.
+const unixBaseURL = "http://localhost"
+
+// unixSocketPath returns the socket path of a unix:// host, and false for
+// other hosts.
+func unixSocketPath(host string) (string, bool, error) {
+	if !strings.HasPrefix(host, unixScheme) {
+		return "", false, nil
+	}
+
+	path := strings.TrimPrefix(host, unixScheme)
+	if !strings.HasPrefix(path, "/") {
+		return "", true, fmt.Errorf("invalid host %q: the socket path must be absolute, e.g. unix:///var/run/n8n.sock", host)
+	}
+	return path, true, nil
+}
+
+// dialUnixSocket makes the transport connect to the unix domain socket for
+// every request. Proxies don't apply to sockets.
+func dialUnixSocket(transport *http.Transport, socketPath string) {
+	dialer := &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: keepAlive,
+	}
+
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return dialer.DialContext(ctx, "unix", socketPath)
+	}
+}
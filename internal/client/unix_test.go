@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnixSocketHost(t *testing.T) {
+	// Socket paths are limited to about 100 bytes, so avoid t.TempDir().
+	dir, err := os.MkdirTemp("", "n8n")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	socketPath := filepath.Join(dir, "n8n.sock")
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/credentials" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"1"}]}`))
+	})}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	client, err := NewClient(stringPtr("unix://"+socketPath), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	credentials, err := client.ListCredentials(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(credentials) != 1 {
+		t.Errorf("Expected 1 credential, got %d", len(credentials))
+	}
+}
+
+func TestUnixSocketPath(t *testing.T) {
+	tests := []struct {
+		host       string
+		wantPath   string
+		wantSocket bool
+		wantError  bool
+	}{
+		{host: "unix:///var/run/n8n.sock", wantPath: "/var/run/n8n.sock", wantSocket: true},
+		{host: "unix://n8n.sock", wantSocket: true, wantError: true},
+		{host: "https://n8n.example.com"},
+	}
+
+	for _, tt := range tests {
+		path, isSocket, err := unixSocketPath(tt.host)
+		if path != tt.wantPath || isSocket != tt.wantSocket || (err != nil) != tt.wantError {
+			t.Errorf("unixSocketPath(%q) = %q, %v, %v", tt.host, path, isSocket, err)
+		}
+	}
+}
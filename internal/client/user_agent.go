@@ -0,0 +1,19 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		userAgent = strings.TrimSpace(userAgent)
+		if userAgent == "" {
+			return fmt.Errorf("user agent must not be empty")
+		}
+
+		c.userAgent = userAgent
+		return nil
+	}
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+)
+
+// Feature is an n8n capability that depends on the instance version.
+type Feature string
+
+const (
+	// FeatureCredentialTransfer is moving credentials between projects.
+	FeatureCredentialTransfer Feature = "credential transfer"
+	// FeatureWorkflowTransfer is moving workflows between projects.
+	FeatureWorkflowTransfer Feature = "workflow transfer"
+	// FeatureProjects is the projects API.
+	FeatureProjects Feature = "projects"
+	// FeatureCredentialUpdate is updating credentials in place without
+	// recreating them.
+	FeatureCredentialUpdate Feature = "credential update"
+	// FeatureWorkflowArchive is archiving workflows instead of deleting them.
+	FeatureWorkflowArchive Feature = "workflow archiving"
+)
+
+// featureVersions is the first n8n version supporting each feature.
+var featureVersions = map[Feature]Version{
+	FeatureCredentialTransfer: {Major: 1, Minor: 56},
+	FeatureWorkflowTransfer:   {Major: 1, Minor: 56},
+	FeatureProjects:           {Major: 1, Minor: 65},
+	FeatureCredentialUpdate:   {Major: 1, Minor: 111},
+	FeatureWorkflowArchive:    {Major: 1, Minor: 94},
+}
+
+// Version is an n8n release version.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseVersion parses versions such as "1.64.2".
+func ParseVersion(value string) (Version, error) {
+	parts := strings.SplitN(strings.TrimPrefix(value, "v"), ".", 3)
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("invalid version %q", value)
+	}
+
+	// Drop pre-release suffixes such as "1.70.0-exp.0".
+	parts[2], _, _ = strings.Cut(parts[2], "-")
+
+	var numbers [3]int
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid version %q: %w", value, err)
+		}
+		numbers[i] = number
+	}
+
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// String returns the version as "major.minor.patch".
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// AtLeast reports whether v is the same as or newer than other.
+func (v Version) AtLeast(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor > other.Minor
+	}
+	return v.Patch >= other.Patch
+}
+
+// settingsResponse is the subset of the frontend settings the client uses.
+type settingsResponse struct {
+	Data settingsData `json:"data"`
+}
+
+// settingsData is the subset of the frontend settings data the client uses.
+// Recent n8n versions only report the enterprise section to authenticated
+// users.
+type settingsData struct {
+	VersionCli string                     `json:"versionCli"`
+	Enterprise *models.EnterpriseSettings `json:"enterprise"`
+}
+
+// DetectVersion reads the instance version from the frontend settings, which
+// n8n serves without authentication, and stores it on the client. The request
+// carries the headers of every other request, e.g. for an access proxy, but
+// is not retried, since detection is best effort.
+func (c *Client) DetectVersion(ctx context.Context) (Version, error) {
+	req, err := newRequest(contextWithoutRetries(ctx), http.MethodGet, fmt.Sprintf("%s/rest/settings", c.Host), nil)
+	if err != nil {
+		return Version{}, err
+	}
+
+	var settings settingsResponse
+	if _, err := c.executeDecode(req, &settings); err != nil {
+		return Version{}, fmt.Errorf("error reading instance settings: %w", err)
+	}
+
+	version, err := ParseVersion(settings.Data.VersionCli)
+	if err != nil {
+		return Version{}, err
+	}
+
+	c.version = &version
+	return version, nil
+}
+
+// Version returns the detected instance version, or false when it is unknown.
+func (c *Client) Version() (Version, bool) {
+	if c.version == nil {
+		return Version{}, false
+	}
+	return *c.version, true
+}
+
+// Supports reports whether the instance supports the feature. Features are
+// assumed to be supported when the version is unknown, so the API decides.
+func (c *Client) Supports(feature Feature) bool {
+	if c.version == nil {
+		return true
+	}
+
+	required, ok := featureVersions[feature]
+	if !ok {
+		return true
+	}
+	return c.version.AtLeast(required)
+}
+
+// UpdatesCredentialsInPlace reports whether credentials can be updated without
+// recreating them, which requires a detected version supporting it.
+func (c *Client) UpdatesCredentialsInPlace() bool {
+	return c.version != nil && c.Supports(FeatureCredentialUpdate)
+}
+
+// RequireFeature returns an error describing the required version when the
+// instance does not support the feature.
+func (c *Client) RequireFeature(feature Feature) error {
+	if c.Supports(feature) {
+		return nil
+	}
+
+	return fmt.Errorf("%s requires n8n %s or later, but the instance runs %s", feature, featureVersions[feature], c.version)
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := map[string]Version{
+		"1.64.2":       {Major: 1, Minor: 64, Patch: 2},
+		"v1.0.0":       {Major: 1},
+		"1.70.0-exp.0": {Major: 1, Minor: 70},
+	}
+
+	for value, want := range tests {
+		got, err := ParseVersion(value)
+		if err != nil {
+			t.Errorf("ParseVersion(%q) unexpected error: %v", value, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseVersion(%q) = %v, want %v", value, got, want)
+		}
+	}
+
+	for _, value := range []string{"", "1.2", "1.x.0"} {
+		if _, err := ParseVersion(value); err == nil {
+			t.Errorf("ParseVersion(%q) expected error", value)
+		}
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	base := Version{Major: 1, Minor: 56}
+
+	if !(Version{Major: 1, Minor: 56}).AtLeast(base) {
+		t.Errorf("Expected equal versions to satisfy AtLeast")
+	}
+	if !(Version{Major: 2}).AtLeast(base) {
+		t.Errorf("Expected newer major version to satisfy AtLeast")
+	}
+	if (Version{Major: 1, Minor: 55, Patch: 9}).AtLeast(base) {
+		t.Errorf("Expected older version not to satisfy AtLeast")
+	}
+}
+
+func TestDetectVersion(t *testing.T) {
+	transfers := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/settings", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"versionCli":"1.40.0"}}`))
+	})
+	mux.HandleFunc("PUT /api/v1/credentials/{id}/transfer", func(w http.ResponseWriter, r *http.Request) {
+		transfers++
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !client.Supports(FeatureCredentialTransfer) {
+		t.Errorf("Expected features to be assumed supported before detection")
+	}
+
+	version, err := client.DetectVersion(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if version.String() != "1.40.0" {
+		t.Errorf("Expected version 1.40.0, got %s", version)
+	}
+
+	if client.Supports(FeatureCredentialTransfer) {
+		t.Errorf("Expected credential transfer to be unsupported on 1.40.0")
+	}
+	if client.UpdatesCredentialsInPlace() {
+		t.Errorf("Expected credentials to be recreated on 1.40.0")
+	}
+	if err := client.TransferCredential(context.Background(), "42", "project-1"); err == nil {
+		t.Errorf("Expected transfer to be refused on 1.40.0")
+	}
+	if transfers != 0 {
+		t.Errorf("Expected no transfer request, got %d", transfers)
+	}
+}
+
+func TestDetectVersionSendsClientHeaders(t *testing.T) {
+	attempts := 0
+	var received http.Header
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/settings", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		received = r.Header.Clone()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false),
+		WithHeaders(map[string]string{"cf-access-token": "token"}),
+		WithUserAgent("terraform-provider-n8n/1.2.3"),
+		WithRetry(3, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.DetectVersion(context.Background()); err == nil {
+		t.Fatalf("Expected error for an unavailable instance")
+	}
+	if attempts != 1 {
+		t.Errorf("Expected detection not to be retried, got %d attempts", attempts)
+	}
+	if received.Get("Cf-Access-Token") != "token" || received.Get("User-Agent") != "terraform-provider-n8n/1.2.3" || received.Get(RequestIDHeader) == "" {
+		t.Errorf("Expected the client headers to be sent, got %v", received)
+	}
+}
+
+func TestUpdateCredentialInPlace(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/settings", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"versionCli":"1.111.0"}}`))
+	})
+	mux.HandleFunc("PATCH /api/v1/credentials/{id}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"` + r.PathValue("id") + `","name":"example","type":"httpBasicAuth"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.DetectVersion(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	credential, err := client.UpdateCredential(context.Background(), "42", &models.Credential{Name: "example", Type: "httpBasicAuth"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if credential.ID != "42" {
+		t.Errorf("Expected credential ID to be kept, got %s", credential.ID)
+	}
+}
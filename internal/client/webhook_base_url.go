@@ -0,0 +1,35 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// WithWebhookBaseURL sets the base URL n8n serves webhooks under, for
+// instances where it differs from the host, e.g. because n8n sets WEBHOOK_URL
+// or the host is a unix domain socket.
+func WithWebhookBaseURL(baseURL string) Option {
+	return func(c *Client) error {
+		parsed, err := url.Parse(baseURL)
+		if err != nil {
+			return fmt.Errorf("invalid webhook base URL: %w", err)
+		}
+		if parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("invalid webhook base URL %q: scheme and host are required", baseURL)
+		}
+
+		c.webhookBaseURL = strings.TrimRight(baseURL, "/")
+		return nil
+	}
+}
+
+// WebhookBaseURL returns the base URL n8n serves webhooks under. Unless set
+// with WithWebhookBaseURL, it is the host, i.e. http://localhost for unix
+// domain sockets.
+func (c *Client) WebhookBaseURL() string {
+	if c.webhookBaseURL != "" {
+		return c.webhookBaseURL
+	}
+	return c.Host
+}
@@ -0,0 +1,25 @@
+package client
+
+import "testing"
+
+func TestWebhookBaseURL(t *testing.T) {
+	client, err := NewClient(stringPtr("unix:///var/run/n8n.sock"), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := client.WebhookBaseURL(); got != "http://localhost" {
+		t.Errorf("Expected the host as webhook base URL, got %q", got)
+	}
+
+	client, err = NewClient(stringPtr("unix:///var/run/n8n.sock"), stringPtr("test-api-key"), boolPtr(false), WithWebhookBaseURL("https://hooks.example.com/"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := client.WebhookBaseURL(); got != "https://hooks.example.com" {
+		t.Errorf("Expected the configured webhook base URL, got %q", got)
+	}
+
+	if _, err := NewClient(stringPtr("https://n8n.example.com"), stringPtr("test-api-key"), boolPtr(false), WithWebhookBaseURL("hooks.example.com")); err == nil {
+		t.Errorf("Expected an error for a webhook base URL without scheme")
+	}
+}
@@ -0,0 +1,383 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+)
+
+// ListWorkflowsResponse represents the response from listing workflows.
+type ListWorkflowsResponse struct {
+	Data       []models.Workflow `json:"data"`
+	NextCursor string            `json:"nextCursor"`
+}
+
+// ListWorkflows retrieves all workflows, following pagination.
+func (c *Client) ListWorkflows(ctx context.Context) ([]models.Workflow, error) {
+	var workflows []models.Workflow
+
+	err := c.listPages(ctx, "workflows", func(pageEndpoint string) (string, error) {
+		var response ListWorkflowsResponse
+		if err := c.doRequestDecode(ctx, "GET", pageEndpoint, nil, &response); err != nil {
+			return "", err
+		}
+
+		workflows = append(workflows, response.Data...)
+		return response.NextCursor, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return workflows, nil
+}
+
+// ErrWorkflowModified is returned when a workflow was changed outside of
+// Terraform since it was last read.
+var ErrWorkflowModified = errors.New("workflow was modified out-of-band")
+
+// GetWorkflow retrieves a workflow by ID.
+func (c *Client) GetWorkflow(ctx context.Context, id string) (*models.Workflow, error) {
+	var workflow models.Workflow
+	if err := c.doRequestDecode(ctx, "GET", fmt.Sprintf("workflows/%s", id), nil, &workflow); err != nil {
+		return nil, err
+	}
+
+	return &workflow, nil
+}
+
+// CreateWorkflow creates a workflow in the personal project of the API key's
+// user.
+func (c *Client) CreateWorkflow(ctx context.Context, workflow *models.Workflow) (*models.Workflow, error) {
+	// Creating a workflow accepts the same fields as updating it.
+	body := models.NewWorkflowUpdateRequest(workflow)
+
+	var createdWorkflow models.Workflow
+	if err := c.doRequestDecode(ctx, "POST", "workflows", body, &createdWorkflow); err != nil {
+		return nil, err
+	}
+
+	return &createdWorkflow, nil
+}
+
+// UpdateWorkflow replaces the definition of a workflow. When versionID is not
+// empty, the update is conditional: the public API does not support If-Match,
+// so the current versionId is compared first and ErrWorkflowModified is
+// returned when the workflow was edited since, e.g. in the n8n editor, instead
+// of overwriting those edits.
+func (c *Client) UpdateWorkflow(ctx context.Context, id string, workflow *models.Workflow, versionID string) (*models.Workflow, error) {
+	if versionID != "" {
+		current, err := c.GetWorkflow(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if current.VersionID != "" && current.VersionID != versionID {
+			return nil, fmt.Errorf("workflow %s has version %s instead of %s: %w", id, current.VersionID, versionID, ErrWorkflowModified)
+		}
+	}
+
+	body := models.NewWorkflowUpdateRequest(workflow)
+
+	var updatedWorkflow models.Workflow
+	if err := c.doRequestDecode(ctx, "PUT", fmt.Sprintf("workflows/%s", id), body, &updatedWorkflow); err != nil {
+		return nil, err
+	}
+
+	return &updatedWorkflow, nil
+}
+
+// UpdateWorkflowMetadata changes the name or settings of a workflow
+// through the internal API, which unlike the public API accepts partial
+// updates. The node graph is not re-uploaded, which avoids overwriting
+// concurrent edits of nodes in the editor. When versionID is not empty, n8n
+// rejects the update if the workflow was edited since.
+func (c *Client) UpdateWorkflowMetadata(ctx context.Context, id string, update *models.WorkflowMetadataUpdateRequest) (*models.Workflow, error) {
+	if c.internal == nil {
+		return nil, fmt.Errorf("partial workflow updates require the internal API, set enable_internal_api in the provider configuration")
+	}
+
+	respBody, err := c.doInternalRequest(ctx, "PATCH", fmt.Sprintf("workflows/%s", id), update)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data models.Workflow `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response: %w", err)
+	}
+
+	return &response.Data, nil
+}
+
+// ActivateWorkflow activates a workflow and waits until n8n reports it as
+// active. Activation registers triggers and webhooks, which may take longer
+// than DefaultTimeout, so it is bound by the activate_workflow operation
+// timeout instead. When the internal API is enabled, activation errors n8n
+// records while registering triggers, e.g. for a missing credential, are
+// checked as well, so a workflow that is active but broken is not reported
+// as activated.
+func (c *Client) ActivateWorkflow(ctx context.Context, id string) (*models.Workflow, error) {
+	ctx, cancel := c.operationContext(ctx, OperationActivateWorkflow)
+	defer cancel()
+
+	var workflow models.Workflow
+	if err := c.doRequestDecode(ctx, "POST", fmt.Sprintf("workflows/%s/activate", id), nil, &workflow); err != nil {
+		return nil, err
+	}
+
+	err := poll(ctx, func() (bool, error) {
+		if !workflow.Active {
+			current, err := c.GetWorkflow(ctx, id)
+			if err != nil {
+				return false, err
+			}
+			workflow = *current
+		}
+
+		if err := c.CheckActivationError(ctx, id); err != nil {
+			return false, err
+		}
+		return workflow.Active, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error activating workflow %s: %w", id, err)
+	}
+
+	return &workflow, nil
+}
+
+// ActivationError is an error n8n recorded while activating a workflow, e.g.
+// because a trigger's credential is missing. The workflow may still be
+// reported as active while its triggers don't run.
+type ActivationError struct {
+	WorkflowID string
+	Message    string
+}
+
+// Error implements the error interface.
+func (e *ActivationError) Error() string {
+	return fmt.Sprintf("n8n reported an activation error for workflow %s: %s", e.WorkflowID, e.Message)
+}
+
+// activationErrorResponse is the activation error n8n recorded for a workflow.
+type activationErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// CheckActivationError returns an *ActivationError when n8n recorded an error
+// while activating the workflow. It requires the internal API and returns nil
+// without it.
+func (c *Client) CheckActivationError(ctx context.Context, id string) error {
+	if c.internal == nil {
+		return nil
+	}
+
+	respBody, err := c.doInternalRequest(ctx, "GET", fmt.Sprintf("active-workflows/error/%s", id), nil)
+	if err != nil {
+		return fmt.Errorf("error checking activation of workflow %s: %w", id, err)
+	}
+
+	// The response data is empty when activation succeeded.
+	var response struct {
+		Data *activationErrorResponse `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return fmt.Errorf("error unmarshaling response: %w", err)
+	}
+	if response.Data != nil && response.Data.Error != "" {
+		return &ActivationError{WorkflowID: id, Message: response.Data.Error}
+	}
+	return nil
+}
+
+// DeactivateWorkflow deactivates a workflow.
+func (c *Client) DeactivateWorkflow(ctx context.Context, id string) (*models.Workflow, error) {
+	var workflow models.Workflow
+	if err := c.doRequestDecode(ctx, "POST", fmt.Sprintf("workflows/%s/deactivate", id), nil, &workflow); err != nil {
+		return nil, err
+	}
+
+	return &workflow, nil
+}
+
+// ArchiveWorkflow archives a workflow through the internal API. Archived
+// workflows are deactivated and hidden in the editor but keep their
+// definition and execution history, which audit-sensitive environments may
+// prefer over deleting them.
+func (c *Client) ArchiveWorkflow(ctx context.Context, id string) (*models.Workflow, error) {
+	return c.setWorkflowArchived(ctx, id, "archive")
+}
+
+// UnarchiveWorkflow restores an archived workflow through the internal API.
+func (c *Client) UnarchiveWorkflow(ctx context.Context, id string) (*models.Workflow, error) {
+	return c.setWorkflowArchived(ctx, id, "unarchive")
+}
+
+// setWorkflowArchived archives or unarchives a workflow, depending on action.
+func (c *Client) setWorkflowArchived(ctx context.Context, id, action string) (*models.Workflow, error) {
+	if c.internal == nil {
+		return nil, fmt.Errorf("archiving workflows requires the internal API, set enable_internal_api in the provider configuration")
+	}
+	if err := c.RequireFeature(FeatureWorkflowArchive); err != nil {
+		return nil, err
+	}
+
+	respBody, err := c.doInternalRequest(ctx, "POST", fmt.Sprintf("workflows/%s/%s", id, action), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error running %s on workflow %s: %w", action, id, err)
+	}
+
+	var response struct {
+		Data models.Workflow `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response: %w", err)
+	}
+
+	return &response.Data, nil
+}
+
+// TransferWorkflow moves a workflow to another project.
+func (c *Client) TransferWorkflow(ctx context.Context, id, projectID string) error {
+	if err := c.RequireFeature(FeatureWorkflowTransfer); err != nil {
+		return err
+	}
+
+	body := models.WorkflowTransferRequest{
+		DestinationProjectID: projectID,
+	}
+
+	_, err := c.doRequest(ctx, "PUT", fmt.Sprintf("workflows/%s/transfer", id), body)
+	return err
+}
+
+// listExecutionsResponse represents the response from listing executions.
+type listExecutionsResponse struct {
+	Data       []models.Execution `json:"data"`
+	NextCursor string             `json:"nextCursor"`
+}
+
+// LastExecution returns the most recent execution of a workflow, or nil when
+// the workflow was never executed or its executions were pruned.
+func (c *Client) LastExecution(ctx context.Context, workflowID string) (*models.Execution, error) {
+	query := url.Values{}
+	query.Set("workflowId", workflowID)
+	query.Set("limit", "1")
+
+	var response listExecutionsResponse
+	if err := c.doRequestDecode(ctx, "GET", "executions?"+query.Encode(), nil, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Data) == 0 {
+		return nil, nil
+	}
+
+	return &response.Data[0], nil
+}
+
+// DeleteWorkflow deletes a workflow by ID.
+func (c *Client) DeleteWorkflow(ctx context.Context, id string) error {
+	_, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("workflows/%s", id), nil)
+	return err
+}
+
+// MissingSubWorkflows returns the sub-workflow references of the workflow
+// whose target does not exist on the instance, so a parent workflow is not
+// uploaded before the workflows it calls.
+func (c *Client) MissingSubWorkflows(ctx context.Context, workflow *models.Workflow) ([]models.SubWorkflowReference, error) {
+	references, err := workflow.SubWorkflowReferences()
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []models.SubWorkflowReference
+	for _, reference := range references {
+		_, err := c.GetWorkflow(ctx, reference.WorkflowID)
+		var notFound *NotFoundError
+		switch {
+		case errors.As(err, &notFound):
+			missing = append(missing, reference)
+		case err != nil:
+			return nil, fmt.Errorf("error checking sub-workflow %s of node %s: %w", reference.WorkflowID, reference.NodeName, err)
+		}
+	}
+	return missing, nil
+}
+
+// DanglingCredentialReferences returns the credentials referenced by the
+// workflow's nodes that do not exist on the instance, so a workflow is not
+// uploaded with nodes that fail at runtime. References are matched by ID, or
+// by name and type when they have no ID. knownIDs lists further credential
+// IDs to accept, e.g. those of credentials managed in the same configuration.
+func (c *Client) DanglingCredentialReferences(ctx context.Context, workflow *models.Workflow, knownIDs []string) ([]models.NodeCredential, error) {
+	references, err := workflow.NodeCredentials()
+	if err != nil {
+		return nil, err
+	}
+	if len(references) == 0 {
+		return nil, nil
+	}
+
+	credentials, err := c.cachedCredentials(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing credentials: %w", err)
+	}
+
+	ids := make(map[string]bool, len(credentials)+len(knownIDs))
+	names := make(map[string]bool, len(credentials))
+	for _, credential := range credentials {
+		ids[credential.ID] = true
+		names[credential.Type+"/"+credential.Name] = true
+	}
+	for _, id := range knownIDs {
+		ids[id] = true
+	}
+
+	var dangling []models.NodeCredential
+	for _, reference := range references {
+		if reference.Reference.ID != "" && ids[reference.Reference.ID] {
+			continue
+		}
+		if reference.Reference.ID == "" && names[reference.Type+"/"+reference.Reference.Name] {
+			continue
+		}
+		dangling = append(dangling, reference)
+	}
+	return dangling, nil
+}
+
+// ListCredentialReferences returns the workflows whose nodes use the credential
+// with the given ID.
+func (c *Client) ListCredentialReferences(ctx context.Context, credentialID string) ([]models.CredentialReference, error) {
+	workflows, err := c.ListWorkflows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var references []models.CredentialReference
+	for i := range workflows {
+		workflow := &workflows[i]
+
+		nodes, err := workflow.NodesUsingCredential(credentialID)
+		if err != nil {
+			return nil, err
+		}
+		if len(nodes) == 0 {
+			continue
+		}
+
+		references = append(references, models.CredentialReference{
+			WorkflowID:   workflow.ID,
+			WorkflowName: workflow.Name,
+			Nodes:        nodes,
+		})
+	}
+
+	return references, nil
+}
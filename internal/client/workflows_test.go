@@ -0,0 +1,344 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+)
+
+func TestListCredentialReferences(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/workflows", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[
+			{"id":"1","name":"uses it","nodes":[{"name":"Fetch","credentials":{"httpBasicAuth":{"id":"42"}}}]},
+			{"id":"2","name":"unrelated","nodes":[{"name":"Start"}]}
+		]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	references, err := client.ListCredentialReferences(context.Background(), "42")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(references) != 1 {
+		t.Fatalf("Expected 1 reference, got %d", len(references))
+	}
+	if references[0].WorkflowID != "1" || len(references[0].Nodes) != 1 || references[0].Nodes[0] != "Fetch" {
+		t.Errorf("Unexpected reference: %+v", references[0])
+	}
+}
+
+func TestUpdateWorkflowIsConditional(t *testing.T) {
+	updates := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/workflows/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"1","name":"example","versionId":"v2"}`))
+	})
+	mux.HandleFunc("PUT /api/v1/workflows/1", func(w http.ResponseWriter, r *http.Request) {
+		updates++
+
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if _, ok := body["versionId"]; ok {
+			t.Errorf("Expected read-only fields to be omitted, got %v", body)
+		}
+
+		_, _ = w.Write([]byte(`{"id":"1","name":"example","versionId":"v3"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	workflow := &models.Workflow{Name: "example", Nodes: json.RawMessage(`[]`), Connections: json.RawMessage(`{}`), VersionID: "v1"}
+
+	if _, err := client.UpdateWorkflow(context.Background(), "1", workflow, "v1"); !errors.Is(err, ErrWorkflowModified) {
+		t.Errorf("Expected ErrWorkflowModified, got %v", err)
+	}
+	if updates != 0 {
+		t.Errorf("Expected no update, got %d", updates)
+	}
+
+	updated, err := client.UpdateWorkflow(context.Background(), "1", workflow, "v2")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if updated.VersionID != "v3" {
+		t.Errorf("Expected version v3, got %s", updated.VersionID)
+	}
+
+	if _, err := client.UpdateWorkflow(context.Background(), "1", workflow, ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if updates != 2 {
+		t.Errorf("Expected 2 updates, got %d", updates)
+	}
+}
+
+func TestGetWorkflowDecodesStreamedResponse(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/workflows/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"1","name":"large","nodes":[`))
+		for i := 0; i < 1000; i++ {
+			if i > 0 {
+				_, _ = w.Write([]byte(`,`))
+			}
+			_, _ = w.Write([]byte(`{"name":"Set","type":"n8n-nodes-base.set"}`))
+		}
+		_, _ = w.Write([]byte(`]}`))
+	})
+	mux.HandleFunc("GET /api/v1/workflows/2", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"2","nodes":[`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	workflow, err := client.GetWorkflow(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	nodes, err := workflow.ParseNodes()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(nodes) != 1000 {
+		t.Errorf("Expected 1000 nodes, got %d", len(nodes))
+	}
+
+	if _, err := client.GetWorkflow(context.Background(), "2"); err == nil {
+		t.Errorf("Expected error for a truncated response")
+	}
+}
+
+func TestArchiveWorkflow(t *testing.T) {
+	var calls []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /rest/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "n8n-auth", Value: "session", Path: "/"})
+	})
+	mux.HandleFunc("POST /rest/workflows/{id}/{action}", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.PathValue("action")+" "+r.PathValue("id"))
+		archived := r.PathValue("action") == "archive"
+		_, _ = fmt.Fprintf(w, `{"data":{"id":"1","name":"example","isArchived":%t}}`, archived)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	withoutInternal, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := withoutInternal.ArchiveWorkflow(context.Background(), "1"); err == nil {
+		t.Errorf("Expected error without internal API but got none")
+	}
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithInternalAPI("owner@example.com", "secret"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	workflow, err := client.ArchiveWorkflow(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !workflow.IsArchived {
+		t.Errorf("Expected the archived workflow, got %+v", workflow)
+	}
+	workflow, err = client.UnarchiveWorkflow(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if workflow.IsArchived {
+		t.Errorf("Expected the unarchived workflow, got %+v", workflow)
+	}
+
+	expected := []string{"archive 1", "unarchive 1"}
+	if strings.Join(calls, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("Expected calls %v, got %v", expected, calls)
+	}
+}
+
+func TestLastExecution(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/executions", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "1" {
+			t.Errorf("Expected only the last execution to be requested, got %s", r.URL.RawQuery)
+		}
+		if r.URL.Query().Get("workflowId") == "idle" {
+			_, _ = w.Write([]byte(`{"data":[],"nextCursor":null}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":1000,"workflowId":"busy","status":"success","startedAt":"2026-01-02T03:04:05.000Z"}]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	execution, err := client.LastExecution(context.Background(), "busy")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if execution == nil || execution.ID.String() != "1000" || execution.StartedAt != "2026-01-02T03:04:05.000Z" {
+		t.Errorf("Expected execution 1000, got %+v", execution)
+	}
+
+	execution, err = client.LastExecution(context.Background(), "idle")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if execution != nil {
+		t.Errorf("Expected no execution, got %+v", execution)
+	}
+}
+
+func TestMissingSubWorkflows(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/workflows/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != "7" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"7","name":"child"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	workflow := &models.Workflow{
+		ID: "1",
+		Nodes: json.RawMessage(`[
+			{"name":"Existing","type":"n8n-nodes-base.executeWorkflow","parameters":{"workflowId":"7"}},
+			{"name":"Dangling","type":"n8n-nodes-base.executeWorkflow","parameters":{"workflowId":{"__rl":true,"value":"8","mode":"id"}}}
+		]`),
+	}
+
+	missing, err := client.MissingSubWorkflows(context.Background(), workflow)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(missing) != 1 || missing[0].NodeName != "Dangling" || missing[0].WorkflowID != "8" {
+		t.Errorf("Expected the reference of node Dangling to be missing, got %+v", missing)
+	}
+}
+
+func TestUpdateWorkflowMetadata(t *testing.T) {
+	var gotBody map[string]json.RawMessage
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /rest/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "n8n-auth", Value: "session", Path: "/"})
+	})
+	mux.HandleFunc("PATCH /rest/workflows/1", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Errorf("Unexpected error decoding body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":"1","name":"renamed","versionId":"v2"}}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	withoutInternal, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := withoutInternal.UpdateWorkflowMetadata(context.Background(), "1", &models.WorkflowMetadataUpdateRequest{Name: "renamed"}); err == nil {
+		t.Errorf("Expected error without internal API but got none")
+	}
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false), WithInternalAPI("owner@example.com", "secret"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	updated, err := client.UpdateWorkflowMetadata(context.Background(), "1", &models.WorkflowMetadataUpdateRequest{Name: "renamed", VersionID: "v1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if updated.Name != "renamed" || updated.VersionID != "v2" {
+		t.Errorf("Unexpected workflow: %+v", updated)
+	}
+
+	if _, ok := gotBody["nodes"]; ok {
+		t.Errorf("Expected the node graph not to be sent, got %v", gotBody)
+	}
+	if string(gotBody["versionId"]) != `"v1"` {
+		t.Errorf("Expected versionId v1 to be sent, got %v", gotBody)
+	}
+}
+
+func TestDanglingCredentialReferences(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/credentials", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[
+			{"id":"1","name":"basic","type":"httpBasicAuth"},
+			{"id":"2","name":"slack","type":"slackApi"}
+		]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewClient(stringPtr(server.URL), stringPtr("test-api-key"), boolPtr(false))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	workflow := &models.Workflow{
+		ID: "1",
+		Nodes: json.RawMessage(`[
+			{"name":"Fetch","credentials":{"httpBasicAuth":{"id":"1","name":"basic"}}},
+			{"name":"Notify","credentials":{"slackApi":{"name":"slack"}}},
+			{"name":"Managed","credentials":{"postgres":{"id":"9","name":"db"}}},
+			{"name":"Dangling","credentials":{"httpHeaderAuth":{"id":"404","name":"deleted"}}}
+		]`),
+	}
+
+	dangling, err := client.DanglingCredentialReferences(context.Background(), workflow, []string{"9"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(dangling) != 1 || dangling[0].NodeName != "Dangling" || dangling[0].Reference.Name != "deleted" {
+		t.Errorf("Expected the reference of node Dangling to dangle, got %+v", dangling)
+	}
+}
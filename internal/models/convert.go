@@ -0,0 +1,46 @@
+package models
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NodesAccessFromSet converts a Terraform set of node types to NodeAccess
+// entries. Null and unknown sets yield no entries.
+func NodesAccessFromSet(ctx context.Context, set types.Set) ([]NodeAccess, diag.Diagnostics) {
+	if set.IsNull() || set.IsUnknown() {
+		return nil, nil
+	}
+
+	var nodeTypes []types.String
+	diags := set.ElementsAs(ctx, &nodeTypes, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	nodesAccess := make([]NodeAccess, 0, len(nodeTypes))
+	for _, nodeType := range nodeTypes {
+		nodesAccess = append(nodesAccess, NodeAccess{
+			NodeType: nodeType.ValueString(),
+		})
+	}
+
+	return nodesAccess, diags
+}
+
+// NodesAccessToSet converts NodeAccess entries to a Terraform set of node
+// types. No entries yield a null set.
+func NodesAccessToSet(ctx context.Context, nodesAccess []NodeAccess) (types.Set, diag.Diagnostics) {
+	if len(nodesAccess) == 0 {
+		return types.SetNull(types.StringType), nil
+	}
+
+	nodeTypeValues := make([]types.String, len(nodesAccess))
+	for i, na := range nodesAccess {
+		nodeTypeValues[i] = types.StringValue(na.NodeType)
+	}
+
+	return types.SetValueFrom(ctx, types.StringType, nodeTypeValues)
+}
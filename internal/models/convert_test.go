@@ -0,0 +1,48 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNodesAccessConversion(t *testing.T) {
+	ctx := context.Background()
+
+	set := types.SetValueMust(types.StringType, []attr.Value{
+		types.StringValue("httpRequest"),
+		types.StringValue("webhook"),
+	})
+
+	nodesAccess, diags := NodesAccessFromSet(ctx, set)
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", diags)
+	}
+	if len(nodesAccess) != 2 {
+		t.Errorf("Unexpected nodes access: %+v", nodesAccess)
+	}
+
+	roundTrip, diags := NodesAccessToSet(ctx, nodesAccess)
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", diags)
+	}
+	if !roundTrip.Equal(set) {
+		t.Errorf("Expected %s, got %s", set, roundTrip)
+	}
+}
+
+func TestNodesAccessConversionEmpty(t *testing.T) {
+	ctx := context.Background()
+
+	nodesAccess, diags := NodesAccessFromSet(ctx, types.SetNull(types.StringType))
+	if diags.HasError() || nodesAccess != nil {
+		t.Errorf("Expected no entries for null set, got %+v (%+v)", nodesAccess, diags)
+	}
+
+	set, diags := NodesAccessToSet(ctx, nil)
+	if diags.HasError() || !set.IsNull() {
+		t.Errorf("Expected null set for no entries, got %s (%+v)", set, diags)
+	}
+}
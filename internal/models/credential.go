@@ -0,0 +1,50 @@
+package models
+
+// Credential represents an n8n credential.
+type Credential struct {
+	ID          string                 `json:"id,omitempty"`
+	Name        string                 `json:"name"`
+	Type        string                 `json:"type"`
+	Data        map[string]interface{} `json:"data"`
+	NodesAccess []NodeAccess           `json:"nodesAccess,omitempty"`
+	HomeProject *Project               `json:"homeProject,omitempty"`
+	// SharedWithProjects is only reported by the internal API.
+	SharedWithProjects []Project `json:"sharedWithProjects,omitempty"`
+	CreatedAt          string    `json:"createdAt,omitempty"`
+	UpdatedAt          string    `json:"updatedAt,omitempty"`
+}
+
+// NodeAccess defines which nodes can access the credential.
+type NodeAccess struct {
+	NodeType string `json:"nodeType"`
+}
+
+// CredentialCreateRequest is the request body for creating a credential.
+type CredentialCreateRequest struct {
+	Name        string                 `json:"name"`
+	Type        string                 `json:"type"`
+	Data        map[string]interface{} `json:"data"`
+	NodesAccess []NodeAccess           `json:"nodesAccess,omitempty"`
+}
+
+// NewCredentialCreateRequest builds the create request for a credential.
+func NewCredentialCreateRequest(credential *Credential) CredentialCreateRequest {
+	return CredentialCreateRequest{
+		Name:        credential.Name,
+		Type:        credential.Type,
+		Data:        credential.Data,
+		NodesAccess: credential.NodesAccess,
+	}
+}
+
+// CredentialTransferRequest is the request body for moving a credential to
+// another project.
+type CredentialTransferRequest struct {
+	DestinationProjectID string `json:"destinationProjectId"`
+}
+
+// CredentialShareRequest is the request body for sharing a credential. The
+// list replaces all existing shares.
+type CredentialShareRequest struct {
+	ShareWithIDs []string `json:"shareWithIds"`
+}
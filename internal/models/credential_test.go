@@ -0,0 +1,47 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCredentialCreateRequestJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		credential *Credential
+		want       string
+	}{
+		{
+			name: "without nodes access",
+			credential: &Credential{
+				ID:   "ignored",
+				Name: "example",
+				Type: "httpBasicAuth",
+				Data: map[string]interface{}{"user": "u"},
+			},
+			want: `{"name":"example","type":"httpBasicAuth","data":{"user":"u"}}`,
+		},
+		{
+			name: "with nodes access",
+			credential: &Credential{
+				Name:        "example",
+				Type:        "httpHeaderAuth",
+				Data:        map[string]interface{}{"name": "X-Key"},
+				NodesAccess: []NodeAccess{{NodeType: "httpRequest"}},
+			},
+			want: `{"name":"example","type":"httpHeaderAuth","data":{"name":"X-Key"},"nodesAccess":[{"nodeType":"httpRequest"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(NewCredentialCreateRequest(tt.credential))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
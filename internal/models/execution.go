@@ -0,0 +1,14 @@
+package models
+
+import "encoding/json"
+
+// Execution represents a run of an n8n workflow.
+type Execution struct {
+	// ID is a number in the public API.
+	ID         json.Number `json:"id"`
+	WorkflowID string      `json:"workflowId"`
+	Mode       string      `json:"mode,omitempty"`
+	Status     string      `json:"status,omitempty"`
+	StartedAt  string      `json:"startedAt,omitempty"`
+	StoppedAt  string      `json:"stoppedAt,omitempty"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// Project represents an n8n project.
+type Project struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+}
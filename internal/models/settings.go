@@ -0,0 +1,32 @@
+package models
+
+// EnterpriseSettings is the enterprise section of the frontend settings of an
+// instance, reporting which enterprise features are licensed and enabled.
+type EnterpriseSettings struct {
+	Sharing         bool `json:"sharing"`
+	LDAP            bool `json:"ldap"`
+	SAML            bool `json:"saml"`
+	OIDC            bool `json:"oidc"`
+	LogStreaming    bool `json:"logStreaming"`
+	Variables       bool `json:"variables"`
+	SourceControl   bool `json:"sourceControl"`
+	ExternalSecrets bool `json:"externalSecrets"`
+	WorkflowHistory bool `json:"workflowHistory"`
+	Projects        struct {
+		Team struct {
+			// Limit is the number of team projects the license allows, -1
+			// for unlimited.
+			Limit int `json:"limit"`
+		} `json:"team"`
+	} `json:"projects"`
+}
+
+// TeamProjects reports whether the license allows team projects.
+func (e *EnterpriseSettings) TeamProjects() bool {
+	return e.Projects.Team.Limit != 0
+}
+
+// SSO reports whether any single sign-on method is enabled.
+func (e *EnterpriseSettings) SSO() bool {
+	return e.SAML || e.OIDC || e.LDAP
+}
@@ -0,0 +1,378 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Workflow represents an n8n workflow.
+// The node graph and settings are kept as raw JSON so definitions round-trip
+// without losing fields the provider does not model.
+type Workflow struct {
+	ID          string          `json:"id,omitempty"`
+	Name        string          `json:"name"`
+	Active      bool            `json:"active"`
+	Nodes       json.RawMessage `json:"nodes"`
+	Connections json.RawMessage `json:"connections"`
+	Settings    json.RawMessage `json:"settings,omitempty"`
+	StaticData  json.RawMessage `json:"staticData,omitempty"`
+	Tags        []WorkflowTag   `json:"tags,omitempty"`
+	VersionID   string          `json:"versionId,omitempty"`
+	IsArchived  bool            `json:"isArchived,omitempty"`
+	HomeProject *Project        `json:"homeProject,omitempty"`
+	CreatedAt   string          `json:"createdAt,omitempty"`
+	UpdatedAt   string          `json:"updatedAt,omitempty"`
+}
+
+// WorkflowUpdateRequest is the request body for updating a workflow. The
+// public API rejects read-only fields such as id, active and tags. pinData is
+// never sent, so test data pinned in exported definitions does not reach the
+// instance.
+type WorkflowUpdateRequest struct {
+	Name        string          `json:"name"`
+	Nodes       json.RawMessage `json:"nodes"`
+	Connections json.RawMessage `json:"connections"`
+	Settings    json.RawMessage `json:"settings"`
+	StaticData  json.RawMessage `json:"staticData,omitempty"`
+}
+
+// NewWorkflowUpdateRequest builds the update request for a workflow.
+func NewWorkflowUpdateRequest(workflow *Workflow) WorkflowUpdateRequest {
+	settings := workflow.Settings
+	if len(settings) == 0 {
+		settings = json.RawMessage(`{}`)
+	}
+
+	return WorkflowUpdateRequest{
+		Name:        workflow.Name,
+		Nodes:       workflow.Nodes,
+		Connections: workflow.Connections,
+		Settings:    settings,
+		StaticData:  workflow.StaticData,
+	}
+}
+
+// WorkflowMetadataUpdateRequest is the request body for changing a workflow's
+// metadata through the internal API without re-uploading the node graph.
+// Empty fields are left unchanged. The internal API rejects the update when
+// versionId is set and the workflow was edited since.
+type WorkflowMetadataUpdateRequest struct {
+	Name      string          `json:"name,omitempty"`
+	Settings  json.RawMessage `json:"settings,omitempty"`
+	VersionID string          `json:"versionId,omitempty"`
+}
+
+// NodeGraphEqual reports whether two workflows have semantically equal nodes
+// and connections, i.e. whether an update between them only changes metadata
+// such as the name or settings.
+func NodeGraphEqual(a, b *Workflow) (bool, error) {
+	graphA, err := json.Marshal(Workflow{Nodes: a.Nodes, Connections: a.Connections})
+	if err != nil {
+		return false, fmt.Errorf("error encoding workflow %s: %w", a.ID, err)
+	}
+	graphB, err := json.Marshal(Workflow{Nodes: b.Nodes, Connections: b.Connections})
+	if err != nil {
+		return false, fmt.Errorf("error encoding workflow %s: %w", b.ID, err)
+	}
+
+	return WorkflowDefinitionsEqual(graphA, graphB, NormalizeOptions{})
+}
+
+// WorkflowTransferRequest is the request body for moving a workflow to
+// another project.
+type WorkflowTransferRequest struct {
+	DestinationProjectID string `json:"destinationProjectId"`
+}
+
+// WorkflowTag is a tag assigned to a workflow.
+type WorkflowTag struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// TagNames returns the names of the workflow's tags.
+func (w *Workflow) TagNames() []string {
+	names := make([]string, len(w.Tags))
+	for i, tag := range w.Tags {
+		names[i] = tag.Name
+	}
+	return names
+}
+
+// WorkflowNode is the subset of a workflow node the provider inspects.
+type WorkflowNode struct {
+	Name        string                             `json:"name"`
+	Type        string                             `json:"type"`
+	Parameters  map[string]interface{}             `json:"parameters,omitempty"`
+	Credentials map[string]NodeCredentialReference `json:"credentials,omitempty"`
+	WebhookID   string                             `json:"webhookId,omitempty"`
+}
+
+// NodeTypePackage returns the npm package providing a node type, e.g.
+// n8n-nodes-base for n8n-nodes-base.httpRequest or @acme/n8n-nodes-crm for
+// @acme/n8n-nodes-crm.contact.
+func NodeTypePackage(nodeType string) string {
+	scope := ""
+	if strings.HasPrefix(nodeType, "@") {
+		if i := strings.Index(nodeType, "/"); i >= 0 {
+			scope, nodeType = nodeType[:i+1], nodeType[i+1:]
+		}
+	}
+
+	if i := strings.Index(nodeType, "."); i >= 0 {
+		nodeType = nodeType[:i]
+	}
+	return scope + nodeType
+}
+
+// NodeCredentialReference is a credential referenced by a workflow node.
+type NodeCredentialReference struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// ParseNodes decodes the workflow's nodes.
+func (w *Workflow) ParseNodes() ([]WorkflowNode, error) {
+	if len(w.Nodes) == 0 {
+		return nil, nil
+	}
+
+	var nodes []WorkflowNode
+	if err := json.Unmarshal(w.Nodes, &nodes); err != nil {
+		return nil, fmt.Errorf("error parsing nodes of workflow %s: %w", w.ID, err)
+	}
+	return nodes, nil
+}
+
+// NodesUsingCredential returns the names of the workflow's nodes that
+// reference the credential with the given ID.
+func (w *Workflow) NodesUsingCredential(credentialID string) ([]string, error) {
+	nodes, err := w.ParseNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, node := range nodes {
+		for _, reference := range node.Credentials {
+			if reference.ID == credentialID {
+				names = append(names, node.Name)
+				break
+			}
+		}
+	}
+	return names, nil
+}
+
+// webhookNodeType is the node type of webhook triggers.
+const webhookNodeType = "n8n-nodes-base.webhook"
+
+// WebhookURL is the endpoint of a webhook trigger of a workflow.
+type WebhookURL struct {
+	NodeName string
+	// Production is called once the workflow is active.
+	Production string
+	// Test is called while the workflow listens for a test event in the editor.
+	Test string
+}
+
+// WebhookURLs returns the endpoints of the workflow's webhook triggers on the
+// instance at baseURL. Webhooks without a path are served under their
+// webhook ID, as in the editor, and paths with route parameters under the
+// webhook ID followed by the path.
+func (w *Workflow) WebhookURLs(baseURL string) ([]WebhookURL, error) {
+	nodes, err := w.ParseNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	var urls []WebhookURL
+	for _, node := range nodes {
+		if node.Type != webhookNodeType {
+			continue
+		}
+
+		path := node.webhookPath()
+		if path == "" {
+			continue
+		}
+
+		urls = append(urls, WebhookURL{
+			NodeName:   node.Name,
+			Production: fmt.Sprintf("%s/webhook/%s", baseURL, path),
+			Test:       fmt.Sprintf("%s/webhook-test/%s", baseURL, path),
+		})
+	}
+	return urls, nil
+}
+
+// webhookPath returns the path a webhook trigger listens on, relative to
+// /webhook/. Webhooks without a path are served under their webhook ID, and
+// paths with route parameters, e.g. users/:id, are prefixed with it.
+func (n *WorkflowNode) webhookPath() string {
+	path, _ := n.Parameters["path"].(string)
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return n.WebhookID
+	}
+	if strings.Contains(path, ":") && n.WebhookID != "" {
+		path = n.WebhookID + "/" + path
+	}
+	return path
+}
+
+// WebhookEndpoint is an HTTP method and path a webhook trigger listens on.
+// n8n refuses to activate a workflow with an endpoint an active workflow
+// already listens on.
+type WebhookEndpoint struct {
+	NodeName string
+	Method   string
+	Path     string
+}
+
+// WebhookEndpoints returns the endpoints of the workflow's webhook triggers.
+// Triggers listening on several methods have an endpoint per method; the
+// method defaults to GET, as in the editor.
+func (w *Workflow) WebhookEndpoints() ([]WebhookEndpoint, error) {
+	nodes, err := w.ParseNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []WebhookEndpoint
+	for _, node := range nodes {
+		if node.Type != webhookNodeType {
+			continue
+		}
+
+		path := node.webhookPath()
+		if path == "" {
+			continue
+		}
+
+		for _, method := range node.webhookMethods() {
+			endpoints = append(endpoints, WebhookEndpoint{NodeName: node.Name, Method: method, Path: path})
+		}
+	}
+	return endpoints, nil
+}
+
+// webhookMethods returns the HTTP methods a webhook trigger listens on. The
+// httpMethod parameter is a list when multipleMethods is enabled.
+func (n *WorkflowNode) webhookMethods() []string {
+	var methods []string
+	switch value := n.Parameters["httpMethod"].(type) {
+	case string:
+		methods = append(methods, value)
+	case []interface{}:
+		for _, item := range value {
+			if method, ok := item.(string); ok {
+				methods = append(methods, method)
+			}
+		}
+	}
+
+	if len(methods) == 0 {
+		return []string{"GET"}
+	}
+	for i, method := range methods {
+		methods[i] = strings.ToUpper(method)
+	}
+	return methods
+}
+
+// executeWorkflowNodeType is the node type calling sub-workflows.
+const executeWorkflowNodeType = "n8n-nodes-base.executeWorkflow"
+
+// SubWorkflowReference is a sub-workflow called by a node of a workflow.
+type SubWorkflowReference struct {
+	NodeName   string
+	WorkflowID string
+	// WorkflowName is the name the editor cached when the sub-workflow was
+	// selected from the list, if any.
+	WorkflowName string
+}
+
+// SubWorkflowReferences returns the sub-workflows the workflow's Execute
+// Workflow nodes call from the database. Sub-workflows loaded from files,
+// URLs or parameters and IDs given as expressions are not references.
+func (w *Workflow) SubWorkflowReferences() ([]SubWorkflowReference, error) {
+	nodes, err := w.ParseNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	var references []SubWorkflowReference
+	for _, node := range nodes {
+		if node.Type != executeWorkflowNodeType {
+			continue
+		}
+		if source, ok := node.Parameters["source"].(string); ok && source != "database" {
+			continue
+		}
+
+		reference := SubWorkflowReference{NodeName: node.Name}
+		switch workflowID := node.Parameters["workflowId"].(type) {
+		case string:
+			// Before version 1.1 of the node, the ID is a plain string.
+			reference.WorkflowID = workflowID
+		case map[string]interface{}:
+			// Resource locators hold the ID in value and the name of
+			// workflows selected from the list in cachedResultName.
+			reference.WorkflowID, _ = workflowID["value"].(string)
+			reference.WorkflowName, _ = workflowID["cachedResultName"].(string)
+		}
+
+		if reference.WorkflowID == "" || strings.HasPrefix(reference.WorkflowID, "=") {
+			continue
+		}
+		references = append(references, reference)
+	}
+	return references, nil
+}
+
+// NodeCredential is a credential referenced by a node of a workflow.
+type NodeCredential struct {
+	NodeName string
+	// Type is the credential type, e.g. slackApi.
+	Type      string
+	Reference NodeCredentialReference
+}
+
+// NodeCredentials returns the credentials referenced by the workflow's nodes,
+// ordered by node and credential type.
+func (w *Workflow) NodeCredentials() ([]NodeCredential, error) {
+	nodes, err := w.ParseNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	var credentials []NodeCredential
+	for _, node := range nodes {
+		credentialTypes := make([]string, 0, len(node.Credentials))
+		for credentialType := range node.Credentials {
+			credentialTypes = append(credentialTypes, credentialType)
+		}
+		sort.Strings(credentialTypes)
+
+		for _, credentialType := range credentialTypes {
+			credentials = append(credentials, NodeCredential{
+				NodeName:  node.Name,
+				Type:      credentialType,
+				Reference: node.Credentials[credentialType],
+			})
+		}
+	}
+	return credentials, nil
+}
+
+// CredentialReference lists the nodes of a workflow that use a credential.
+type CredentialReference struct {
+	WorkflowID   string
+	WorkflowName string
+	Nodes        []string
+}
@@ -0,0 +1,378 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// serverManagedFields lists top-level fields of a workflow document that n8n
+// manages itself. They are not part of the definition and differ between an
+// exported file and the API response.
+var serverManagedFields = []string{
+	"id", "active", "versionId", "createdAt", "updatedAt", "tags", "shared",
+	"triggerCount", "isArchived", "homeProject", "meta",
+}
+
+// nodeDefaults lists node fields n8n adds with their default value. Nodes
+// carrying the default are equal to nodes omitting the field.
+var nodeDefaults = map[string]interface{}{
+	"disabled":         false,
+	"alwaysOutputData": false,
+	"executeOnce":      false,
+	"retryOnFail":      false,
+	"continueOnFail":   false,
+	"notesInFlow":      false,
+	"onError":          "stopWorkflow",
+}
+
+// stickyNoteType is the node type of sticky notes, which annotate the canvas
+// and have no effect on executions.
+const stickyNoteType = "n8n-nodes-base.stickyNote"
+
+// NormalizeOptions selects the parts of a workflow definition ignored when
+// normalizing it.
+type NormalizeOptions struct {
+	// IgnoreUILayout removes node positions and sticky notes, which change
+	// whenever the canvas is rearranged in the editor.
+	IgnoreUILayout bool
+	// CompareStaticData keeps staticData, which n8n mutates at runtime, e.g.
+	// to store the cursors of polling triggers. It is removed by default so
+	// executed workflows are not reported as changed.
+	CompareStaticData bool
+	// IgnorePinData removes pinData, the test data pinned to nodes in the
+	// editor, so pins in exported definitions do not cause drift.
+	IgnorePinData bool
+}
+
+// NormalizeWorkflowDefinition returns the canonical JSON form of a workflow
+// definition so definitions can be compared regardless of formatting. Object
+// keys are sorted, numbers are rendered in their shortest form, nodes are
+// ordered by name, and server-managed fields, null values and fields equal to
+// n8n's defaults are removed. Further parts are removed as selected by options.
+func NormalizeWorkflowDefinition(definition []byte, options NormalizeOptions) ([]byte, error) {
+	document, err := decodeWorkflowDefinition(definition)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range serverManagedFields {
+		delete(document, field)
+	}
+	if !options.CompareStaticData {
+		delete(document, "staticData")
+	}
+	if options.IgnorePinData {
+		delete(document, "pinData")
+	}
+
+	if nodes, ok := document["nodes"].([]interface{}); ok {
+		if options.IgnoreUILayout {
+			nodes = removeUILayout(nodes)
+			document["nodes"] = nodes
+		}
+		for _, node := range nodes {
+			if object, ok := node.(map[string]interface{}); ok {
+				removeNodeDefaults(object)
+			}
+		}
+		sort.SliceStable(nodes, func(i, j int) bool {
+			return nodeName(nodes[i]) < nodeName(nodes[j])
+		})
+	}
+	if settings, ok := document["settings"].(map[string]interface{}); ok && len(settings) == 0 {
+		delete(document, "settings")
+	}
+
+	normalized, err := json.Marshal(normalizeValue(document))
+	if err != nil {
+		return nil, fmt.Errorf("error encoding workflow definition: %w", err)
+	}
+	return normalized, nil
+}
+
+// WorkflowDefinitionsEqual reports whether two workflow definitions are
+// semantically equal, i.e. equal after normalization with the given options.
+func WorkflowDefinitionsEqual(a, b []byte, options NormalizeOptions) (bool, error) {
+	normalizedA, err := NormalizeWorkflowDefinition(a, options)
+	if err != nil {
+		return false, err
+	}
+	normalizedB, err := NormalizeWorkflowDefinition(b, options)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(normalizedA, normalizedB), nil
+}
+
+// InjectCredentials rewrites the credential references of the workflow's
+// nodes. References whose name or ID matches a key of credentialIDs, such as
+// the credential names of an exported workflow or placeholders like
+// "slack_prod", are pointed at the credential with the mapped ID, so
+// definitions can be promoted across instances without editing them.
+func InjectCredentials(definition []byte, credentialIDs map[string]string) ([]byte, error) {
+	document, err := decodeWorkflowDefinition(definition)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, _ := document["nodes"].([]interface{})
+	for _, node := range nodes {
+		object, ok := node.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		credentials, _ := object["credentials"].(map[string]interface{})
+		for _, credential := range credentials {
+			reference, ok := credential.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			id, ok := credentialIDs[referenceKey(reference, "name")]
+			if !ok {
+				id, ok = credentialIDs[referenceKey(reference, "id")]
+			}
+			if ok {
+				reference["id"] = id
+			}
+		}
+	}
+
+	injected, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding workflow definition: %w", err)
+	}
+	return injected, nil
+}
+
+// templateVarPattern matches ${name} placeholders, and $${name} escapes
+// of them, in workflow definitions.
+var templateVarPattern = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// RenderWorkflowTemplate substitutes ${name} placeholders in the string values
+// of a workflow definition, e.g. URLs, channel names or schedule expressions,
+// so one definition can serve several environments. Only the names in vars
+// are substituted: code nodes commonly contain JavaScript template literals
+// of the same form, which are left alone. $${name} renders a literal ${name}
+// for names in vars.
+func RenderWorkflowTemplate(definition []byte, vars map[string]string) ([]byte, error) {
+	document, err := decodeWorkflowDefinition(definition)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered, err := json.Marshal(renderTemplateValue(document, vars))
+	if err != nil {
+		return nil, fmt.Errorf("error encoding workflow definition: %w", err)
+	}
+	return rendered, nil
+}
+
+// renderTemplateValue substitutes placeholders in the strings of a decoded
+// JSON value.
+func renderTemplateValue(value interface{}, vars map[string]string) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, field := range typed {
+			typed[key] = renderTemplateValue(field, vars)
+		}
+		return typed
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = renderTemplateValue(item, vars)
+		}
+		return typed
+	case string:
+		return templateVarPattern.ReplaceAllStringFunc(typed, func(match string) string {
+			name := templateVarPattern.FindStringSubmatch(match)[1]
+			replacement, ok := vars[name]
+			switch {
+			case !ok:
+				return match
+			case strings.HasPrefix(match, "$$"):
+				return match[1:]
+			default:
+				return replacement
+			}
+		})
+	default:
+		return value
+	}
+}
+
+// referenceKey returns a string field of a decoded credential reference.
+func referenceKey(reference map[string]interface{}, field string) string {
+	value, _ := reference[field].(string)
+	return value
+}
+
+// ValidateWorkflowDefinition checks the structure of a workflow definition
+// and returns every problem found: the definition must have nodes with unique
+// names including a trigger, and connections may only connect nodes of the
+// workflow.
+func ValidateWorkflowDefinition(definition []byte) []error {
+	var document struct {
+		Nodes       []WorkflowNode `json:"nodes"`
+		Connections map[string]map[string][][]struct {
+			Node string `json:"node"`
+		} `json:"connections"`
+	}
+	if err := json.Unmarshal(definition, &document); err != nil {
+		return []error{fmt.Errorf("error parsing workflow definition: %w", err)}
+	}
+
+	if len(document.Nodes) == 0 {
+		return []error{fmt.Errorf("workflow definition has no nodes")}
+	}
+
+	var problems []error
+	names := make(map[string]bool, len(document.Nodes))
+	hasTrigger := false
+	for _, node := range document.Nodes {
+		if names[node.Name] {
+			problems = append(problems, fmt.Errorf("node name %q is used by more than one node", node.Name))
+		}
+		names[node.Name] = true
+		hasTrigger = hasTrigger || isTriggerNodeType(node.Type)
+	}
+	if !hasTrigger {
+		problems = append(problems, fmt.Errorf("workflow definition has no trigger node"))
+	}
+
+	for _, source := range sortedKeys(document.Connections) {
+		if !names[source] {
+			problems = append(problems, fmt.Errorf("connections reference unknown source node %q", source))
+		}
+		outputs := document.Connections[source]
+		for _, outputType := range sortedKeys(outputs) {
+			for _, targets := range outputs[outputType] {
+				for _, target := range targets {
+					if !names[target.Node] {
+						problems = append(problems, fmt.Errorf("node %q is connected to unknown node %q", source, target.Node))
+					}
+				}
+			}
+		}
+	}
+
+	return problems
+}
+
+// isTriggerNodeType reports whether nodes of the type start executions. By
+// convention, trigger node types end in "Trigger"; webhooks and the start node
+// of old workflows are the exceptions.
+func isTriggerNodeType(nodeType string) bool {
+	return strings.HasSuffix(nodeType, "Trigger") ||
+		nodeType == webhookNodeType ||
+		nodeType == "n8n-nodes-base.start"
+}
+
+// sortedKeys returns the keys of a map in ascending order.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// decodeWorkflowDefinition decodes a workflow definition, keeping numbers as
+// json.Number so their formatting can be normalized without losing precision.
+func decodeWorkflowDefinition(definition []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(definition))
+	decoder.UseNumber()
+
+	var document map[string]interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("error parsing workflow definition: %w", err)
+	}
+	if document == nil {
+		return nil, fmt.Errorf("error parsing workflow definition: expected a JSON object")
+	}
+	return document, nil
+}
+
+// removeNodeDefaults removes the fields of a node that hold n8n's defaults.
+func removeNodeDefaults(node map[string]interface{}) {
+	for field, value := range nodeDefaults {
+		if node[field] == value {
+			delete(node, field)
+		}
+	}
+	if parameters, ok := node["parameters"].(map[string]interface{}); ok && len(parameters) == 0 {
+		delete(node, "parameters")
+	}
+}
+
+// removeUILayout removes sticky notes and the positions of the other nodes.
+func removeUILayout(nodes []interface{}) []interface{} {
+	kept := nodes[:0]
+	for _, node := range nodes {
+		object, ok := node.(map[string]interface{})
+		if !ok {
+			kept = append(kept, node)
+			continue
+		}
+		if object["type"] == stickyNoteType {
+			continue
+		}
+		delete(object, "position")
+		kept = append(kept, object)
+	}
+	return kept
+}
+
+// nodeName returns the name of a decoded node.
+func nodeName(node interface{}) string {
+	if object, ok := node.(map[string]interface{}); ok {
+		if name, ok := object["name"].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// normalizeValue removes null object fields and renders numbers in their
+// shortest form, e.g. 1.0 and 1e0 as 1, in a decoded JSON value.
+func normalizeValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, field := range typed {
+			if field == nil {
+				delete(typed, key)
+				continue
+			}
+			typed[key] = normalizeValue(field)
+		}
+		return typed
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = normalizeValue(item)
+		}
+		return typed
+	case json.Number:
+		return normalizeNumber(typed)
+	default:
+		return value
+	}
+}
+
+// normalizeNumber renders a JSON number in its shortest form. Integers are
+// kept exact; other numbers are rendered as the shortest float64 form.
+func normalizeNumber(number json.Number) json.Number {
+	if _, err := strconv.ParseInt(number.String(), 10, 64); err == nil {
+		return number
+	}
+
+	float, err := number.Float64()
+	if err != nil {
+		return number
+	}
+	return json.Number(strconv.FormatFloat(float, 'f', -1, 64))
+}
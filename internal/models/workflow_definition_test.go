@@ -0,0 +1,287 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNormalizeWorkflowDefinition(t *testing.T) {
+	definition := `{
+		"name": "Sync",
+		"id": "42",
+		"versionId": "abc",
+		"active": true,
+		"settings": {},
+		"connections": {},
+		"nodes": [
+			{"name": "Transform", "type": "n8n-nodes-base.set", "typeVersion": 3.0, "position": [440, 300.0], "disabled": false, "parameters": {"value": 1.50, "note": null}},
+			{"name": "Start", "type": "n8n-nodes-base.manualTrigger", "typeVersion": 1, "position": [240, 300], "parameters": {}, "onError": "stopWorkflow"}
+		]
+	}`
+
+	normalized, err := NormalizeWorkflowDefinition([]byte(definition), NormalizeOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `{"connections":{},"name":"Sync","nodes":[` +
+		`{"name":"Start","position":[240,300],"type":"n8n-nodes-base.manualTrigger","typeVersion":1},` +
+		`{"name":"Transform","parameters":{"value":1.5},"position":[440,300],"type":"n8n-nodes-base.set","typeVersion":3}]}`
+	if string(normalized) != expected {
+		t.Errorf("Expected %s, got %s", expected, normalized)
+	}
+}
+
+func TestNormalizeWorkflowDefinitionKeepsLargeIntegers(t *testing.T) {
+	normalized, err := NormalizeWorkflowDefinition([]byte(`{"nodes":[{"name":"A","parameters":{"id":9007199254740993}}]}`), NormalizeOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `{"nodes":[{"name":"A","parameters":{"id":9007199254740993}}]}`
+	if string(normalized) != expected {
+		t.Errorf("Expected %s, got %s", expected, normalized)
+	}
+}
+
+func TestNormalizeWorkflowDefinitionInvalid(t *testing.T) {
+	for _, definition := range []string{`not json`, `null`, `[]`} {
+		if _, err := NormalizeWorkflowDefinition([]byte(definition), NormalizeOptions{}); err == nil {
+			t.Errorf("Expected error for %s but got none", definition)
+		}
+	}
+}
+
+func TestWorkflowDefinitionsEqual(t *testing.T) {
+	exported := `{"name":"Sync","nodes":[{"name":"Start","type":"n8n-nodes-base.manualTrigger","position":[240,300]}],"connections":{}}`
+	fromAPI := `{
+		"id": "42",
+		"name": "Sync",
+		"connections": {},
+		"nodes": [{"position": [240.0, 300.0], "type": "n8n-nodes-base.manualTrigger", "name": "Start", "disabled": false}],
+		"settings": {},
+		"updatedAt": "2024-01-01T00:00:00.000Z"
+	}`
+
+	equal, err := WorkflowDefinitionsEqual([]byte(exported), []byte(fromAPI), NormalizeOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !equal {
+		t.Errorf("Expected definitions to be equal")
+	}
+
+	renamed := `{"name":"Sync","nodes":[{"name":"Begin","type":"n8n-nodes-base.manualTrigger","position":[240,300]}],"connections":{}}`
+	equal, err = WorkflowDefinitionsEqual([]byte(exported), []byte(renamed), NormalizeOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if equal {
+		t.Errorf("Expected definitions to differ")
+	}
+}
+
+func TestWorkflowDefinitionsEqualIgnoreUILayout(t *testing.T) {
+	original := `{"name":"Sync","nodes":[{"name":"Start","type":"n8n-nodes-base.manualTrigger","position":[240,300]}],"connections":{}}`
+	rearranged := `{"name":"Sync","nodes":[
+		{"name":"Start","type":"n8n-nodes-base.manualTrigger","position":[600,120]},
+		{"name":"Sticky Note","type":"n8n-nodes-base.stickyNote","position":[0,0],"parameters":{"content":"Syncs every hour"}}
+	],"connections":{}}`
+
+	equal, err := WorkflowDefinitionsEqual([]byte(original), []byte(rearranged), NormalizeOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if equal {
+		t.Errorf("Expected definitions to differ when the layout is compared")
+	}
+
+	equal, err = WorkflowDefinitionsEqual([]byte(original), []byte(rearranged), NormalizeOptions{IgnoreUILayout: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !equal {
+		t.Errorf("Expected definitions to be equal when the layout is ignored")
+	}
+}
+
+func TestWorkflowDefinitionsEqualStaticData(t *testing.T) {
+	deployed := `{"name":"Poll","nodes":[],"connections":{}}`
+	executed := `{"name":"Poll","nodes":[],"connections":{},"staticData":{"node:Poll Feed":{"lastItemDate":"2024-01-01T00:00:00.000Z"}}}`
+
+	equal, err := WorkflowDefinitionsEqual([]byte(deployed), []byte(executed), NormalizeOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !equal {
+		t.Errorf("Expected staticData to be ignored by default")
+	}
+
+	equal, err = WorkflowDefinitionsEqual([]byte(deployed), []byte(executed), NormalizeOptions{CompareStaticData: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if equal {
+		t.Errorf("Expected definitions to differ when staticData is compared")
+	}
+}
+
+func TestInjectCredentials(t *testing.T) {
+	definition := `{"name":"Notify","nodes":[
+		{"name":"Slack","type":"n8n-nodes-base.slack","credentials":{"slackApi":{"id":"dev-1","name":"Slack account"}}},
+		{"name":"Fetch","type":"n8n-nodes-base.httpRequest","credentials":{"httpBasicAuth":{"id":"api_basic_auth"}}},
+		{"name":"Other","type":"n8n-nodes-base.postgres","credentials":{"postgres":{"id":"9","name":"Postgres"}}}
+	],"connections":{}}`
+
+	injected, err := InjectCredentials([]byte(definition), map[string]string{
+		"Slack account":  "prod-1",
+		"api_basic_auth": "prod-2",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	workflow := &Workflow{}
+	if err := json.Unmarshal(injected, workflow); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	nodes, err := workflow.ParseNodes()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]string{"Slack": "prod-1", "Fetch": "prod-2", "Other": "9"}
+	for _, node := range nodes {
+		for _, reference := range node.Credentials {
+			if reference.ID != expected[node.Name] {
+				t.Errorf("Expected node %s to reference %s, got %s", node.Name, expected[node.Name], reference.ID)
+			}
+		}
+	}
+}
+
+func TestWorkflowDefinitionsEqualIgnorePinData(t *testing.T) {
+	deployed := `{"name":"Sync","nodes":[],"connections":{}}`
+	exported := `{"name":"Sync","nodes":[],"connections":{},"pinData":{"Start":[{"json":{"id":1}}]}}`
+
+	equal, err := WorkflowDefinitionsEqual([]byte(deployed), []byte(exported), NormalizeOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if equal {
+		t.Errorf("Expected definitions to differ when pinData is compared")
+	}
+
+	equal, err = WorkflowDefinitionsEqual([]byte(deployed), []byte(exported), NormalizeOptions{IgnorePinData: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !equal {
+		t.Errorf("Expected definitions to be equal when pinData is ignored")
+	}
+}
+
+func TestValidateWorkflowDefinition(t *testing.T) {
+	testCases := []struct {
+		name       string
+		definition string
+		expected   []string
+	}{
+		{
+			name: "valid",
+			definition: `{"nodes":[{"name":"Start","type":"n8n-nodes-base.manualTrigger"},{"name":"Set","type":"n8n-nodes-base.set"}],
+				"connections":{"Start":{"main":[[{"node":"Set","type":"main","index":0}]]}}}`,
+		},
+		{
+			name:       "invalid JSON",
+			definition: `{"nodes":`,
+			expected:   []string{"error parsing workflow definition: unexpected end of JSON input"},
+		},
+		{
+			name:       "no nodes",
+			definition: `{"nodes":[],"connections":{}}`,
+			expected:   []string{"workflow definition has no nodes"},
+		},
+		{
+			name: "duplicate names",
+			definition: `{"nodes":[{"name":"Start","type":"n8n-nodes-base.manualTrigger"},{"name":"Start","type":"n8n-nodes-base.set"}],
+				"connections":{}}`,
+			expected: []string{`node name "Start" is used by more than one node`},
+		},
+		{
+			name:       "no trigger",
+			definition: `{"nodes":[{"name":"Set","type":"n8n-nodes-base.set"}],"connections":{}}`,
+			expected:   []string{"workflow definition has no trigger node"},
+		},
+		{
+			name:       "webhook trigger",
+			definition: `{"nodes":[{"name":"Orders","type":"n8n-nodes-base.webhook"}],"connections":{}}`,
+		},
+		{
+			name: "dangling connections",
+			definition: `{"nodes":[{"name":"Start","type":"n8n-nodes-base.manualTrigger"}],
+				"connections":{"Start":{"main":[[{"node":"Set","type":"main","index":0}]]},"Removed":{"main":[]}}}`,
+			expected: []string{
+				`connections reference unknown source node "Removed"`,
+				`node "Start" is connected to unknown node "Set"`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			problems := ValidateWorkflowDefinition([]byte(tc.definition))
+			if len(problems) != len(tc.expected) {
+				t.Fatalf("Expected %d problems, got %v", len(tc.expected), problems)
+			}
+			for i, problem := range problems {
+				if problem.Error() != tc.expected[i] {
+					t.Errorf("Expected %q, got %q", tc.expected[i], problem.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestRenderWorkflowTemplate(t *testing.T) {
+	definition := `{"name":"Notify ${env}","nodes":[
+		{"name":"Slack","type":"n8n-nodes-base.slack","parameters":{"channel":"${channel}","text":"Costs $${amount}"}},
+		{"name":"Code","type":"n8n-nodes-base.code","parameters":{"jsCode":"return [{json: {greeting: ` + "`" + `Hi ${name}` + "`" + `}}];"}},
+		{"name":"Wait","type":"n8n-nodes-base.wait","parameters":{"amount":5}}
+	],"connections":{}}`
+
+	rendered, err := RenderWorkflowTemplate([]byte(definition), map[string]string{
+		"env":     "prod",
+		"channel": "#alerts-\"prod\"",
+		"amount":  "unused",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var document struct {
+		Name  string `json:"name"`
+		Nodes []struct {
+			Parameters map[string]interface{} `json:"parameters"`
+		} `json:"nodes"`
+	}
+	if err := json.Unmarshal(rendered, &document); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if document.Name != "Notify prod" {
+		t.Errorf("Expected name to be rendered, got %q", document.Name)
+	}
+	if got := document.Nodes[0].Parameters["channel"]; got != `#alerts-"prod"` {
+		t.Errorf("Expected channel to be rendered and escaped, got %v", got)
+	}
+	if got := document.Nodes[0].Parameters["text"]; got != "Costs ${amount}" {
+		t.Errorf("Expected escaped placeholder to be kept literally, got %v", got)
+	}
+	if got := document.Nodes[1].Parameters["jsCode"]; got != "return [{json: {greeting: `Hi ${name}`}}];" {
+		t.Errorf("Expected unknown placeholder to be left alone, got %v", got)
+	}
+	if got := document.Nodes[2].Parameters["amount"]; got != float64(5) {
+		t.Errorf("Expected numbers to be kept, got %v", got)
+	}
+}
@@ -0,0 +1,140 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// WorkflowSettings holds the workflow settings the provider manages. Settings
+// are stored as raw JSON on Workflow, so settings not listed here are kept
+// as they are.
+type WorkflowSettings struct {
+	// ExecutionOrder is "v0" (legacy) or "v1" (recommended).
+	ExecutionOrder string `json:"executionOrder,omitempty"`
+	// SaveDataErrorExecution is "all" or "none".
+	SaveDataErrorExecution string `json:"saveDataErrorExecution,omitempty"`
+	// SaveDataSuccessExecution is "all" or "none".
+	SaveDataSuccessExecution string `json:"saveDataSuccessExecution,omitempty"`
+	SaveManualExecutions     *bool  `json:"saveManualExecutions,omitempty"`
+	SaveExecutionProgress    *bool  `json:"saveExecutionProgress,omitempty"`
+	// CallerPolicy controls which workflows may call this workflow as a
+	// sub-workflow: "any", "none", "workflowsFromSameOwner" or
+	// "workflowsFromAList".
+	CallerPolicy string `json:"callerPolicy,omitempty"`
+	// CallerIDs is the comma separated list of workflow IDs allowed to call
+	// this workflow when CallerPolicy is "workflowsFromAList".
+	CallerIDs string `json:"callerIds,omitempty"`
+}
+
+// callerPolicyFromAList is the caller policy restricting callers to CallerIDs.
+const callerPolicyFromAList = "workflowsFromAList"
+
+// workflowSettingValues lists the accepted values of enumerated settings.
+var workflowSettingValues = []struct {
+	name   string
+	value  func(*WorkflowSettings) string
+	values []string
+}{
+	{"executionOrder", func(s *WorkflowSettings) string { return s.ExecutionOrder }, []string{"v0", "v1"}},
+	{"saveDataErrorExecution", func(s *WorkflowSettings) string { return s.SaveDataErrorExecution }, []string{"all", "none"}},
+	{"saveDataSuccessExecution", func(s *WorkflowSettings) string { return s.SaveDataSuccessExecution }, []string{"all", "none"}},
+	{"callerPolicy", func(s *WorkflowSettings) string { return s.CallerPolicy }, []string{"any", "none", "workflowsFromSameOwner", callerPolicyFromAList}},
+}
+
+// Validate returns every setting with a value n8n does not accept.
+func (s *WorkflowSettings) Validate() []error {
+	var problems []error
+	for _, setting := range workflowSettingValues {
+		value := setting.value(s)
+		if value == "" || slices.Contains(setting.values, value) {
+			continue
+		}
+		problems = append(problems, fmt.Errorf("setting %s must be one of %v, got %q", setting.name, setting.values, value))
+	}
+
+	if s.CallerIDs != "" && s.CallerPolicy != callerPolicyFromAList {
+		problems = append(problems, fmt.Errorf("setting callerIds requires callerPolicy %q", callerPolicyFromAList))
+	}
+	return problems
+}
+
+// CallerIDList returns the workflow IDs allowed to call the workflow.
+func (s *WorkflowSettings) CallerIDList() []string {
+	var ids []string
+	for _, id := range strings.Split(s.CallerIDs, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// SetCallerIDList restricts the callers of the workflow to the given workflow
+// IDs, setting CallerPolicy accordingly.
+func (s *WorkflowSettings) SetCallerIDList(ids []string) {
+	s.CallerPolicy = callerPolicyFromAList
+	s.CallerIDs = strings.Join(ids, ",")
+}
+
+// settingDefault is the value n8n stores for settings left at the instance
+// default.
+const settingDefault = `"DEFAULT"`
+
+// ParseSettings decodes the workflow's settings. Settings n8n stores as
+// "DEFAULT" are left empty.
+func (w *Workflow) ParseSettings() (*WorkflowSettings, error) {
+	settings := &WorkflowSettings{}
+	if len(w.Settings) == 0 {
+		return settings, nil
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(w.Settings, &values); err != nil {
+		return nil, fmt.Errorf("error parsing settings of workflow %s: %w", w.ID, err)
+	}
+	for key, value := range values {
+		if string(value) == settingDefault {
+			delete(values, key)
+		}
+	}
+
+	explicit, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding settings of workflow %s: %w", w.ID, err)
+	}
+	if err := json.Unmarshal(explicit, settings); err != nil {
+		return nil, fmt.Errorf("error parsing settings of workflow %s: %w", w.ID, err)
+	}
+	return settings, nil
+}
+
+// ApplySettings sets the non-empty settings on the workflow, keeping all
+// other settings, e.g. those configured in the editor.
+func (w *Workflow) ApplySettings(settings *WorkflowSettings) error {
+	merged := map[string]json.RawMessage{}
+	if len(w.Settings) > 0 {
+		if err := json.Unmarshal(w.Settings, &merged); err != nil {
+			return fmt.Errorf("error parsing settings of workflow %s: %w", w.ID, err)
+		}
+	}
+
+	encoded, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("error encoding settings: %w", err)
+	}
+	var changes map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &changes); err != nil {
+		return fmt.Errorf("error encoding settings: %w", err)
+	}
+	for key, value := range changes {
+		merged[key] = value
+	}
+
+	w.Settings, err = json.Marshal(merged)
+	if err != nil {
+		return fmt.Errorf("error encoding settings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWorkflowSettingsValidate(t *testing.T) {
+	valid := &WorkflowSettings{ExecutionOrder: "v1", SaveDataSuccessExecution: "none"}
+	if problems := valid.Validate(); len(problems) != 0 {
+		t.Errorf("Expected no problems, got %v", problems)
+	}
+
+	invalid := &WorkflowSettings{ExecutionOrder: "v2", SaveDataErrorExecution: "some"}
+	problems := invalid.Validate()
+	if len(problems) != 2 {
+		t.Fatalf("Expected 2 problems, got %v", problems)
+	}
+	expected := `setting executionOrder must be one of [v0 v1], got "v2"`
+	if problems[0].Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, problems[0].Error())
+	}
+}
+
+func TestWorkflowApplySettings(t *testing.T) {
+	workflow := &Workflow{
+		ID:       "1",
+		Settings: json.RawMessage(`{"executionOrder":"v0","timezone":"Europe/Berlin"}`),
+	}
+
+	saveManual := false
+	err := workflow.ApplySettings(&WorkflowSettings{ExecutionOrder: "v1", SaveManualExecutions: &saveManual})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `{"executionOrder":"v1","saveManualExecutions":false,"timezone":"Europe/Berlin"}`
+	if string(workflow.Settings) != expected {
+		t.Errorf("Expected %s, got %s", expected, workflow.Settings)
+	}
+
+	settings, err := workflow.ParseSettings()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if settings.ExecutionOrder != "v1" || settings.SaveManualExecutions == nil || *settings.SaveManualExecutions {
+		t.Errorf("Unexpected settings: %+v", settings)
+	}
+}
+
+func TestWorkflowParseSettingsEmpty(t *testing.T) {
+	settings, err := (&Workflow{ID: "1"}).ParseSettings()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if *settings != (WorkflowSettings{}) {
+		t.Errorf("Expected empty settings, got %+v", settings)
+	}
+}
+
+func TestWorkflowParseSettingsDefault(t *testing.T) {
+	workflow := &Workflow{Settings: json.RawMessage(`{"executionOrder":"v1","saveManualExecutions":"DEFAULT","saveDataErrorExecution":"DEFAULT"}`)}
+	settings, err := workflow.ParseSettings()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if settings.ExecutionOrder != "v1" || settings.SaveManualExecutions != nil || settings.SaveDataErrorExecution != "" {
+		t.Errorf("Expected the DEFAULT settings to be empty, got %+v", settings)
+	}
+}
+
+func TestWorkflowSettingsCallerPolicy(t *testing.T) {
+	settings := &WorkflowSettings{}
+	settings.SetCallerIDList([]string{"12", "34"})
+	if settings.CallerPolicy != "workflowsFromAList" || settings.CallerIDs != "12,34" {
+		t.Errorf("Unexpected settings: %+v", settings)
+	}
+	if problems := settings.Validate(); len(problems) != 0 {
+		t.Errorf("Expected no problems, got %v", problems)
+	}
+
+	parsed := &WorkflowSettings{CallerIDs: " 12, 34 ,"}
+	ids := parsed.CallerIDList()
+	if len(ids) != 2 || ids[0] != "12" || ids[1] != "34" {
+		t.Errorf("Expected [12 34], got %v", ids)
+	}
+
+	mismatched := &WorkflowSettings{CallerPolicy: "any", CallerIDs: "12"}
+	problems := mismatched.Validate()
+	if len(problems) != 1 || problems[0].Error() != `setting callerIds requires callerPolicy "workflowsFromAList"` {
+		t.Errorf("Unexpected problems: %v", problems)
+	}
+
+	unknown := &WorkflowSettings{CallerPolicy: "everyone"}
+	if problems := unknown.Validate(); len(problems) != 1 {
+		t.Errorf("Expected 1 problem, got %v", problems)
+	}
+}
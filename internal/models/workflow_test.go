@@ -0,0 +1,237 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWorkflowNodesUsingCredential(t *testing.T) {
+	workflow := &Workflow{
+		ID: "1",
+		Nodes: json.RawMessage(`[
+			{"name":"Fetch","type":"n8n-nodes-base.httpRequest","credentials":{"httpBasicAuth":{"id":"42","name":"api"}}},
+			{"name":"Notify","type":"n8n-nodes-base.slack","credentials":{"slackApi":{"id":"7","name":"slack"}}},
+			{"name":"Start","type":"n8n-nodes-base.manualTrigger"}
+		]`),
+	}
+
+	names, err := workflow.NodesUsingCredential("42")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(names) != 1 || names[0] != "Fetch" {
+		t.Errorf("Expected [Fetch], got %v", names)
+	}
+
+	names, err = workflow.NodesUsingCredential("missing")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(names) != 0 {
+		t.Errorf("Expected no nodes, got %v", names)
+	}
+}
+
+func TestWorkflowParseNodesInvalid(t *testing.T) {
+	workflow := &Workflow{ID: "1", Nodes: json.RawMessage(`{"not":"a list"}`)}
+
+	if _, err := workflow.ParseNodes(); err == nil {
+		t.Errorf("Expected error but got none")
+	}
+}
+
+func TestNewWorkflowUpdateRequestStripsPinData(t *testing.T) {
+	var workflow Workflow
+	definition := `{"name":"Sync","nodes":[],"connections":{},"pinData":{"Start":[{"json":{"id":1}}]}}`
+	if err := json.Unmarshal([]byte(definition), &workflow); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	body, err := json.Marshal(NewWorkflowUpdateRequest(&workflow))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `{"name":"Sync","nodes":[],"connections":{},"settings":{}}`
+	if string(body) != expected {
+		t.Errorf("Expected %s, got %s", expected, body)
+	}
+}
+
+func TestNodeTypePackage(t *testing.T) {
+	testCases := map[string]string{
+		"n8n-nodes-base.httpRequest":         "n8n-nodes-base",
+		"@n8n/n8n-nodes-langchain.agent":     "@n8n/n8n-nodes-langchain",
+		"n8n-nodes-example.exampleNode":      "n8n-nodes-example",
+		"@acme/n8n-nodes-crm.contact.create": "@acme/n8n-nodes-crm",
+		"unqualified":                        "unqualified",
+	}
+
+	for nodeType, expected := range testCases {
+		if got := NodeTypePackage(nodeType); got != expected {
+			t.Errorf("NodeTypePackage(%q) = %q, expected %q", nodeType, got, expected)
+		}
+	}
+}
+
+func TestWorkflowWebhookURLs(t *testing.T) {
+	workflow := &Workflow{
+		ID: "1",
+		Nodes: json.RawMessage(`[
+			{"name":"Orders","type":"n8n-nodes-base.webhook","webhookId":"b1c2","parameters":{"path":"/orders","httpMethod":"POST"}},
+			{"name":"Fallback","type":"n8n-nodes-base.webhook","webhookId":"d3e4","parameters":{}},
+			{"name":"User","type":"n8n-nodes-base.webhook","webhookId":"f5a6","parameters":{"path":"users/:id"}},
+			{"name":"Respond","type":"n8n-nodes-base.respondToWebhook","parameters":{}}
+		]`),
+	}
+
+	urls, err := workflow.WebhookURLs("https://n8n.example.com/")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []WebhookURL{
+		{NodeName: "Orders", Production: "https://n8n.example.com/webhook/orders", Test: "https://n8n.example.com/webhook-test/orders"},
+		{NodeName: "Fallback", Production: "https://n8n.example.com/webhook/d3e4", Test: "https://n8n.example.com/webhook-test/d3e4"},
+		{NodeName: "User", Production: "https://n8n.example.com/webhook/f5a6/users/:id", Test: "https://n8n.example.com/webhook-test/f5a6/users/:id"},
+	}
+	if len(urls) != len(expected) {
+		t.Fatalf("Expected %d URLs, got %v", len(expected), urls)
+	}
+	for i := range expected {
+		if urls[i] != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], urls[i])
+		}
+	}
+}
+
+func TestWorkflowWebhookEndpoints(t *testing.T) {
+	workflow := &Workflow{
+		ID: "1",
+		Nodes: json.RawMessage(`[
+			{"name":"Orders","type":"n8n-nodes-base.webhook","parameters":{"path":"/orders/","httpMethod":"post"}},
+			{"name":"Items","type":"n8n-nodes-base.webhook","parameters":{"path":"items","multipleMethods":true,"httpMethod":["GET","DELETE"]}},
+			{"name":"Fallback","type":"n8n-nodes-base.webhook","webhookId":"d3e4","parameters":{}},
+			{"name":"User","type":"n8n-nodes-base.webhook","webhookId":"f5a6","parameters":{"path":"users/:id"}},
+			{"name":"Respond","type":"n8n-nodes-base.respondToWebhook","parameters":{}}
+		]`),
+	}
+
+	endpoints, err := workflow.WebhookEndpoints()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []WebhookEndpoint{
+		{NodeName: "Orders", Method: "POST", Path: "orders"},
+		{NodeName: "Items", Method: "GET", Path: "items"},
+		{NodeName: "Items", Method: "DELETE", Path: "items"},
+		{NodeName: "Fallback", Method: "GET", Path: "d3e4"},
+		{NodeName: "User", Method: "GET", Path: "f5a6/users/:id"},
+	}
+	if len(endpoints) != len(expected) {
+		t.Fatalf("Expected %d endpoints, got %v", len(expected), endpoints)
+	}
+	for i := range expected {
+		if endpoints[i] != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], endpoints[i])
+		}
+	}
+}
+
+func TestWorkflowSubWorkflowReferences(t *testing.T) {
+	workflow := &Workflow{
+		ID: "1",
+		Nodes: json.RawMessage(`[
+			{"name":"Legacy","type":"n8n-nodes-base.executeWorkflow","parameters":{"workflowId":"7"}},
+			{"name":"Locator","type":"n8n-nodes-base.executeWorkflow","parameters":{"workflowId":{"__rl":true,"value":"8","mode":"list","cachedResultName":"Child"}}},
+			{"name":"Expression","type":"n8n-nodes-base.executeWorkflow","parameters":{"workflowId":{"__rl":true,"value":"={{ $json.id }}","mode":"id"}}},
+			{"name":"File","type":"n8n-nodes-base.executeWorkflow","parameters":{"source":"localFile","workflowPath":"/data/child.json"}},
+			{"name":"Fetch","type":"n8n-nodes-base.httpRequest","parameters":{"workflowId":"9"}}
+		]`),
+	}
+
+	references, err := workflow.SubWorkflowReferences()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []SubWorkflowReference{
+		{NodeName: "Legacy", WorkflowID: "7"},
+		{NodeName: "Locator", WorkflowID: "8", WorkflowName: "Child"},
+	}
+	if len(references) != len(expected) {
+		t.Fatalf("Expected %d references, got %v", len(expected), references)
+	}
+	for i := range expected {
+		if references[i] != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], references[i])
+		}
+	}
+}
+
+func TestNodeGraphEqual(t *testing.T) {
+	current := &Workflow{
+		ID:          "1",
+		Name:        "Sync",
+		Nodes:       json.RawMessage(`[{"name":"Start","type":"n8n-nodes-base.manualTrigger","position":[240,300]}]`),
+		Connections: json.RawMessage(`{}`),
+	}
+	renamed := &Workflow{
+		ID:          "1",
+		Name:        "Sync orders",
+		Settings:    json.RawMessage(`{"executionOrder":"v1"}`),
+		Nodes:       json.RawMessage(`[{"position":[240.0,300.0],"type":"n8n-nodes-base.manualTrigger","name":"Start","disabled":false}]`),
+		Connections: json.RawMessage(`{}`),
+	}
+	moved := &Workflow{
+		ID:          "1",
+		Name:        "Sync",
+		Nodes:       json.RawMessage(`[{"name":"Start","type":"n8n-nodes-base.manualTrigger","position":[480,300]}]`),
+		Connections: json.RawMessage(`{}`),
+	}
+
+	equal, err := NodeGraphEqual(current, renamed)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !equal {
+		t.Errorf("Expected metadata changes to leave the node graph equal")
+	}
+
+	equal, err = NodeGraphEqual(current, moved)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if equal {
+		t.Errorf("Expected node changes to be detected")
+	}
+}
+
+func TestWorkflowNodeCredentials(t *testing.T) {
+	workflow := &Workflow{
+		ID: "1",
+		Nodes: json.RawMessage(`[
+			{"name":"Fetch","type":"n8n-nodes-base.httpRequest","credentials":{"httpHeaderAuth":{"id":"2","name":"header"},"httpBasicAuth":{"id":"1","name":"basic"}}},
+			{"name":"Start","type":"n8n-nodes-base.manualTrigger"}
+		]`),
+	}
+
+	credentials, err := workflow.NodeCredentials()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []NodeCredential{
+		{NodeName: "Fetch", Type: "httpBasicAuth", Reference: NodeCredentialReference{ID: "1", Name: "basic"}},
+		{NodeName: "Fetch", Type: "httpHeaderAuth", Reference: NodeCredentialReference{ID: "2", Name: "header"}},
+	}
+	if len(credentials) != len(expected) {
+		t.Fatalf("Expected %d credentials, got %v", len(expected), credentials)
+	}
+	for i := range expected {
+		if credentials[i] != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], credentials[i])
+		}
+	}
+}
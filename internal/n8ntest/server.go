@@ -0,0 +1,811 @@
+// Package n8ntest provides a fake n8n API server for tests.
+//
+// The server keeps credentials, workflows, executions, tags and users in
+// memory and behaves like the n8n public API where the provider depends on it:
+// requests need the API key, lists are paginated with cursors, credential data
+// is never returned, workflows get a new versionId on every update and only
+// workflows with a trigger node can be activated. The internal REST API is
+// served where the provider uses it, after logging in as Email with Password.
+package n8ntest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+)
+
+// APIKey is the API key the server accepts.
+const APIKey = "n8ntest-api-key"
+
+// Email and Password log in to the internal REST API.
+const (
+	Email    = "owner@example.com"
+	Password = "n8ntest-password"
+)
+
+// sessionCookie and sessionValue are the internal API session cookie the server
+// issues and its value.
+const (
+	sessionCookie = "n8n-auth"
+	sessionValue  = "n8ntest-session"
+)
+
+// Version is the n8n version the server reports.
+const Version = "1.111.0"
+
+// builtinNodeTypes are the node types the server reports as installed before
+// any are added with InstallNodeTypes.
+var builtinNodeTypes = []string{
+	"n8n-nodes-base.emailSend",
+	"n8n-nodes-base.executeWorkflow",
+	"n8n-nodes-base.executeWorkflowTrigger",
+	"n8n-nodes-base.httpRequest",
+	"n8n-nodes-base.manualTrigger",
+	"n8n-nodes-base.scheduleTrigger",
+	"n8n-nodes-base.set",
+	"n8n-nodes-base.stickyNote",
+	"n8n-nodes-base.webhook",
+}
+
+// defaultPageSize is the page size used when a list request has no limit.
+const defaultPageSize = 100
+
+// Tag is a workflow tag.
+type Tag struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"createdAt,omitempty"`
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+// User is an n8n user.
+type User struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+	Role      string `json:"role,omitempty"`
+}
+
+// Server is a fake n8n API server.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	nextID      int
+	credentials map[string]*models.Credential
+	workflows   map[string]*models.Workflow
+	tags        map[string]*Tag
+	users       map[string]*User
+	nodeTypes   map[string]bool
+	executions  []models.Execution
+
+	activationErrors map[string]string
+}
+
+// NewServer starts a fake n8n API server that is closed when the test ends.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{
+		credentials: make(map[string]*models.Credential),
+		workflows:   make(map[string]*models.Workflow),
+		tags:        make(map[string]*Tag),
+		users:       make(map[string]*User),
+		nodeTypes:   make(map[string]bool),
+
+		activationErrors: make(map[string]string),
+	}
+	for _, name := range builtinNodeTypes {
+		s.nodeTypes[name] = true
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/settings", s.getSettings)
+	mux.HandleFunc("POST /rest/login", s.login)
+	mux.HandleFunc("GET /rest/active-workflows/error/{id}", s.session(s.getActivationError))
+	mux.HandleFunc("GET /types/nodes.json", s.session(s.listNodeTypes))
+	mux.HandleFunc("PATCH /rest/workflows/{id}", s.session(s.patchWorkflow))
+	mux.HandleFunc("POST /rest/workflows/{id}/archive", s.session(s.archiveWorkflow))
+	mux.HandleFunc("POST /rest/workflows/{id}/unarchive", s.session(s.unarchiveWorkflow))
+	mux.HandleFunc("GET /api/v1/credentials", s.authenticated(s.listCredentials))
+	mux.HandleFunc("POST /api/v1/credentials", s.authenticated(s.createCredential))
+	mux.HandleFunc("PATCH /api/v1/credentials/{id}", s.authenticated(s.updateCredential))
+	mux.HandleFunc("DELETE /api/v1/credentials/{id}", s.authenticated(s.deleteCredential))
+	mux.HandleFunc("PUT /api/v1/credentials/{id}/transfer", s.authenticated(s.transferCredential))
+	mux.HandleFunc("GET /api/v1/workflows", s.authenticated(s.listWorkflows))
+	mux.HandleFunc("POST /api/v1/workflows", s.authenticated(s.createWorkflow))
+	mux.HandleFunc("GET /api/v1/workflows/{id}", s.authenticated(s.getWorkflow))
+	mux.HandleFunc("PUT /api/v1/workflows/{id}", s.authenticated(s.updateWorkflow))
+	mux.HandleFunc("DELETE /api/v1/workflows/{id}", s.authenticated(s.deleteWorkflow))
+	mux.HandleFunc("PUT /api/v1/workflows/{id}/transfer", s.authenticated(s.transferWorkflow))
+	mux.HandleFunc("POST /api/v1/workflows/{id}/activate", s.authenticated(s.activateWorkflow))
+	mux.HandleFunc("POST /api/v1/workflows/{id}/deactivate", s.authenticated(s.deactivateWorkflow))
+	mux.HandleFunc("GET /api/v1/executions", s.authenticated(s.listExecutions))
+	mux.HandleFunc("GET /api/v1/tags", s.authenticated(s.listTags))
+	mux.HandleFunc("POST /api/v1/tags", s.authenticated(s.createTag))
+	mux.HandleFunc("GET /api/v1/users", s.authenticated(s.listUsers))
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Credential returns a copy of the stored credential, including its data,
+// or nil when it does not exist.
+func (s *Server) Credential(id string) *models.Credential {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	credential, ok := s.credentials[id]
+	if !ok {
+		return nil
+	}
+	stored := *credential
+	return &stored
+}
+
+// AddCredential stores a credential as if it was created outside of the
+// provider and returns its ID.
+func (s *Server) AddCredential(credential models.Credential) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	credential.ID = s.newID()
+	credential.CreatedAt = now()
+	credential.UpdatedAt = credential.CreatedAt
+	s.credentials[credential.ID] = &credential
+	return credential.ID
+}
+
+// Workflow returns a copy of the stored workflow, or nil when it does not
+// exist.
+func (s *Server) Workflow(id string) *models.Workflow {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workflow, ok := s.workflows[id]
+	if !ok {
+		return nil
+	}
+	stored := *workflow
+	return &stored
+}
+
+// AddWorkflow stores a workflow as if it was created outside of the provider
+// and returns its ID.
+func (s *Server) AddWorkflow(workflow models.Workflow) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workflow.ID = s.newID()
+	workflow.VersionID = s.newID()
+	workflow.CreatedAt = now()
+	workflow.UpdatedAt = workflow.CreatedAt
+	s.workflows[workflow.ID] = &workflow
+	return workflow.ID
+}
+
+// EditWorkflow changes a stored workflow as if it was edited in the n8n editor.
+func (s *Server) EditWorkflow(id string, edit func(*models.Workflow)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if workflow, ok := s.workflows[id]; ok {
+		edit(workflow)
+		workflow.VersionID = s.newID()
+		workflow.UpdatedAt = now()
+	}
+}
+
+// AddExecution stores a run of a workflow and returns its ID.
+func (s *Server) AddExecution(execution models.Execution) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	execution.ID = json.Number(s.newID())
+	s.executions = append(s.executions, execution)
+	return execution.ID.String()
+}
+
+// SetActivationError records an error for activating the workflow, as n8n
+// does when registering a trigger fails. The workflow can still be activated.
+func (s *Server) SetActivationError(id, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.activationErrors[id] = message
+}
+
+// InstallNodeTypes adds node types to the installed ones, as if a community
+// package providing them was installed.
+func (s *Server) InstallNodeTypes(names ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, name := range names {
+		s.nodeTypes[name] = true
+	}
+}
+
+// AddUser stores a user and returns its ID.
+func (s *Server) AddUser(user User) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user.ID = s.newID()
+	s.users[user.ID] = &user
+	return user.ID
+}
+
+// newID returns a new object ID. The caller must hold the lock.
+func (s *Server) newID() string {
+	s.nextID++
+	return strconv.Itoa(s.nextID)
+}
+
+// now returns the current time in the format n8n uses.
+func now() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+// authenticated rejects requests without the API key.
+func (s *Server) authenticated(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-N8N-API-KEY") != APIKey {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// session rejects internal API requests without a session.
+func (s *Server) session(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie(sessionCookie); err != nil || cookie.Value != sessionValue {
+			writeError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	var login struct {
+		EmailOrLdapLoginID string `json:"emailOrLdapLoginId"`
+		Password           string `json:"password"`
+	}
+	if !decode(w, r, &login) {
+		return
+	}
+	if login.EmailOrLdapLoginID != Email || login.Password != Password {
+		writeError(w, http.StatusUnauthorized, "Wrong username or password. Do you have caps lock on?")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{Name: sessionCookie, Value: sessionValue, Path: "/"})
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]string{"email": Email},
+	})
+}
+
+func (s *Server) getSettings(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{"versionCli": Version},
+	})
+}
+
+func (s *Server) listCredentials(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	credentials := make([]interface{}, 0, len(s.credentials))
+	for _, id := range sortedIDs(s.credentials) {
+		credentials = append(credentials, withoutData(s.credentials[id]))
+	}
+	writePage(w, r, credentials)
+}
+
+func (s *Server) createCredential(w http.ResponseWriter, r *http.Request) {
+	var credential models.Credential
+	if !decode(w, r, &credential) {
+		return
+	}
+	if credential.Name == "" {
+		writeError(w, http.StatusBadRequest, "request/body must have required property 'name'")
+		return
+	}
+	if credential.Type == "" {
+		writeError(w, http.StatusBadRequest, "request/body must have required property 'type'")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	credential.ID = s.newID()
+	credential.CreatedAt = now()
+	credential.UpdatedAt = credential.CreatedAt
+	s.credentials[credential.ID] = &credential
+
+	writeJSON(w, http.StatusOK, withoutData(&credential))
+}
+
+func (s *Server) updateCredential(w http.ResponseWriter, r *http.Request) {
+	var update models.Credential
+	if !decode(w, r, &update) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	credential, ok := s.credentials[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Credential not found")
+		return
+	}
+
+	if update.Name != "" {
+		credential.Name = update.Name
+	}
+	if update.Data != nil {
+		credential.Data = update.Data
+	}
+	credential.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, withoutData(credential))
+}
+
+func (s *Server) deleteCredential(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	credential, ok := s.credentials[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	delete(s.credentials, credential.ID)
+
+	writeJSON(w, http.StatusOK, withoutData(credential))
+}
+
+func (s *Server) transferCredential(w http.ResponseWriter, r *http.Request) {
+	var transfer models.CredentialTransferRequest
+	if !decode(w, r, &transfer) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	credential, ok := s.credentials[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	credential.HomeProject = &models.Project{ID: transfer.DestinationProjectID}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listWorkflows(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workflows := make([]interface{}, 0, len(s.workflows))
+	for _, id := range sortedIDs(s.workflows) {
+		workflows = append(workflows, s.workflows[id])
+	}
+	writePage(w, r, workflows)
+}
+
+func (s *Server) createWorkflow(w http.ResponseWriter, r *http.Request) {
+	var workflow models.Workflow
+	if !decode(w, r, &workflow) {
+		return
+	}
+	if workflow.Name == "" {
+		writeError(w, http.StatusBadRequest, "request/body must have required property 'name'")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workflow.ID = s.newID()
+	workflow.VersionID = s.newID()
+	workflow.Active = false
+	workflow.CreatedAt = now()
+	workflow.UpdatedAt = workflow.CreatedAt
+	s.workflows[workflow.ID] = &workflow
+
+	writeJSON(w, http.StatusOK, &workflow)
+}
+
+func (s *Server) getWorkflow(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workflow, ok := s.workflows[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, workflow)
+}
+
+func (s *Server) updateWorkflow(w http.ResponseWriter, r *http.Request) {
+	var update models.WorkflowUpdateRequest
+	if !decode(w, r, &update) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workflow, ok := s.workflows[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	if workflow.IsArchived {
+		writeError(w, http.StatusBadRequest, "Cannot update an archived workflow.")
+		return
+	}
+
+	workflow.Name = update.Name
+	workflow.Nodes = update.Nodes
+	workflow.Connections = update.Connections
+	workflow.Settings = update.Settings
+	workflow.StaticData = update.StaticData
+	workflow.VersionID = s.newID()
+	workflow.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, workflow)
+}
+
+func (s *Server) deleteWorkflow(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workflow, ok := s.workflows[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	delete(s.workflows, workflow.ID)
+
+	writeJSON(w, http.StatusOK, workflow)
+}
+
+func (s *Server) transferWorkflow(w http.ResponseWriter, r *http.Request) {
+	var transfer models.WorkflowTransferRequest
+	if !decode(w, r, &transfer) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workflow, ok := s.workflows[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	workflow.HomeProject = &models.Project{ID: transfer.DestinationProjectID}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) activateWorkflow(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workflow, ok := s.workflows[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	if workflow.IsArchived {
+		writeError(w, http.StatusBadRequest, "Cannot activate an archived workflow.")
+		return
+	}
+
+	nodes, err := workflow.ParseNodes()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !hasTrigger(nodes) {
+		writeError(w, http.StatusBadRequest, "Workflow has no node to start the workflow - at least one trigger, poller or webhook node is required")
+		return
+	}
+
+	workflow.Active = true
+	writeJSON(w, http.StatusOK, workflow)
+}
+
+func (s *Server) deactivateWorkflow(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workflow, ok := s.workflows[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	workflow.Active = false
+	writeJSON(w, http.StatusOK, workflow)
+}
+
+func (s *Server) getActivationError(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var data interface{}
+	if message, ok := s.activationErrors[r.PathValue("id")]; ok {
+		data = map[string]string{"error": message}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+}
+
+func (s *Server) listNodeTypes(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.nodeTypes))
+	for name := range s.nodeTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	nodeTypes := make([]map[string]string, len(names))
+	for i, name := range names {
+		nodeTypes[i] = map[string]string{"name": name}
+	}
+	writeJSON(w, http.StatusOK, nodeTypes)
+}
+
+// patchWorkflow changes the name and settings of a workflow. Like n8n, it
+// rejects the update when versionId is set and the workflow was edited since.
+func (s *Server) patchWorkflow(w http.ResponseWriter, r *http.Request) {
+	var update models.WorkflowMetadataUpdateRequest
+	if !decode(w, r, &update) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workflow, ok := s.workflows[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Could not find workflow")
+		return
+	}
+	if workflow.IsArchived {
+		writeError(w, http.StatusBadRequest, "Cannot update an archived workflow.")
+		return
+	}
+	if update.VersionID != "" && update.VersionID != workflow.VersionID {
+		writeError(w, http.StatusBadRequest, "Your most recent changes may be lost, because someone else just updated this workflow. "+
+			"Open this workflow in a new tab to see those new updates.")
+		return
+	}
+
+	if update.Name != "" {
+		workflow.Name = update.Name
+	}
+	if len(update.Settings) > 0 {
+		workflow.Settings = update.Settings
+	}
+	workflow.VersionID = s.newID()
+	workflow.UpdatedAt = now()
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": workflow})
+}
+
+// archiveWorkflow archives a workflow. Like n8n, archiving deactivates it.
+func (s *Server) archiveWorkflow(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workflow, ok := s.workflows[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Could not find workflow")
+		return
+	}
+	if workflow.IsArchived {
+		writeError(w, http.StatusBadRequest, "Workflow is already archived.")
+		return
+	}
+
+	workflow.IsArchived = true
+	workflow.Active = false
+	workflow.VersionID = s.newID()
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": workflow})
+}
+
+func (s *Server) unarchiveWorkflow(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workflow, ok := s.workflows[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Could not find workflow")
+		return
+	}
+	if !workflow.IsArchived {
+		writeError(w, http.StatusBadRequest, "Workflow is not archived.")
+		return
+	}
+
+	workflow.IsArchived = false
+	workflow.VersionID = s.newID()
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": workflow})
+}
+
+// listExecutions lists executions, most recent first, like n8n.
+func (s *Server) listExecutions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workflowID := r.URL.Query().Get("workflowId")
+	executions := make([]interface{}, 0, len(s.executions))
+	for i := len(s.executions) - 1; i >= 0; i-- {
+		if workflowID == "" || s.executions[i].WorkflowID == workflowID {
+			executions = append(executions, s.executions[i])
+		}
+	}
+	writePage(w, r, executions)
+}
+
+func (s *Server) listTags(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tags := make([]interface{}, 0, len(s.tags))
+	for _, id := range sortedIDs(s.tags) {
+		tags = append(tags, s.tags[id])
+	}
+	writePage(w, r, tags)
+}
+
+func (s *Server) createTag(w http.ResponseWriter, r *http.Request) {
+	var tag Tag
+	if !decode(w, r, &tag) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.tags {
+		if existing.Name == tag.Name {
+			writeError(w, http.StatusConflict, "Tag already exists")
+			return
+		}
+	}
+
+	tag.ID = s.newID()
+	tag.CreatedAt = now()
+	tag.UpdatedAt = tag.CreatedAt
+	s.tags[tag.ID] = &tag
+
+	writeJSON(w, http.StatusCreated, &tag)
+}
+
+func (s *Server) listUsers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	users := make([]interface{}, 0, len(s.users))
+	for _, id := range sortedIDs(s.users) {
+		users = append(users, s.users[id])
+	}
+	writePage(w, r, users)
+}
+
+// withoutData returns a copy of the credential without its data, which the
+// n8n API never returns.
+// hasTrigger reports whether one of the nodes can start the workflow.
+func hasTrigger(nodes []models.WorkflowNode) bool {
+	for _, node := range nodes {
+		if strings.HasSuffix(node.Type, "Trigger") || node.Type == "n8n-nodes-base.webhook" {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutData(credential *models.Credential) *models.Credential {
+	redacted := *credential
+	redacted.Data = nil
+	return &redacted
+}
+
+// sortedIDs returns the keys of the map in the order objects were created.
+func sortedIDs[T any](objects map[string]T) []string {
+	ids := make([]string, 0, len(objects))
+	for id := range objects {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, _ := strconv.Atoi(ids[i])
+		b, _ := strconv.Atoi(ids[j])
+		return a < b
+	})
+	return ids
+}
+
+// writePage writes the page of objects selected by the limit and cursor query
+// parameters. The cursor is the offset of the page.
+func writePage(w http.ResponseWriter, r *http.Request, objects []interface{}) {
+	limit := defaultPageSize
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 250 {
+			writeError(w, http.StatusBadRequest, "request/query/limit must be <= 250")
+			return
+		}
+		limit = parsed
+	}
+
+	offset := 0
+	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+		parsed, err := strconv.Atoi(cursor)
+		if err != nil || parsed < 0 || parsed > len(objects) {
+			writeError(w, http.StatusBadRequest, "invalid cursor")
+			return
+		}
+		offset = parsed
+	}
+
+	end := min(offset+limit, len(objects))
+
+	var nextCursor interface{}
+	if end < len(objects) {
+		nextCursor = strconv.Itoa(end)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data":       objects[offset:end],
+		"nextCursor": nextCursor,
+	})
+}
+
+// decode decodes the request body, writing a 400 response on failure.
+func decode(w http.ResponseWriter, r *http.Request, out interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(out); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return false
+	}
+	return true
+}
+
+// writeError writes an error response in the format of the n8n API.
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]string{"message": message})
+}
+
+// writeJSON writes a JSON response.
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,180 @@
+package n8ntest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+)
+
+func newClient(t *testing.T, server *Server, apiKey string) *client.Client {
+	t.Helper()
+
+	host, insecure := server.URL, false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return n8nClient
+}
+
+func TestServerCredentials(t *testing.T) {
+	server := NewServer(t)
+	n8nClient := newClient(t, server, APIKey)
+	ctx := context.Background()
+
+	created, err := n8nClient.CreateCredential(ctx, &models.Credential{
+		Name: "api",
+		Type: "httpBasicAuth",
+		Data: map[string]interface{}{"user": "admin", "password": "secret"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if created.Data != nil {
+		t.Errorf("Expected credential data not to be returned, got %v", created.Data)
+	}
+	if stored := server.Credential(created.ID); stored == nil || stored.Data["password"] != "secret" {
+		t.Errorf("Expected credential data to be stored, got %+v", stored)
+	}
+
+	for i := 0; i < 150; i++ {
+		server.AddCredential(models.Credential{Name: fmt.Sprintf("other-%d", i), Type: "httpHeaderAuth"})
+	}
+
+	credentials, err := n8nClient.ListCredentials(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(credentials) != 151 {
+		t.Errorf("Expected 151 credentials across pages, got %d", len(credentials))
+	}
+
+	if err := n8nClient.DeleteCredential(ctx, created.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var notFound *client.NotFoundError
+	if err := n8nClient.DeleteCredential(ctx, created.ID); !errors.As(err, &notFound) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+	if _, err := n8nClient.GetCredential(ctx, created.ID); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestServerRequiresAPIKey(t *testing.T) {
+	server := NewServer(t)
+	n8nClient := newClient(t, server, "wrong-api-key")
+
+	var unauthorized *client.UnauthorizedError
+	if err := n8nClient.Ping(context.Background()); !errors.As(err, &unauthorized) {
+		t.Errorf("Expected unauthorized error, got %v", err)
+	}
+}
+
+func TestServerWorkflows(t *testing.T) {
+	server := NewServer(t)
+	n8nClient := newClient(t, server, APIKey)
+	ctx := context.Background()
+
+	id := server.AddWorkflow(models.Workflow{Name: "example", Nodes: json.RawMessage(`[]`), Connections: json.RawMessage(`{}`)})
+
+	workflow, err := n8nClient.GetWorkflow(ctx, id)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	server.EditWorkflow(id, func(w *models.Workflow) { w.Name = "edited in the editor" })
+
+	if _, err := n8nClient.UpdateWorkflow(ctx, id, workflow, workflow.VersionID); !errors.Is(err, client.ErrWorkflowModified) {
+		t.Errorf("Expected ErrWorkflowModified, got %v", err)
+	}
+
+	current, err := n8nClient.GetWorkflow(ctx, id)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	updated, err := n8nClient.UpdateWorkflow(ctx, id, workflow, current.VersionID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if updated.Name != "example" || updated.VersionID == current.VersionID {
+		t.Errorf("Expected a new version of the workflow, got %+v", updated)
+	}
+
+	if _, err := n8nClient.ActivateWorkflow(ctx, id); err == nil {
+		t.Errorf("Expected a workflow without trigger not to be activated")
+	}
+
+	server.EditWorkflow(id, func(w *models.Workflow) {
+		w.Nodes = json.RawMessage(`[{"name":"Schedule","type":"n8n-nodes-base.scheduleTrigger"}]`)
+	})
+	activated, err := n8nClient.ActivateWorkflow(ctx, id)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !activated.Active || !server.Workflow(id).Active {
+		t.Errorf("Expected the workflow to be active, got %+v", activated)
+	}
+
+	if _, err := n8nClient.DeactivateWorkflow(ctx, id); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if server.Workflow(id).Active {
+		t.Errorf("Expected the workflow to be inactive")
+	}
+}
+
+func TestServerReportsVersion(t *testing.T) {
+	server := NewServer(t)
+	n8nClient := newClient(t, server, APIKey)
+
+	version, err := n8nClient.DetectVersion(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if version.String() != Version {
+		t.Errorf("Expected version %s, got %s", Version, version)
+	}
+}
+
+func TestServerInternalAPI(t *testing.T) {
+	server := NewServer(t)
+	ctx := context.Background()
+
+	host, apiKey, insecure := server.URL, APIKey, false
+	wrongPassword, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0), client.WithInternalAPI(Email, "wrong"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	id := server.AddWorkflow(models.Workflow{Name: "example", Nodes: json.RawMessage(`[]`)})
+	if _, err := wrongPassword.ArchiveWorkflow(ctx, id); err == nil {
+		t.Errorf("Expected the login to fail with a wrong password")
+	}
+
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0), client.WithInternalAPI(Email, Password))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := n8nClient.ArchiveWorkflow(ctx, id); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !server.Workflow(id).IsArchived {
+		t.Errorf("Expected the workflow to be archived")
+	}
+	if _, err := n8nClient.UpdateWorkflow(ctx, id, &models.Workflow{Name: "renamed", Nodes: json.RawMessage(`[]`)}, ""); err == nil {
+		t.Errorf("Expected an archived workflow not to be updated")
+	}
+
+	if _, err := n8nClient.UnarchiveWorkflow(ctx, id); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if server.Workflow(id).IsArchived {
+		t.Errorf("Expected the workflow to be unarchived")
+	}
+}
@@ -2,27 +2,37 @@ package provider
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &credentialResource{}
-	_ resource.ResourceWithConfigure   = &credentialResource{}
-	_ resource.ResourceWithImportState = &credentialResource{}
-	_ resource.ResourceWithModifyPlan  = &credentialResource{}
+	_ resource.Resource                     = &credentialResource{}
+	_ resource.ResourceWithConfigure        = &credentialResource{}
+	_ resource.ResourceWithImportState      = &credentialResource{}
+	_ resource.ResourceWithConfigValidators = &credentialResource{}
+	_ resource.ResourceWithModifyPlan       = &credentialResource{}
 )
 
 // NewCredentialResource is a helper function to simplify the provider implementation.
@@ -35,38 +45,56 @@ type credentialResource struct {
 	client *client.Client
 }
 
+// Values of the name_conflict attribute.
+const (
+	nameConflictWarn  = "warn"
+	nameConflictError = "error"
+)
+
 // credentialResourceModel maps the resource schema data.
 type credentialResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	BasicAuth   types.Object `tfsdk:"basic_auth"`
-	OAuth2      types.Object `tfsdk:"oauth2"`
-	HeaderAuth  types.Object `tfsdk:"header_auth"`
-	NodesAccess types.List   `tfsdk:"nodes_access"`
-}
-
-// basicAuthModel represents the httpBasicAuth credential block.
-type basicAuthModel struct {
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
-}
-
-// oAuth2Model represents the oAuth2Api credential block.
-type oAuth2Model struct {
-	ClientId                     types.String `tfsdk:"client_id"`
-	ClientSecret                 types.String `tfsdk:"client_secret"`
-	AccessTokenUrl               types.String `tfsdk:"access_token_url"`
-	AuthUrl                      types.String `tfsdk:"auth_url"`
-	Scope                        types.String `tfsdk:"scope"`
-	AuthQueryParameters          types.String `tfsdk:"auth_query_parameters"`
-	SendAdditionalBodyProperties types.Bool   `tfsdk:"send_additional_body_properties"`
-	AdditionalBodyProperties     types.String `tfsdk:"additional_body_properties"`
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	BasicAuth          types.Object `tfsdk:"basic_auth"`
+	OAuth2             types.Object `tfsdk:"oauth2"`
+	HeaderAuth         types.Object `tfsdk:"header_auth"`
+	MicrosoftOAuth2    types.Object `tfsdk:"microsoft_oauth2"`
+	AzureOpenAI        types.Object `tfsdk:"azure_openai"`
+	MQTT               types.Object `tfsdk:"mqtt"`
+	Kafka              types.Object `tfsdk:"kafka"`
+	Salesforce         types.Object `tfsdk:"salesforce"`
+	TLSCertificate     types.Object `tfsdk:"tls_certificate"`
+	NodesAccess        types.Set    `tfsdk:"nodes_access"`
+	ProjectID          types.String `tfsdk:"project_id"`
+	SharedWith         types.Set    `tfsdk:"shared_with"`
+	RotationTriggers   types.Map    `tfsdk:"rotation_triggers"`
+	RotateAfter        types.String `tfsdk:"rotate_after"`
+	ExpiresAt          types.String `tfsdk:"expires_at"`
+	NameConflict       types.String `tfsdk:"name_conflict"`
+	ForceDestroy       types.Bool   `tfsdk:"force_destroy"`
+	SecretsFingerprint types.Map    `tfsdk:"secrets_fingerprint"`
+	SkipRefresh        types.Bool   `tfsdk:"skip_refresh"`
+	CreatedAt          types.String `tfsdk:"created_at"`
+	UpdatedAt          types.String `tfsdk:"updated_at"`
+	HomeProjectName    types.String `tfsdk:"home_project_name"`
+	HomeProjectType    types.String `tfsdk:"home_project_type"`
+	OwnerEmail         types.String `tfsdk:"owner_email"`
+	Timeouts           types.Object `tfsdk:"timeouts"`
 }
 
-// headerAuthModel represents the httpHeaderAuth credential block.
-type headerAuthModel struct {
-	Name  types.String `tfsdk:"name"`
-	Value types.String `tfsdk:"value"`
+// blockValues returns the credential blocks of the model keyed by block name.
+func (m *credentialResourceModel) blockValues() map[string]types.Object {
+	return map[string]types.Object{
+		"basic_auth":       m.BasicAuth,
+		"oauth2":           m.OAuth2,
+		"header_auth":      m.HeaderAuth,
+		"microsoft_oauth2": m.MicrosoftOAuth2,
+		"azure_openai":     m.AzureOpenAI,
+		"mqtt":             m.MQTT,
+		"kafka":            m.Kafka,
+		"salesforce":       m.Salesforce,
+		"tls_certificate":  m.TLSCertificate,
+	}
 }
 
 // Metadata returns the resource type name.
@@ -76,8 +104,18 @@ func (r *credentialResource) Metadata(_ context.Context, req resource.MetadataRe
 
 // Schema defines the schema for the resource.
 func (r *credentialResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	blocks := map[string]schema.Block{
+		"timeouts": timeoutsBlock(),
+	}
+	for i := range credentialBlocks {
+		blocks[credentialBlocks[i].name] = credentialBlocks[i].schemaBlock()
+	}
+
 	resp.Schema = schema.Schema{
-		Description: "Manages a credential in n8n. Credentials are used to authenticate with external services. Exactly one credential type block must be specified.",
+		Description: "Manages a credential in n8n. Credentials are used to authenticate with external services. Exactly one credential type block must be specified. " +
+			"On n8n 1.111 and later, changing the name, node access or credential data updates the credential in place. " +
+			"On older versions, or when the version cannot be detected, it replaces the credential; set `create_before_destroy` " +
+			"in the resource's lifecycle block to create the replacement before the old credential is deleted.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The unique identifier of the credential.",
@@ -89,144 +127,113 @@ func (r *credentialResource) Schema(_ context.Context, _ resource.SchemaRequest,
 			"name": schema.StringAttribute{
 				Description: "The name of the credential.",
 				Required:    true,
+			},
+			"nodes_access": schema.SetAttribute{
+				Description: "Set of node types that can access this credential. Each item should be a string representing the node type.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"project_id": schema.StringAttribute{
+				Description: "The ID of the project the credential belongs to. Defaults to the personal project of the API key owner. " +
+					"Changing this transfers the credential to the new project.",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"nodes_access": schema.ListAttribute{
-				Description: "List of node types that can access this credential. Each item should be a string representing the node type.",
+			"shared_with": schema.SetAttribute{
+				Description: "IDs of the projects the credential is shared with. Share with a user through their personal project. " +
+					"Shares not listed are removed. Leave unset to not manage sharing. Requires enable_internal_api in the provider configuration. " +
+					"Plans fail when the license of the instance does not include sharing.",
 				ElementType: types.StringType,
 				Optional:    true,
-				PlanModifiers: []planmodifier.List{
-					&requiresReplaceListModifier{},
-				},
 			},
-		},
-		Blocks: map[string]schema.Block{
-			"basic_auth": schema.SingleNestedBlock{
-				Description: "HTTP Basic Authentication credentials.",
-				Attributes: map[string]schema.Attribute{
-					"username": schema.StringAttribute{
-						Description: "The username for basic authentication.",
-						Optional:    true, // Made optional - validated in ModifyPlan
-						Sensitive:   false,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-					},
-					"password": schema.StringAttribute{
-						Description: "The password for basic authentication.",
-						Optional:    true, // Made optional - validated in ModifyPlan
-						Sensitive:   true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-					},
-				},
-				PlanModifiers: []planmodifier.Object{
-					&requiresReplaceObjectModifier{},
+			"rotation_triggers": schema.MapAttribute{
+				Description: "Arbitrary map of values that, when changed, recreates the credential and re-sends its secrets. " +
+					"Use it to drive scheduled rotation, e.g. from a time_rotating resource.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
 				},
 			},
-			"oauth2": schema.SingleNestedBlock{
-				Description: "OAuth2 API credentials.",
-				Attributes: map[string]schema.Attribute{
-					"client_id": schema.StringAttribute{
-						Description: "The OAuth2 client ID.",
-						Optional:    true, // Made optional - validated in ModifyPlan
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-					},
-					"client_secret": schema.StringAttribute{
-						Description: "The OAuth2 client secret.",
-						Optional:    true, // Made optional - validated in ModifyPlan
-						Sensitive:   true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-					},
-					"access_token_url": schema.StringAttribute{
-						Description: "The URL to obtain the access token.",
-						Optional:    true, // Made optional - validated in ModifyPlan
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-					},
-					"auth_url": schema.StringAttribute{
-						Description: "The OAuth2 authorization URL.",
-						Optional:    true, // Made optional - validated in ModifyPlan
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-					},
-					"scope": schema.StringAttribute{
-						Description: "The OAuth2 scope.",
-						Optional:    true, // Made optional - validated in ModifyPlan
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-					},
-					"auth_query_parameters": schema.StringAttribute{
-						Description: "Additional query parameters for the authorization request.",
-						Optional:    true,
-						Computed:    true,
-						Default:     stringdefault.StaticString(""),
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-					},
-					"send_additional_body_properties": schema.BoolAttribute{
-						Description: "Whether to send additional body properties.",
-						Optional:    true,
-						Computed:    true,
-						Default:     booldefault.StaticBool(false),
-						PlanModifiers: []planmodifier.Bool{
-							&requiresReplaceBoolModifier{},
-						},
-					},
-					"additional_body_properties": schema.StringAttribute{
-						Description: "Additional body properties to send.",
-						Optional:    true,
-						Computed:    true,
-						Default:     stringdefault.StaticString(""),
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-					},
-				},
-				PlanModifiers: []planmodifier.Object{
-					&requiresReplaceObjectModifier{},
+			"rotate_after": schema.StringAttribute{
+				Description: "Duration after which the credential expires and is recreated on the next apply, e.g. \"2160h\" for 90 days. " +
+					"A changed value takes effect at the next rotation.",
+				Optional: true,
+				Validators: []validator.String{
+					durationValidator{},
 				},
 			},
-			"header_auth": schema.SingleNestedBlock{
-				Description: "HTTP Header Authentication credentials.",
-				Attributes: map[string]schema.Attribute{
-					"name": schema.StringAttribute{
-						Description: "The header name (e.g., 'Authorization').",
-						Optional:    true, // Made optional - validated in ModifyPlan
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-					},
-					"value": schema.StringAttribute{
-						Description: "The header value (e.g., 'Bearer token').",
-						Optional:    true, // Made optional - validated in ModifyPlan
-						Sensitive:   true,
-						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
-						},
-					},
+			"expires_at": schema.StringAttribute{
+				Description: "The RFC 3339 timestamp after which the credential is recreated. Null when rotate_after is not set.",
+				Computed:    true,
+			},
+			"name_conflict": schema.StringAttribute{
+				Description: "Checks for an existing credential with the same name and type before creating one. " +
+					"\"warn\" reports the conflict, \"error\" aborts the create so the existing credential can be imported instead. " +
+					"Not checked when unset. With create_before_destroy, the credential being replaced also counts as a conflict.",
+				Optional: true,
+				Validators: []validator.String{
+					stringOneOfValidator{values: []string{nameConflictWarn, nameConflictError}},
 				},
-				PlanModifiers: []planmodifier.Object{
-					&requiresReplaceObjectModifier{},
+			},
+			"secrets_fingerprint": schema.MapAttribute{
+				Description: "A short fingerprint of each configured sensitive field, keyed by \"<block>.<field>\", " +
+					"e.g. \"basic_auth.password\". Plans show which secret changes without revealing its value. The fingerprints " +
+					"are keyed with a random salt kept in the private state of the resource, so they cannot be used to confirm a " +
+					"guessed secret. Known after apply for credentials being created or imported, or created by an older provider version.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "The RFC 3339 timestamp at which n8n created the credential, e.g. to find credentials due for rotation. " +
+					"Null when n8n does not report it.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"updated_at": schema.StringAttribute{
+				Description: "The RFC 3339 timestamp at which the credential was last changed. Null when n8n does not report it.",
+				Computed:    true,
+			},
+			"home_project_name": schema.StringAttribute{
+				Description: "The name of the project owning the credential. Requires enable_internal_api in the provider configuration; null otherwise.",
+				Computed:    true,
+			},
+			"home_project_type": schema.StringAttribute{
+				Description: "The type of the project owning the credential: \"personal\" for the personal project of a user, or \"team\". " +
+					"Requires enable_internal_api in the provider configuration; null otherwise.",
+				Computed: true,
+			},
+			"owner_email": schema.StringAttribute{
+				Description: "The email of the user owning the credential, e.g. to assert that it belongs to a service account. " +
+					"Null when the credential belongs to a team project, or without enable_internal_api in the provider configuration.",
+				Computed: true,
+			},
+			"skip_refresh": schema.BoolAttribute{
+				Description: "Whether to skip reading the credential from n8n on refresh and keep the known state. " +
+					"Secrets cannot be read back anyway, so this only stops detecting renames, deletions and node access changes made outside of Terraform, " +
+					"in exchange for plans that don't list all credentials on instances where single credentials cannot be read. Defaults to false.",
+				Optional: true,
+			},
+			"force_destroy": forceDestroyAttribute(
+				"workflows use the credential",
+				"Their nodes are left without a credential and fail until they are pointed at another one.",
+			),
 		},
+		Blocks: blocks,
 	}
+}
 
-	// Set ExactlyOneOf validation using custom validation
-	// Note: Terraform Plugin Framework doesn't have built-in ExactlyOneOf for blocks,
-	// so we'll validate this in the Create/Update methods
+// ConfigValidators returns validators that run against the configuration during
+// terraform validate, without requiring provider connectivity.
+func (r *credentialResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		exactlyOneBlockValidator{noun: "credential", blocks: credentialBlockNames},
+	}
 }
 
 // Configure adds the provider configured client to the resource.
@@ -253,6 +260,9 @@ func (r *credentialResource) Configure(_ context.Context, req resource.Configure
 //
 //nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
 func (r *credentialResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, warnRetries := withRetryWarning(ctx)
+	defer warnRetries(&resp.Diagnostics)
+
 	var plan credentialResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -261,7 +271,7 @@ func (r *credentialResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	// Validate that exactly one credential block is defined and extract type/data
-	credentialType, data, err := validateCredentialBlocks(ctx, plan)
+	credentialType, data, err := validateCredentialBlocks(plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Invalid Credential Configuration",
@@ -270,41 +280,50 @@ func (r *credentialResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, timeoutCreate)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(setSecretsFingerprint(ctx, &plan, resp.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Info(ctx, "Creating credential", map[string]interface{}{
 		"name": plan.Name.ValueString(),
 		"type": credentialType,
 	})
 
-	// Convert nodes_access to []client.NodeAccess
-	var nodesAccess []client.NodeAccess
-	if !plan.NodesAccess.IsNull() && !plan.NodesAccess.IsUnknown() {
-		var nodeTypes []types.String
-		diags := plan.NodesAccess.ElementsAs(ctx, &nodeTypes, false)
+	if !plan.NameConflict.IsNull() {
+		diags = checkNameConflict(ctx, r.client, plan.Name.ValueString(), credentialType, plan.NameConflict.ValueString())
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
+	}
 
-		for _, nodeType := range nodeTypes {
-			nodesAccess = append(nodesAccess, client.NodeAccess{
-				NodeType: nodeType.ValueString(),
-			})
-		}
+	nodesAccess, diags := models.NodesAccessFromSet(ctx, plan.NodesAccess)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Create the credential
-	credential := &client.Credential{
+	credential := &models.Credential{
 		Name:        plan.Name.ValueString(),
 		Type:        credentialType,
 		Data:        data,
 		NodesAccess: nodesAccess,
 	}
 
-	createdCredential, err := r.client.CreateCredential(credential)
+	createdCredential, err := r.client.CreateCredential(ctx, credential)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating credential",
-			fmt.Sprintf("Could not create credential, unexpected error: %s", err.Error()),
+			fmt.Sprintf("Could not create credential, unexpected error: %s", errorDetail(err)),
 		)
 		return
 	}
@@ -312,19 +331,33 @@ func (r *credentialResource) Create(ctx context.Context, req resource.CreateRequ
 	// Map response body to resource schema attributes
 	plan.ID = types.StringValue(createdCredential.ID)
 	plan.Name = types.StringValue(createdCredential.Name)
+	plan.CreatedAt = timestampValue(createdCredential.CreatedAt)
+	plan.UpdatedAt = timestampValue(createdCredential.UpdatedAt)
+
+	// Move the credential into the requested project.
+	plan.ProjectID, diags = transferCreatedCredential(ctx, r.client, createdCredential, plan.ProjectID)
+	resp.Diagnostics.Append(diags...)
+	plan.setHomeProject(createdCredential.HomeProject)
+
+	plan.ExpiresAt, diags = expiresAtValue(plan.RotateAfter, time.Now())
+	resp.Diagnostics.Append(diags...)
+
+	if !plan.SharedWith.IsNull() && !plan.SharedWith.IsUnknown() {
+		diags = shareCredential(ctx, r.client, createdCredential.ID, plan.SharedWith)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			plan.SharedWith = types.SetNull(types.StringType)
+		}
+	}
 
 	// Set nodes_access if it was provided
 	if len(createdCredential.NodesAccess) > 0 {
-		nodeTypeValues := make([]types.String, len(createdCredential.NodesAccess))
-		for i, na := range createdCredential.NodesAccess {
-			nodeTypeValues[i] = types.StringValue(na.NodeType)
-		}
-		nodesAccessList, diags := types.ListValueFrom(ctx, types.StringType, nodeTypeValues)
+		nodesAccessSet, diags := models.NodesAccessToSet(ctx, createdCredential.NodesAccess)
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
-		plan.NodesAccess = nodesAccessList
+		plan.NodesAccess = nodesAccessSet
 	}
 	// Note: If nodesAccess was not provided in the response and was null in plan,
 	// it will remain null, which is correct behavior
@@ -348,9 +381,13 @@ func (r *credentialResource) Create(ctx context.Context, req resource.CreateRequ
 // Read refreshes the Terraform state with the latest data.
 // Note: n8n API may not support reading credentials for security reasons.
 // If reading fails, we keep the existing state to avoid breaking Terraform operations.
+// If the credential is known to be deleted, it is removed from state.
 //
 //nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
 func (r *credentialResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, warnRetries := withRetryWarning(ctx)
+	defer warnRetries(&resp.Diagnostics)
+
 	var state credentialResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -358,14 +395,37 @@ func (r *credentialResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	if state.SkipRefresh.ValueBool() {
+		tflog.Debug(ctx, "Skipping refresh of credential", map[string]interface{}{
+			"id": state.ID.ValueString(),
+		})
+		return
+	}
+
 	tflog.Info(ctx, "Reading credential", map[string]interface{}{
 		"id": state.ID.ValueString(),
 	})
 
-	credential, err := r.client.GetCredential(state.ID.ValueString())
+	credential, err := r.client.GetCredential(ctx, state.ID.ValueString())
+	if errors.Is(err, client.ErrNotFound) {
+		// The credential was deleted outside of Terraform, so remove it from
+		// state and let Terraform plan its recreation.
+		tflog.Warn(ctx, "Credential not found, removing from state", map[string]interface{}{
+			"id": state.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil && r.client.StrictReads() {
+		resp.Diagnostics.AddError(
+			"Error reading credential",
+			fmt.Sprintf("Could not read credential ID %s: %s", state.ID.ValueString(), errorDetail(err)),
+		)
+		return
+	}
 	if err != nil {
 		// n8n API may not support reading credentials (security feature).
-		// Instead of failing, we log a warning and keep the existing state.
+		// Unless strict_reads is set, we log a warning and keep the existing state.
 		// This allows Terraform to continue working even if the API doesn't
 		// support credential retrieval.
 		tflog.Warn(ctx, "Could not read credential from API, keeping existing state", map[string]interface{}{
@@ -384,23 +444,43 @@ func (r *credentialResource) Read(ctx context.Context, req resource.ReadRequest,
 	// Update state with refreshed values (if we successfully read the credential)
 	state.ID = types.StringValue(credential.ID)
 	state.Name = types.StringValue(credential.Name)
+	// Keep the known timestamps when the response doesn't include them.
+	if credential.CreatedAt != "" {
+		state.CreatedAt = types.StringValue(credential.CreatedAt)
+	}
+	if credential.UpdatedAt != "" {
+		state.UpdatedAt = types.StringValue(credential.UpdatedAt)
+	}
 	// Note: We don't update the credential blocks from the API response because
 	// n8n doesn't return sensitive credential data. We keep the existing blocks.
 
-	// Update nodes_access if present
-	if len(credential.NodesAccess) > 0 {
-		nodeTypeValues := make([]types.String, len(credential.NodesAccess))
-		for i, na := range credential.NodesAccess {
-			nodeTypeValues[i] = types.StringValue(na.NodeType)
+	// Update nodes_access; an empty response clears it
+	nodesAccessSet, diags := models.NodesAccessToSet(ctx, credential.NodesAccess)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.NodesAccess = nodesAccessSet
+
+	// Only the internal API reports the owning project. Keep the known value
+	// when the response doesn't include it.
+	if credential.HomeProject != nil {
+		state.ProjectID = types.StringValue(credential.HomeProject.ID)
+	}
+	state.setHomeProject(credential.HomeProject)
+
+	// Shares are only refreshed when managed, and only the internal API reports them.
+	if !state.SharedWith.IsNull() && credential.SharedWithProjects != nil {
+		projectIDs := make([]string, len(credential.SharedWithProjects))
+		for i, project := range credential.SharedWithProjects {
+			projectIDs[i] = project.ID
 		}
-		nodesAccessList, diags := types.ListValueFrom(ctx, types.StringType, nodeTypeValues)
+		sharedWith, diags := types.SetValueFrom(ctx, types.StringType, projectIDs)
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
-		state.NodesAccess = nodesAccessList
-	} else {
-		state.NodesAccess = types.ListNull(types.StringType)
+		state.SharedWith = sharedWith
 	}
 
 	// Note: The data field is not updated from the API response because
@@ -420,10 +500,15 @@ func (r *credentialResource) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
-// Note: Updates are handled via replacement (delete and recreate) due to n8n API limitations.
+// Note: Instances that cannot update credentials in place plan a replacement
+// instead, see ModifyPlan; UpdateCredential still recreates them when the
+// version could not be detected at plan time.
 //
 //nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
 func (r *credentialResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, warnRetries := withRetryWarning(ctx)
+	defer warnRetries(&resp.Diagnostics)
+
 	var plan credentialResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -431,8 +516,81 @@ func (r *credentialResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	var state credentialResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, timeoutUpdate)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(setSecretsFingerprint(ctx, &plan, resp.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Changes limited to the project or provider-side settings such as timeouts
+	// don't require recreating the credential.
+	if credentialSettingsEqual(plan, state) {
+		plan.ID = state.ID
+		plan.CreatedAt = state.CreatedAt
+		plan.UpdatedAt = state.UpdatedAt
+
+		if plan.ExpiresAt.IsUnknown() {
+			plan.ExpiresAt, diags = expiresAtValue(plan.RotateAfter, time.Now())
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		if plan.ProjectID.IsUnknown() {
+			plan.ProjectID = state.ProjectID
+		}
+		if !plan.ProjectID.IsNull() && !plan.ProjectID.Equal(state.ProjectID) {
+			tflog.Info(ctx, "Transferring credential", map[string]interface{}{
+				"id":         plan.ID.ValueString(),
+				"project_id": plan.ProjectID.ValueString(),
+			})
+
+			err := r.client.TransferCredential(ctx, plan.ID.ValueString(), plan.ProjectID.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error transferring credential",
+					fmt.Sprintf("Could not transfer credential ID %s to project %s: %s", plan.ID.ValueString(), plan.ProjectID.ValueString(), errorDetail(err)),
+				)
+				return
+			}
+		}
+
+		if !plan.SharedWith.IsNull() && !plan.SharedWith.Equal(state.SharedWith) {
+			tflog.Info(ctx, "Updating credential shares", map[string]interface{}{
+				"id": plan.ID.ValueString(),
+			})
+
+			diags = shareCredential(ctx, r.client, plan.ID.ValueString(), plan.SharedWith)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		// The owning project is refreshed on the next read after a transfer.
+		plan.setHomeProject(nil)
+
+		diags = resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
 	// Validate that exactly one credential block is defined and extract type/data
-	credentialType, data, err := validateCredentialBlocks(ctx, plan)
+	credentialType, data, err := validateCredentialBlocks(plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Invalid Credential Configuration",
@@ -441,47 +599,53 @@ func (r *credentialResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	tflog.Info(ctx, "Updating credential via delete-and-recreate", map[string]interface{}{
+	tflog.Info(ctx, "Updating credential", map[string]interface{}{
 		"old_id": plan.ID.ValueString(),
 		"name":   plan.Name.ValueString(),
 		"type":   credentialType,
 	})
 
-	// Convert nodes_access to []client.NodeAccess
-	var nodesAccess []client.NodeAccess
-	if !plan.NodesAccess.IsNull() && !plan.NodesAccess.IsUnknown() {
-		var nodeTypes []types.String
-		diags := plan.NodesAccess.ElementsAs(ctx, &nodeTypes, false)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		for _, nodeType := range nodeTypes {
-			nodesAccess = append(nodesAccess, client.NodeAccess{
-				NodeType: nodeType.ValueString(),
-			})
-		}
+	nodesAccess, diags := models.NodesAccessFromSet(ctx, plan.NodesAccess)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Update the credential
-	credential := &client.Credential{
+	credential := &models.Credential{
 		Name:        plan.Name.ValueString(),
 		Type:        credentialType,
 		Data:        data,
 		NodesAccess: nodesAccess,
 	}
 
-	// Update credential by deleting and recreating (n8n API doesn't support PUT/PATCH)
-	// Note: This will result in a new credential ID
-	updatedCredential, err := r.client.UpdateCredential(plan.ID.ValueString(), credential)
-	if err != nil {
+	if !r.client.UpdatesCredentialsInPlace() {
+		// Recreating deletes the old credential, so workflows using it
+		// block the update like they block a destroy.
+		resp.Diagnostics.Append(credentialDestroyDiagnostics(ctx, r.client, plan.ID.ValueString(), plan.ForceDestroy)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Update credential in place where the instance supports it, otherwise by
+	// creating a new one and deleting the old one.
+	// Note: Recreating results in a new credential ID
+	updatedCredential, err := r.client.UpdateCredential(ctx, plan.ID.ValueString(), credential)
+	if updatedCredential == nil {
 		resp.Diagnostics.AddError(
 			"Error updating credential",
-			fmt.Sprintf("Could not update credential ID %s: %s", plan.ID.ValueString(), err.Error()),
+			fmt.Sprintf("Could not update credential ID %s: %s", plan.ID.ValueString(), errorDetail(err)),
 		)
 		return
 	}
+	if err != nil {
+		// The replacement exists, so track it and leave the old credential for manual cleanup.
+		resp.Diagnostics.AddWarning(
+			"Old credential not deleted",
+			fmt.Sprintf("Credential ID %s was replaced by %s but could not be deleted: %s", plan.ID.ValueString(), updatedCredential.ID, errorDetail(err)),
+		)
+	}
 
 	// Log that the ID has changed
 	if updatedCredential.ID != plan.ID.ValueString() {
@@ -494,19 +658,36 @@ func (r *credentialResource) Update(ctx context.Context, req resource.UpdateRequ
 	// Map response body to resource schema attributes
 	plan.ID = types.StringValue(updatedCredential.ID)
 	plan.Name = types.StringValue(updatedCredential.Name)
+	plan.CreatedAt = timestampValue(updatedCredential.CreatedAt)
+	plan.UpdatedAt = timestampValue(updatedCredential.UpdatedAt)
+
+	// A recreated credential lands in the personal project, so move it back.
+	// A credential updated in place only moves when project_id changed.
+	if updatedCredential.ID != state.ID.ValueString() || !plan.ProjectID.Equal(state.ProjectID) {
+		plan.ProjectID, diags = transferCreatedCredential(ctx, r.client, updatedCredential, plan.ProjectID)
+		resp.Diagnostics.Append(diags...)
+	}
+	plan.setHomeProject(updatedCredential.HomeProject)
+
+	plan.ExpiresAt, diags = expiresAtValue(plan.RotateAfter, time.Now())
+	resp.Diagnostics.Append(diags...)
+
+	if !plan.SharedWith.IsNull() && !plan.SharedWith.IsUnknown() {
+		diags = shareCredential(ctx, r.client, updatedCredential.ID, plan.SharedWith)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			plan.SharedWith = types.SetNull(types.StringType)
+		}
+	}
 
 	// Update nodes_access if it was provided
 	if len(updatedCredential.NodesAccess) > 0 {
-		nodeTypeValues := make([]types.String, len(updatedCredential.NodesAccess))
-		for i, na := range updatedCredential.NodesAccess {
-			nodeTypeValues[i] = types.StringValue(na.NodeType)
-		}
-		nodesAccessList, diags := types.ListValueFrom(ctx, types.StringType, nodeTypeValues)
+		nodesAccessSet, diags := models.NodesAccessToSet(ctx, updatedCredential.NodesAccess)
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
-		plan.NodesAccess = nodesAccessList
+		plan.NodesAccess = nodesAccessSet
 	}
 	// Note: If nodesAccess was not provided in the response and was null in plan,
 	// it will remain null, which is correct behavior
@@ -527,6 +708,9 @@ func (r *credentialResource) Update(ctx context.Context, req resource.UpdateRequ
 //
 //nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
 func (r *credentialResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, warnRetries := withRetryWarning(ctx)
+	defer warnRetries(&resp.Diagnostics)
+
 	var state credentialResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -534,15 +718,35 @@ func (r *credentialResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, timeoutDelete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Info(ctx, "Deleting credential", map[string]interface{}{
 		"id": state.ID.ValueString(),
 	})
 
-	err := r.client.DeleteCredential(state.ID.ValueString())
+	resp.Diagnostics.Append(credentialDestroyDiagnostics(ctx, r.client, state.ID.ValueString(), state.ForceDestroy)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteCredential(ctx, state.ID.ValueString())
+	var notFound *client.NotFoundError
+	if errors.As(err, &notFound) {
+		// The credential was already deleted outside of Terraform.
+		tflog.Warn(ctx, "Credential not found, removing from state", map[string]interface{}{
+			"id": state.ID.ValueString(),
+		})
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting credential",
-			fmt.Sprintf("Could not delete credential ID %s: %s", state.ID.ValueString(), err.Error()),
+			fmt.Sprintf("Could not delete credential ID %s: %s", state.ID.ValueString(), errorDetail(err)),
 		)
 		return
 	}
@@ -552,232 +756,147 @@ func (r *credentialResource) Delete(ctx context.Context, req resource.DeleteRequ
 	})
 }
 
-// ImportState imports the resource.
-func (r *credentialResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-}
-
-// ModifyPlan validates that exactly one credential block is provided.
-// This runs during plan time to validate the configuration before Terraform
-// validates nested block attributes.
+// ModifyPlan fingerprints the configured secrets, plans the recreation of
+// credentials whose rotate_after period has expired, and of changed
+// credentials on instances that cannot update them in place.
 //
 //nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
 func (r *credentialResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
-	// Skip validation during destroy or if plan is null
+	ctx, warnRetries := withRetryWarning(ctx)
+	defer warnRetries(&resp.Diagnostics)
+
+	// Nothing to do on destroy
 	if req.Plan.Raw.IsNull() {
 		return
 	}
 
-	// If we have a state (update scenario), we might want to skip validation
-	// if the plan is being destroyed
-	if req.State.Raw.IsNull() && req.Plan.Raw.IsNull() {
+	var plan credentialResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var plan credentialResourceModel
-	diags := req.Plan.Get(ctx, &plan)
+	// Fingerprints are computed on apply until the resource has a salt.
+	salt, diags := secretsFingerprintSalt(ctx, req.Private)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan.SecretsFingerprint = secretsFingerprintValue(&plan, salt)
 
-	// Count how many blocks are defined (not null and not unknown)
-	blocksDefined := 0
-	blockNames := []string{}
-
-	if !plan.BasicAuth.IsNull() && !plan.BasicAuth.IsUnknown() {
-		blocksDefined++
-		blockNames = append(blockNames, "basic_auth")
-	}
-	if !plan.OAuth2.IsNull() && !plan.OAuth2.IsUnknown() {
-		blocksDefined++
-		blockNames = append(blockNames, "oauth2")
+	creating := req.State.Raw.IsNull()
+	var state credentialResourceModel
+	if !creating {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
-	if !plan.HeaderAuth.IsNull() && !plan.HeaderAuth.IsUnknown() {
-		blocksDefined++
-		blockNames = append(blockNames, "header_auth")
+
+	// Shares need the sharing feature. The license is checked when they
+	// change; the client is not configured yet when validating offline.
+	if r.client != nil && len(plan.SharedWith.Elements()) > 0 && (creating || !plan.SharedWith.Equal(state.SharedWith)) {
+		resp.Diagnostics.Append(enterpriseFeatureDiagnostics(ctx, r.client, client.FeatureSharing, path.Root("shared_with"))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	// If all blocks are unknown, skip validation (might be during refresh)
-	if plan.BasicAuth.IsUnknown() && plan.OAuth2.IsUnknown() && plan.HeaderAuth.IsUnknown() {
+	if creating {
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
 		return
 	}
 
-	// Validate exactly one block is provided
-	if blocksDefined == 0 {
-		resp.Diagnostics.AddError(
-			"Missing Credential Block",
-			"Exactly one credential block must be specified: basic_auth, oauth2, or header_auth",
-		)
-		return
+	// Older instances cannot update credentials in place, so changing what
+	// n8n stores replaces them.
+	if r.client == nil || !r.client.UpdatesCredentialsInPlace() {
+		resp.RequiresReplace = append(resp.RequiresReplace, credentialChangedPaths(&plan, &state)...)
 	}
-	if blocksDefined > 1 {
-		resp.Diagnostics.AddError(
-			"Multiple Credential Blocks",
-			fmt.Sprintf("Exactly one credential block must be specified, but %d were found (%s). Please specify only one of: basic_auth, oauth2, or header_auth", blocksDefined, fmt.Sprintf("%v", blockNames)),
-		)
-		return
+
+	// The owning project only changes with project_id. A replacement is
+	// transferred back into the same project.
+	if plan.ProjectID.Equal(state.ProjectID) {
+		plan.HomeProjectName = state.HomeProjectName
+		plan.HomeProjectType = state.HomeProjectType
+		plan.OwnerEmail = state.OwnerEmail
 	}
 
-	// Now validate that the selected block has all required attributes
-	if !plan.BasicAuth.IsNull() && !plan.BasicAuth.IsUnknown() {
-		var basicAuth basicAuthModel
-		diags := plan.BasicAuth.As(ctx, &basicAuth, basetypes.ObjectAsOptions{})
-		if !diags.HasError() {
-			if basicAuth.Username.IsNull() || basicAuth.Username.IsUnknown() {
-				resp.Diagnostics.AddAttributeError(
-					path.Root("basic_auth").AtName("username"),
-					"Missing Required Attribute",
-					"The username attribute is required when using the basic_auth block.",
-				)
-			}
-			if basicAuth.Password.IsNull() || basicAuth.Password.IsUnknown() {
-				resp.Diagnostics.AddAttributeError(
-					path.Root("basic_auth").AtName("password"),
-					"Missing Required Attribute",
-					"The password attribute is required when using the basic_auth block.",
-				)
-			}
+	switch {
+	case plan.RotateAfter.IsNull():
+		plan.ExpiresAt = types.StringNull()
+	case plan.RotateAfter.IsUnknown() || state.ExpiresAt.IsNull():
+		// Computed on apply
+		plan.ExpiresAt = types.StringUnknown()
+	default:
+		expiresAt, err := time.Parse(time.RFC3339, state.ExpiresAt.ValueString())
+		if err != nil || !time.Now().Before(expiresAt) {
+			tflog.Info(ctx, "Credential expired, planning recreation", map[string]interface{}{
+				"id":         state.ID.ValueString(),
+				"expires_at": state.ExpiresAt.ValueString(),
+			})
+			plan.ExpiresAt = types.StringUnknown()
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("expires_at"))
+		} else {
+			plan.ExpiresAt = state.ExpiresAt
 		}
 	}
 
-	if !plan.OAuth2.IsNull() && !plan.OAuth2.IsUnknown() {
-		var oauth2 oAuth2Model
-		diags := plan.OAuth2.As(ctx, &oauth2, basetypes.ObjectAsOptions{})
-		if !diags.HasError() {
-			if oauth2.ClientId.IsNull() || oauth2.ClientId.IsUnknown() {
-				resp.Diagnostics.AddAttributeError(
-					path.Root("oauth2").AtName("client_id"),
-					"Missing Required Attribute",
-					"The client_id attribute is required when using the oauth2 block.",
-				)
-			}
-			if oauth2.ClientSecret.IsNull() || oauth2.ClientSecret.IsUnknown() {
-				resp.Diagnostics.AddAttributeError(
-					path.Root("oauth2").AtName("client_secret"),
-					"Missing Required Attribute",
-					"The client_secret attribute is required when using the oauth2 block.",
-				)
-			}
-			if oauth2.AccessTokenUrl.IsNull() || oauth2.AccessTokenUrl.IsUnknown() {
-				resp.Diagnostics.AddAttributeError(
-					path.Root("oauth2").AtName("access_token_url"),
-					"Missing Required Attribute",
-					"The access_token_url attribute is required when using the oauth2 block.",
-				)
-			}
-			if oauth2.AuthUrl.IsNull() || oauth2.AuthUrl.IsUnknown() {
-				resp.Diagnostics.AddAttributeError(
-					path.Root("oauth2").AtName("auth_url"),
-					"Missing Required Attribute",
-					"The auth_url attribute is required when using the oauth2 block.",
-				)
-			}
-			if oauth2.Scope.IsNull() || oauth2.Scope.IsUnknown() {
-				resp.Diagnostics.AddAttributeError(
-					path.Root("oauth2").AtName("scope"),
-					"Missing Required Attribute",
-					"The scope attribute is required when using the oauth2 block.",
-				)
-			}
-		}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// credentialImportIDFormats are the accepted import IDs of credentials. The
+// project is only needed when the instance does not report it, i.e. without
+// the internal API.
+var credentialImportIDFormats = [][]string{
+	{"credential_id"},
+	{"project_id", "credential_id"},
+}
+
+// ImportState imports the resource by credential ID, or by project and
+// credential ID to also set project_id.
+func (r *credentialResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseImportID(req.ID, credentialImportIDFormats...)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
 	}
 
-	if !plan.HeaderAuth.IsNull() && !plan.HeaderAuth.IsUnknown() {
-		var headerAuth headerAuthModel
-		diags := plan.HeaderAuth.As(ctx, &headerAuth, basetypes.ObjectAsOptions{})
-		if !diags.HasError() {
-			if headerAuth.Name.IsNull() || headerAuth.Name.IsUnknown() {
-				resp.Diagnostics.AddAttributeError(
-					path.Root("header_auth").AtName("name"),
-					"Missing Required Attribute",
-					"The name attribute is required when using the header_auth block.",
-				)
-			}
-			if headerAuth.Value.IsNull() || headerAuth.Value.IsUnknown() {
-				resp.Diagnostics.AddAttributeError(
-					path.Root("header_auth").AtName("value"),
-					"Missing Required Attribute",
-					"The value attribute is required when using the header_auth block.",
-				)
-			}
-		}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts["credential_id"])...)
+	if projectID, ok := parts["project_id"]; ok {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
 	}
 }
 
-// validateCredentialBlocks ensures exactly one credential block is defined.
+// validateCredentialBlocks ensures exactly one credential block is defined and
+// returns its n8n credential type and data.
 //
 //nolint:gocritic // model parameter passed by value for clarity and immutability
-func validateCredentialBlocks(ctx context.Context, model credentialResourceModel) (string, map[string]interface{}, error) {
+func validateCredentialBlocks(model credentialResourceModel) (string, map[string]interface{}, error) {
 	blocksDefined := 0
 	var credentialType string
 	var data map[string]interface{}
 
-	if !model.BasicAuth.IsNull() && !model.BasicAuth.IsUnknown() {
-		blocksDefined++
-		//nolint:gosec // G101: This is a credential type identifier, not actual credentials
-		credentialType = "httpBasicAuth"
-		var basicAuth basicAuthModel
-		diags := model.BasicAuth.As(ctx, &basicAuth, basetypes.ObjectAsOptions{})
-		if diags.HasError() {
-			return "", nil, fmt.Errorf("failed to parse basic_auth block: %v", diags)
-		}
-		data = map[string]interface{}{
-			"user":     basicAuth.Username.ValueString(),
-			"password": basicAuth.Password.ValueString(),
-		}
-	}
+	values := model.blockValues()
+	for i := range credentialBlocks {
+		block := &credentialBlocks[i]
 
-	if !model.OAuth2.IsNull() && !model.OAuth2.IsUnknown() {
-		blocksDefined++
-		//nolint:gosec // G101: This is a credential type identifier, not actual credentials
-		credentialType = "oAuth2Api"
-		var oauth2 oAuth2Model
-		diags := model.OAuth2.As(ctx, &oauth2, basetypes.ObjectAsOptions{})
-		if diags.HasError() {
-			return "", nil, fmt.Errorf("failed to parse oauth2 block: %v", diags)
-		}
-		data = map[string]interface{}{
-			"clientId":       oauth2.ClientId.ValueString(),
-			"clientSecret":   oauth2.ClientSecret.ValueString(),
-			"accessTokenUrl": oauth2.AccessTokenUrl.ValueString(),
-			"authUrl":        oauth2.AuthUrl.ValueString(),
-			"scope":          oauth2.Scope.ValueString(),
-		}
-		if !oauth2.AuthQueryParameters.IsNull() {
-			data["authQueryParameters"] = oauth2.AuthQueryParameters.ValueString()
-		} else {
-			data["authQueryParameters"] = ""
-		}
-		if !oauth2.SendAdditionalBodyProperties.IsNull() {
-			data["sendAdditionalBodyProperties"] = oauth2.SendAdditionalBodyProperties.ValueBool()
-		} else {
-			data["sendAdditionalBodyProperties"] = false
-		}
-		if !oauth2.AdditionalBodyProperties.IsNull() {
-			data["additionalBodyProperties"] = oauth2.AdditionalBodyProperties.ValueString()
-		} else {
-			data["additionalBodyProperties"] = ""
+		value := values[block.name]
+		if value.IsNull() || value.IsUnknown() {
+			continue
 		}
-	}
 
-	if !model.HeaderAuth.IsNull() && !model.HeaderAuth.IsUnknown() {
 		blocksDefined++
-		//nolint:gosec // G101: This is a credential type identifier, not actual credentials
-		credentialType = "httpHeaderAuth"
-		var headerAuth headerAuthModel
-		diags := model.HeaderAuth.As(ctx, &headerAuth, basetypes.ObjectAsOptions{})
-		if diags.HasError() {
-			return "", nil, fmt.Errorf("failed to parse header_auth block: %v", diags)
-		}
-		data = map[string]interface{}{
-			"name":  headerAuth.Name.ValueString(),
-			"value": headerAuth.Value.ValueString(),
+
+		blockType, blockData, err := block.credential(value)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to parse %s block: %w", block.name, err)
 		}
+		credentialType = blockType
+		data = blockData
 	}
 
 	if blocksDefined == 0 {
-		return "", nil, fmt.Errorf("exactly one credential block must be specified (basic_auth, oauth2, or header_auth)")
+		return "", nil, fmt.Errorf("exactly one credential block must be specified (%s)", formatBlockList(credentialBlockNames))
 	}
 	if blocksDefined > 1 {
 		return "", nil, fmt.Errorf("exactly one credential block must be specified, but %d were found", blocksDefined)
@@ -786,90 +905,309 @@ func validateCredentialBlocks(ctx context.Context, model credentialResourceModel
 	return credentialType, data, nil
 }
 
-// requiresReplaceListModifier is a plan modifier that marks the resource for replacement
-// when the list attribute changes.
-type requiresReplaceListModifier struct{}
+// expiresAtValue returns the expiry timestamp for a credential created at now,
+// or null when rotate_after is not set.
+func expiresAtValue(rotateAfter types.String, now time.Time) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
 
-// Description returns a human-readable description of the plan modifier.
-func (m *requiresReplaceListModifier) Description(ctx context.Context) string {
-	return "Requires replacement when nodes_access changes"
-}
+	if rotateAfter.IsNull() || rotateAfter.IsUnknown() {
+		return types.StringNull(), diags
+	}
+
+	duration, err := time.ParseDuration(rotateAfter.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("rotate_after"),
+			"Invalid Duration",
+			fmt.Sprintf("The value %q is not a valid duration: %s", rotateAfter.ValueString(), err.Error()),
+		)
+		return types.StringNull(), diags
+	}
 
-// MarkdownDescription returns a markdown formatted human-readable description of the plan modifier.
-func (m *requiresReplaceListModifier) MarkdownDescription(ctx context.Context) string {
-	return "Requires replacement when nodes_access changes"
+	return types.StringValue(now.Add(duration).UTC().Format(time.RFC3339)), diags
 }
 
-// PlanModifyList implements the plan modification logic.
-//
-//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
-func (m *requiresReplaceListModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
-	// If the attribute is being removed or changed, require replacement
-	if !req.StateValue.IsNull() && !req.PlanValue.IsNull() {
-		// Check if values are different
-		if !req.StateValue.Equal(req.PlanValue) {
-			resp.RequiresReplace = true
+// secretsFingerprintValue returns the fingerprints of the sensitive fields of
+// the configured credential block, or unknown when a block or the salt is not
+// known yet.
+func secretsFingerprintValue(model *credentialResourceModel, salt []byte) types.Map {
+	if salt == nil {
+		return types.MapUnknown(types.StringType)
+	}
+
+	fingerprints := make(map[string]attr.Value)
+
+	values := model.blockValues()
+	for i := range credentialBlocks {
+		block := &credentialBlocks[i]
+
+		value := values[block.name]
+		if value.IsUnknown() {
+			return types.MapUnknown(types.StringType)
+		}
+		if value.IsNull() {
+			continue
 		}
-	} else if req.StateValue.IsNull() != req.PlanValue.IsNull() {
-		// One is null and the other isn't - require replacement
-		resp.RequiresReplace = true
+		block.secretFingerprints(value, salt, fingerprints)
 	}
+
+	return types.MapValueMust(types.StringType, fingerprints)
 }
 
-// requiresReplaceObjectModifier is a plan modifier that marks the resource for replacement
-// when the object attribute changes.
-type requiresReplaceObjectModifier struct{}
+// secretsFingerprintSaltKey is the private state key of the salt of the
+// secret fingerprints.
+const secretsFingerprintSaltKey = "secrets_fingerprint_salt"
 
-// Description returns a human-readable description of the plan modifier.
-func (m *requiresReplaceObjectModifier) Description(ctx context.Context) string {
-	return "Requires replacement when credential block changes"
+// privateState is the private state of a resource, as passed to its methods.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
 }
 
-// MarkdownDescription returns a markdown formatted human-readable description of the plan modifier.
-func (m *requiresReplaceObjectModifier) MarkdownDescription(ctx context.Context) string {
-	return "Requires replacement when credential block changes"
+// secretsFingerprintSalt returns the salt of the secret fingerprints kept in
+// private state, or nil when the resource has none yet.
+func secretsFingerprintSalt(ctx context.Context, private privateState) ([]byte, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, secretsFingerprintSaltKey)
+	if diags.HasError() || value == nil {
+		return nil, diags
+	}
+
+	var encoded string
+	if err := json.Unmarshal(value, &encoded); err != nil {
+		diags.AddError("Invalid Private State", fmt.Sprintf("Could not read the salt of the secret fingerprints: %s", err.Error()))
+		return nil, diags
+	}
+	salt, err := hex.DecodeString(encoded)
+	if err != nil {
+		diags.AddError("Invalid Private State", fmt.Sprintf("Could not read the salt of the secret fingerprints: %s", err.Error()))
+		return nil, diags
+	}
+	return salt, diags
 }
 
-// PlanModifyObject implements the plan modification logic.
-//
-//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
-func (m *requiresReplaceObjectModifier) PlanModifyObject(ctx context.Context, req planmodifier.ObjectRequest, resp *planmodifier.ObjectResponse) {
-	// If the attribute is being removed or changed, require replacement
-	if !req.StateValue.IsNull() && !req.PlanValue.IsNull() {
-		// Check if values are different
-		if !req.StateValue.Equal(req.PlanValue) {
-			resp.RequiresReplace = true
+// setSecretsFingerprint sets the secret fingerprints of a credential being
+// created or updated. Resources without a salt, i.e. new, imported or created
+// by older provider versions, get a random one, which is kept in private
+// state. The salt is not created when planning: the plan is repeated on
+// apply, and a second salt would change the planned fingerprints.
+func setSecretsFingerprint(ctx context.Context, model *credentialResourceModel, private privateState) diag.Diagnostics {
+	salt, diags := secretsFingerprintSalt(ctx, private)
+	if diags.HasError() {
+		return diags
+	}
+
+	if salt == nil {
+		salt = make([]byte, 32)
+		if _, err := rand.Read(salt); err != nil {
+			diags.AddError("Error fingerprinting secrets", fmt.Sprintf("Could not generate a salt: %s", err.Error()))
+			return diags
+		}
+		encoded, err := json.Marshal(hex.EncodeToString(salt))
+		if err != nil {
+			diags.AddError("Error fingerprinting secrets", fmt.Sprintf("Could not encode the salt: %s", err.Error()))
+			return diags
+		}
+		diags.Append(private.SetKey(ctx, secretsFingerprintSaltKey, encoded)...)
+		if diags.HasError() {
+			return diags
 		}
-	} else if req.StateValue.IsNull() != req.PlanValue.IsNull() {
-		// One is null and the other isn't - require replacement
-		resp.RequiresReplace = true
 	}
+
+	model.SecretsFingerprint = secretsFingerprintValue(model, salt)
+	return diags
+}
+
+// timestampValue returns a timestamp reported by n8n, or null when the API
+// response doesn't include it.
+func timestampValue(timestamp string) types.String {
+	if timestamp == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(timestamp)
+}
+
+// personalProjectOwnerPattern matches the email in the name n8n gives the
+// personal project of a user, e.g. "Jane Doe <jane@example.com>".
+var personalProjectOwnerPattern = regexp.MustCompile(`<([^<>\s]+@[^<>\s]+)>\s*$`)
+
+// setHomeProject sets the attributes describing the project owning the
+// credential from the project n8n reports. Only the internal API reports it;
+// when it is not reported, or no longer matches project_id after a transfer,
+// known values are kept and unknown values become null.
+func (m *credentialResourceModel) setHomeProject(project *models.Project) {
+	reported := project != nil &&
+		(m.ProjectID.IsNull() || m.ProjectID.IsUnknown() || project.ID == m.ProjectID.ValueString())
+	if !reported {
+		for _, value := range []*types.String{&m.HomeProjectName, &m.HomeProjectType, &m.OwnerEmail} {
+			if value.IsUnknown() {
+				*value = types.StringNull()
+			}
+		}
+		return
+	}
+
+	m.HomeProjectName = optionalStringValue(project.Name)
+	m.HomeProjectType = optionalStringValue(project.Type)
+	m.OwnerEmail = types.StringNull()
+	if project.Type == "personal" {
+		if match := personalProjectOwnerPattern.FindStringSubmatch(project.Name); match != nil {
+			m.OwnerEmail = types.StringValue(match[1])
+		}
+	}
+}
+
+// optionalStringValue returns the string, or null when it is empty.
+func optionalStringValue(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// projectIDValue returns the owning project of the credential, or null when the
+// API response doesn't include it.
+func projectIDValue(credential *models.Credential) types.String {
+	if credential.HomeProject == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(credential.HomeProject.ID)
+}
+
+// transferCreatedCredential moves a credential that was just created or
+// updated into the requested project and returns the project_id to save. When
+// the transfer fails, the credential is saved all the same, so the failure is
+// a warning and the project n8n reports is saved instead: the next plan then
+// shows the transfer as an in-place update rather than replacing the
+// credential.
+func transferCreatedCredential(ctx context.Context, n8nClient *client.Client, credential *models.Credential, projectID types.String) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if projectID.IsNull() || projectID.IsUnknown() {
+		return projectIDValue(credential), diags
+	}
+
+	if err := n8nClient.TransferCredential(ctx, credential.ID, projectID.ValueString()); err != nil {
+		diags.AddWarning(
+			"Credential not transferred",
+			fmt.Sprintf("Credential ID %s was saved but could not be transferred to project %s: %s. "+
+				"The next apply transfers it again.", credential.ID, projectID.ValueString(), errorDetail(err)),
+		)
+		return projectIDValue(credential), diags
+	}
+
+	return projectID, diags
 }
 
-// requiresReplaceBoolModifier is a plan modifier that marks the resource for replacement
-// when the bool attribute changes.
-type requiresReplaceBoolModifier struct{}
+// shareCredential shares the credential with exactly the projects in the set.
+func shareCredential(ctx context.Context, n8nClient *client.Client, id string, sharedWith types.Set) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var projectIDs []string
+	diags.Append(sharedWith.ElementsAs(ctx, &projectIDs, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := n8nClient.ShareCredential(ctx, id, projectIDs); err != nil {
+		diags.AddError(
+			"Error sharing credential",
+			fmt.Sprintf("Could not share credential ID %s: %s", id, errorDetail(err)),
+		)
+	}
 
-// Description returns a human-readable description of the plan modifier.
-func (m *requiresReplaceBoolModifier) Description(ctx context.Context) string {
-	return "Requires replacement when attribute changes"
+	return diags
 }
 
-// MarkdownDescription returns a markdown formatted human-readable description of the plan modifier.
-func (m *requiresReplaceBoolModifier) MarkdownDescription(ctx context.Context) string {
-	return "Requires replacement when attribute changes"
+// checkNameConflict reports existing credentials with the same name and type.
+// The mode decides whether a conflict is a warning or an error.
+func checkNameConflict(ctx context.Context, n8nClient *client.Client, name, credentialType, mode string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	credentials, err := n8nClient.ListCredentials(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error checking credential name",
+			fmt.Sprintf("Could not list credentials to check for name conflicts: %s", errorDetail(err)),
+		)
+		return diags
+	}
+
+	for i := range credentials {
+		existing := &credentials[i]
+		if existing.Name != name || existing.Type != credentialType {
+			continue
+		}
+
+		summary := "Credential name conflict"
+		detail := fmt.Sprintf("A %s credential named %q already exists with ID %s. "+
+			"Duplicate names are hard to tell apart in the n8n UI; rename this credential or import the existing one with:\n\n"+
+			"  terraform import <resource address> %s", credentialType, name, existing.ID, existing.ID)
+		if mode == nameConflictError {
+			diags.AddError(summary, detail)
+		} else {
+			diags.AddWarning(summary, detail)
+		}
+	}
+
+	return diags
+}
+
+// credentialDestroyDiagnostics reports the workflows that still use a
+// credential which is about to be deleted, as selected by force_destroy.
+// Failing to list workflows is not fatal; the check is best effort.
+func credentialDestroyDiagnostics(ctx context.Context, n8nClient *client.Client, id string, forceDestroy types.Bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if forceDestroy.ValueBool() {
+		return diags
+	}
+
+	references, err := n8nClient.ListCredentialReferences(ctx, id)
+	if err != nil {
+		tflog.Warn(ctx, "Could not check workflows for credential references", map[string]interface{}{
+			"id":    id,
+			"error": err.Error(),
+		})
+		return diags
+	}
+
+	dependents := make([]destroyDependent, 0, len(references))
+	for _, reference := range references {
+		dependents = append(dependents, destroyDependent{
+			Description: fmt.Sprintf("Workflow %q (ID %s)", reference.WorkflowName, reference.WorkflowID),
+			Detail:      "nodes " + strings.Join(reference.Nodes, ", "),
+		})
+	}
+
+	return forceDestroyDiagnostics(forceDestroy, "credential ID "+id, "Update the workflows to use the replacement credential.", dependents)
 }
 
-// PlanModifyBool implements the plan modification logic.
+// credentialSettingsEqual reports whether two models describe the same credential
+// in n8n, ignoring the project, shares and provider-side settings such as timeouts.
 //
-//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
-func (m *requiresReplaceBoolModifier) PlanModifyBool(ctx context.Context, req planmodifier.BoolRequest, resp *planmodifier.BoolResponse) {
-	// If the attribute is being changed, require replacement
-	if !req.StateValue.IsNull() && !req.PlanValue.IsNull() {
-		if req.StateValue.ValueBool() != req.PlanValue.ValueBool() {
-			resp.RequiresReplace = true
+//nolint:gocritic // models passed by value for clarity and immutability
+func credentialSettingsEqual(a, b credentialResourceModel) bool {
+	return len(credentialChangedPaths(&a, &b)) == 0
+}
+
+// credentialChangedPaths returns the attributes describing the credential in
+// n8n that differ between the plan and the state: the name, node access and
+// credential blocks. Reordering node access is not a change.
+func credentialChangedPaths(plan, state *credentialResourceModel) path.Paths {
+	var paths path.Paths
+	if !plan.Name.Equal(state.Name) {
+		paths = append(paths, path.Root("name"))
+	}
+	if !plan.NodesAccess.Equal(state.NodesAccess) {
+		paths = append(paths, path.Root("nodes_access"))
+	}
+
+	planBlocks, stateBlocks := plan.blockValues(), state.blockValues()
+	for i := range credentialBlocks {
+		name := credentialBlocks[i].name
+		if !planBlocks[name].Equal(stateBlocks[name]) {
+			paths = append(paths, path.Root(name))
 		}
-	} else if req.StateValue.IsNull() != req.PlanValue.IsNull() {
-		resp.RequiresReplace = true
 	}
+
+	return paths
 }
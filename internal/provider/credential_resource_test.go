@@ -2,10 +2,23 @@ package provider
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/artus-engineering/terraform-provider-n8n/internal/n8ntest"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestCredentialResourceSchema(t *testing.T) {
@@ -25,6 +38,18 @@ func TestCredentialResourceSchema(t *testing.T) {
 	validateSchemaAttributeExists(t, schemaResponse.Schema, "id")
 	validateSchemaAttributeExists(t, schemaResponse.Schema, "name")
 	validateSchemaAttributeExists(t, schemaResponse.Schema, "nodes_access")
+	validateSchemaAttributeExists(t, schemaResponse.Schema, "project_id")
+	validateSchemaAttributeExists(t, schemaResponse.Schema, "shared_with")
+	validateSchemaAttributeExists(t, schemaResponse.Schema, "rotation_triggers")
+	validateSchemaAttributeExists(t, schemaResponse.Schema, "name_conflict")
+	validateSchemaAttributeExists(t, schemaResponse.Schema, "force_destroy")
+	validateSchemaAttributeExists(t, schemaResponse.Schema, "secrets_fingerprint")
+	validateSchemaAttributeExists(t, schemaResponse.Schema, "skip_refresh")
+	validateSchemaAttributeExists(t, schemaResponse.Schema, "created_at")
+	validateSchemaAttributeExists(t, schemaResponse.Schema, "updated_at")
+	validateSchemaAttributeExists(t, schemaResponse.Schema, "home_project_name")
+	validateSchemaAttributeExists(t, schemaResponse.Schema, "home_project_type")
+	validateSchemaAttributeExists(t, schemaResponse.Schema, "owner_email")
 
 	// Validate blocks exist
 	if _, ok := schemaResponse.Schema.Blocks["basic_auth"]; !ok {
@@ -36,6 +61,9 @@ func TestCredentialResourceSchema(t *testing.T) {
 	if _, ok := schemaResponse.Schema.Blocks["header_auth"]; !ok {
 		t.Errorf("missing block: header_auth")
 	}
+	if _, ok := schemaResponse.Schema.Blocks["timeouts"]; !ok {
+		t.Errorf("missing block: timeouts")
+	}
 }
 
 func validateSchemaAttributeExists(t *testing.T, s schema.Schema, attributeName string) {
@@ -67,3 +95,582 @@ func TestCredentialResourceMetadata(t *testing.T) {
 		t.Errorf("Expected TypeName to be 'n8n_credential', got '%s'", metadataResponse.TypeName)
 	}
 }
+
+func TestCredentialChangedPaths(t *testing.T) {
+	t.Parallel()
+
+	ab := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("a"), types.StringValue("b")})
+	ba := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("b"), types.StringValue("a")})
+	ac := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("a"), types.StringValue("c")})
+
+	tests := []struct {
+		name  string
+		state types.Set
+		plan  types.Set
+		want  int
+	}{
+		{name: "reordered", state: ab, plan: ba, want: 0},
+		{name: "changed", state: ab, plan: ac, want: 1},
+		{name: "removed", state: ab, plan: types.SetNull(types.StringType), want: 1},
+		{name: "unset", state: types.SetNull(types.StringType), plan: types.SetNull(types.StringType), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := credentialResourceModel{Name: types.StringValue("api"), NodesAccess: tt.state}
+			plan := credentialResourceModel{Name: types.StringValue("api"), NodesAccess: tt.plan}
+
+			paths := credentialChangedPaths(&plan, &state)
+			if len(paths) != tt.want {
+				t.Errorf("Expected %d changed paths, got %v", tt.want, paths)
+			}
+		})
+	}
+}
+
+func TestCredentialResourceModifyPlanInPlaceUpdate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	basicAuth := func(password string) tftypes.Value {
+		return credentialTestBlock(t, "basic_auth", map[string]tftypes.Value{
+			"username": tftypes.NewValue(tftypes.String, "user"),
+			"password": tftypes.NewValue(tftypes.String, password),
+		})
+	}
+	state := credentialTestState(t, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, "42"),
+		"name":       tftypes.NewValue(tftypes.String, "api"),
+		"basic_auth": basicAuth("old-secret"),
+	})
+	planState := credentialTestState(t, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, "42"),
+		"name":       tftypes.NewValue(tftypes.String, "api"),
+		"basic_auth": basicAuth("new-secret"),
+	})
+	plan := tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}
+
+	for _, tt := range []struct {
+		version     string
+		wantReplace bool
+	}{
+		{version: "1.111.0", wantReplace: false},
+		{version: "1.100.0", wantReplace: true},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"data":{"versionCli":"` + tt.version + `"}}`))
+		}))
+		defer server.Close()
+
+		host, apiKey, insecure := server.URL, "test-api-key", false
+		n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err := n8nClient.DetectVersion(ctx); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		req := resource.ModifyPlanRequest{State: state, Plan: plan}
+		resp := &resource.ModifyPlanResponse{Plan: plan}
+		(&credentialResource{client: n8nClient}).ModifyPlan(ctx, req, resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("n8n %s: unexpected diagnostics: %+v", tt.version, resp.Diagnostics)
+		}
+		if replace := len(resp.RequiresReplace) > 0; replace != tt.wantReplace {
+			t.Errorf("n8n %s: expected replacement %t, got %v", tt.version, tt.wantReplace, resp.RequiresReplace)
+		}
+	}
+}
+
+func TestExpiresAtValue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	got, diags := expiresAtValue(types.StringValue("48h"), now)
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", diags)
+	}
+	if got.ValueString() != "2025-01-03T12:00:00Z" {
+		t.Errorf("Expected 2025-01-03T12:00:00Z, got %s", got.ValueString())
+	}
+
+	got, diags = expiresAtValue(types.StringNull(), now)
+	if diags.HasError() || !got.IsNull() {
+		t.Errorf("Expected null expiry without rotate_after, got %s (%+v)", got, diags)
+	}
+
+	_, diags = expiresAtValue(types.StringValue("90d"), now)
+	if !diags.HasError() {
+		t.Errorf("Expected error for invalid duration")
+	}
+}
+
+func TestCheckNameConflict(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"id":"1","name":"api","type":"httpBasicAuth"},{"id":"2","name":"api","type":"httpHeaderAuth"}]}`))
+	}))
+	defer server.Close()
+
+	host, apiKey, insecure := server.URL, "test-api-key", false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	diags := checkNameConflict(context.Background(), n8nClient, "api", "httpBasicAuth", nameConflictWarn)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("Expected 1 warning, got %+v", diags)
+	}
+
+	diags = checkNameConflict(context.Background(), n8nClient, "api", "httpBasicAuth", nameConflictError)
+	if diags.ErrorsCount() != 1 {
+		t.Errorf("Expected 1 error, got %+v", diags)
+	}
+
+	diags = checkNameConflict(context.Background(), n8nClient, "other", "httpBasicAuth", nameConflictError)
+	if len(diags) != 0 {
+		t.Errorf("Expected no diagnostics, got %+v", diags)
+	}
+}
+
+func TestTimestampValue(t *testing.T) {
+	t.Parallel()
+
+	if value := timestampValue("2024-06-01T10:00:00.000Z"); value.ValueString() != "2024-06-01T10:00:00.000Z" {
+		t.Errorf("Expected the reported timestamp, got %v", value)
+	}
+	if value := timestampValue(""); !value.IsNull() {
+		t.Errorf("Expected null for a missing timestamp, got %v", value)
+	}
+}
+
+func TestSetHomeProject(t *testing.T) {
+	t.Parallel()
+
+	personal := &models.Project{ID: "p1", Name: "Jane Doe <jane@example.com>", Type: "personal"}
+	team := &models.Project{ID: "p2", Name: "Billing", Type: "team"}
+
+	model := credentialResourceModel{ProjectID: types.StringValue("p1")}
+	model.setHomeProject(personal)
+	if model.HomeProjectName.ValueString() != personal.Name || model.HomeProjectType.ValueString() != "personal" {
+		t.Errorf("Expected the personal project, got %v and %v", model.HomeProjectName, model.HomeProjectType)
+	}
+	if model.OwnerEmail.ValueString() != "jane@example.com" {
+		t.Errorf("Expected the owner email from the project name, got %v", model.OwnerEmail)
+	}
+
+	model = credentialResourceModel{ProjectID: types.StringValue("p2")}
+	model.setHomeProject(team)
+	if model.HomeProjectType.ValueString() != "team" || !model.OwnerEmail.IsNull() {
+		t.Errorf("Expected a team project without owner email, got %v and %v", model.HomeProjectType, model.OwnerEmail)
+	}
+
+	// A project that no longer matches project_id, e.g. before a transfer is
+	// reported, keeps known values and nulls unknown ones.
+	model = credentialResourceModel{
+		ProjectID:       types.StringValue("p2"),
+		HomeProjectName: types.StringValue("Billing"),
+		HomeProjectType: types.StringUnknown(),
+		OwnerEmail:      types.StringUnknown(),
+	}
+	model.setHomeProject(personal)
+	if model.HomeProjectName.ValueString() != "Billing" || !model.HomeProjectType.IsNull() || !model.OwnerEmail.IsNull() {
+		t.Errorf("Expected known values kept and unknown values nulled, got %v, %v and %v",
+			model.HomeProjectName, model.HomeProjectType, model.OwnerEmail)
+	}
+}
+
+func TestTransferCreatedCredential(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/credentials/42/transfer" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"forbidden"}`))
+			return
+		}
+		t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	host, apiKey, insecure := server.URL, "test-api-key", false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	credential := &models.Credential{ID: "42", HomeProject: &models.Project{ID: "personal"}}
+
+	projectID, diags := transferCreatedCredential(ctx, n8nClient, credential, types.StringNull())
+	if len(diags) != 0 || projectID.ValueString() != "personal" {
+		t.Errorf("Expected the reported project without a transfer, got %v (%+v)", projectID, diags)
+	}
+
+	// The credential exists even though the transfer failed, so the failure
+	// must not taint it. The reported project is saved for the next plan.
+	projectID, diags = transferCreatedCredential(ctx, n8nClient, credential, types.StringValue("team"))
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("Expected 1 warning, got %+v", diags)
+	}
+	if projectID.ValueString() != "personal" {
+		t.Errorf("Expected the reported project after a failed transfer, got %v", projectID)
+	}
+}
+
+func TestCredentialResourceReadSkipRefresh(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request when skipping the refresh, got %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	host, apiKey, insecure := server.URL, "test-api-key", false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	state := credentialTestState(t, map[string]tftypes.Value{
+		"id":           tftypes.NewValue(tftypes.String, "42"),
+		"name":         tftypes.NewValue(tftypes.String, "api"),
+		"skip_refresh": tftypes.NewValue(tftypes.Bool, true),
+	})
+	resp := &resource.ReadResponse{State: state}
+	(&credentialResource{client: n8nClient}).Read(ctx, resource.ReadRequest{State: state}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.Equal(state.Raw) {
+		t.Errorf("Expected the state to be kept, got %v", resp.State.Raw)
+	}
+}
+
+func TestCredentialResourceReadStrictReads(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"unauthorized"}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	state := credentialTestState(t, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "42"),
+		"name": tftypes.NewValue(tftypes.String, "api"),
+	})
+
+	for _, strict := range []bool{false, true} {
+		host, apiKey, insecure := server.URL, "test-api-key", false
+		opts := []client.Option{client.WithRetry(0, 0, 0)}
+		if strict {
+			opts = append(opts, client.WithStrictReads())
+		}
+		n8nClient, err := client.NewClient(&host, &apiKey, &insecure, opts...)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		resp := &resource.ReadResponse{State: state}
+		(&credentialResource{client: n8nClient}).Read(ctx, resource.ReadRequest{State: state}, resp)
+
+		if resp.Diagnostics.HasError() != strict {
+			t.Errorf("strict_reads %t: unexpected diagnostics: %+v", strict, resp.Diagnostics)
+		}
+		if !strict && !resp.State.Raw.Equal(state.Raw) {
+			t.Errorf("Expected the state to be kept without strict_reads, got %v", resp.State.Raw)
+		}
+	}
+}
+
+func TestCredentialResourceLifecycle(t *testing.T) {
+	t.Parallel()
+
+	server := n8ntest.NewServer(t)
+	host, apiKey, insecure := server.URL, n8ntest.APIKey, false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := n8nClient.DetectVersion(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	r := &credentialResource{client: n8nClient}
+
+	basicAuth := func(password string) tftypes.Value {
+		return credentialTestBlock(t, "basic_auth", map[string]tftypes.Value{
+			"username": tftypes.NewValue(tftypes.String, "user"),
+			"password": tftypes.NewValue(tftypes.String, password),
+		})
+	}
+	storedPassword := func(id string) interface{} {
+		t.Helper()
+		credential := server.Credential(id)
+		if credential == nil {
+			t.Fatalf("Expected credential %s to exist", id)
+		}
+		return credential.Data["password"]
+	}
+	stateFingerprint := func(state tfsdk.State) string {
+		t.Helper()
+		var fingerprints map[string]types.String
+		if diags := state.GetAttribute(ctx, path.Root("secrets_fingerprint"), &fingerprints); diags.HasError() {
+			t.Fatalf("Unexpected diagnostics: %+v", diags)
+		}
+		return fingerprints["basic_auth.password"].ValueString()
+	}
+	stateID := func(state tfsdk.State) string {
+		t.Helper()
+		var id types.String
+		if diags := state.GetAttribute(ctx, path.Root("id"), &id); diags.HasError() {
+			t.Fatalf("Unexpected diagnostics: %+v", diags)
+		}
+		return id.ValueString()
+	}
+
+	// Create
+	planState := credentialTestState(t, map[string]tftypes.Value{
+		"name":       tftypes.NewValue(tftypes.String, "api"),
+		"basic_auth": basicAuth("old-secret"),
+	})
+	createResp := &resource.CreateResponse{State: credentialTestState(t, nil)}
+	newTestPrivateState(&createResp.Private)
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: unexpected diagnostics: %+v", createResp.Diagnostics)
+	}
+	id := stateID(createResp.State)
+	if password := storedPassword(id); password != "old-secret" {
+		t.Errorf("Create: expected the secret to be stored, got %v", password)
+	}
+
+	// Read
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: unexpected diagnostics: %+v", readResp.Diagnostics)
+	}
+	if readResp.State.Raw.IsNull() || stateID(readResp.State) != id {
+		t.Fatalf("Read: expected credential %s to stay in state, got %v", id, readResp.State.Raw)
+	}
+
+	createdFingerprint := stateFingerprint(createResp.State)
+	if createdFingerprint == "" {
+		t.Fatalf("Create: expected the secret to be fingerprinted")
+	}
+
+	// Update changes the secret in place. The plan fingerprints it with the
+	// salt of the resource.
+	planState = credentialTestState(t, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, id),
+		"name":       tftypes.NewValue(tftypes.String, "api"),
+		"basic_auth": basicAuth("new-secret"),
+	})
+	plan := tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}
+	modifyResp := &resource.ModifyPlanResponse{Plan: plan, Private: createResp.Private}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{State: readResp.State, Plan: plan, Private: createResp.Private}, modifyResp)
+	if modifyResp.Diagnostics.HasError() {
+		t.Fatalf("ModifyPlan: unexpected diagnostics: %+v", modifyResp.Diagnostics)
+	}
+	plannedFingerprint := stateFingerprint(tfsdk.State{Schema: modifyResp.Plan.Schema, Raw: modifyResp.Plan.Raw})
+	if plannedFingerprint == "" || plannedFingerprint == createdFingerprint {
+		t.Errorf("ModifyPlan: expected a new fingerprint, got %q", plannedFingerprint)
+	}
+
+	updateResp := &resource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: modifyResp.Plan.Raw}, Private: createResp.Private}
+	r.Update(ctx, resource.UpdateRequest{State: readResp.State, Plan: modifyResp.Plan, Private: createResp.Private}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: unexpected diagnostics: %+v", updateResp.Diagnostics)
+	}
+	if fingerprint := stateFingerprint(updateResp.State); fingerprint != plannedFingerprint {
+		t.Errorf("Update: expected the planned fingerprint %q, got %q", plannedFingerprint, fingerprint)
+	}
+	if updatedID := stateID(updateResp.State); updatedID != id {
+		t.Errorf("Update: expected credential %s to be updated in place, got %s", id, updatedID)
+	}
+	if password := storedPassword(id); password != "new-secret" {
+		t.Errorf("Update: expected the secret to be changed, got %v", password)
+	}
+
+	// Import
+	importResp := &resource.ImportStateResponse{State: credentialTestState(t, nil)}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: id}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("ImportState: unexpected diagnostics: %+v", importResp.Diagnostics)
+	}
+	readResp = &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read after import: unexpected diagnostics: %+v", readResp.Diagnostics)
+	}
+	var name types.String
+	if diags := readResp.State.GetAttribute(ctx, path.Root("name"), &name); diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", diags)
+	}
+	if name.ValueString() != "api" {
+		t.Errorf("Read after import: expected name %q, got %v", "api", name)
+	}
+
+	// Delete
+	deleteResp := &resource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete: unexpected diagnostics: %+v", deleteResp.Diagnostics)
+	}
+	if server.Credential(id) != nil {
+		t.Errorf("Delete: expected credential %s to be deleted", id)
+	}
+
+	// A deleted credential is removed from state on refresh.
+	readResp = &resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read after delete: unexpected diagnostics: %+v", readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Errorf("Read after delete: expected the credential to be removed from state, got %v", readResp.State.Raw)
+	}
+}
+
+func TestSetSecretsFingerprint(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	model := func(password string) *credentialResourceModel {
+		state := credentialTestState(t, map[string]tftypes.Value{
+			"basic_auth": credentialTestBlock(t, "basic_auth", map[string]tftypes.Value{
+				"username": tftypes.NewValue(tftypes.String, "user"),
+				"password": tftypes.NewValue(tftypes.String, password),
+			}),
+		})
+		var model credentialResourceModel
+		if diags := state.Get(ctx, &model); diags.HasError() {
+			t.Fatalf("Unexpected diagnostics: %+v", diags)
+		}
+		return &model
+	}
+	fingerprint := func(model *credentialResourceModel) string {
+		value, ok := model.SecretsFingerprint.Elements()["basic_auth.password"].(types.String)
+		if !ok || value.IsUnknown() {
+			t.Fatalf("Expected a fingerprint of the password, got %v", model.SecretsFingerprint)
+		}
+		return value.ValueString()
+	}
+
+	// Without a salt, the plan leaves the fingerprints to the apply.
+	if value := secretsFingerprintValue(model("secret"), nil); !value.IsUnknown() {
+		t.Errorf("Expected unknown fingerprints without a salt, got %v", value)
+	}
+
+	private := testPrivateState{}
+	first := model("secret")
+	if diags := setSecretsFingerprint(ctx, first, private); diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", diags)
+	}
+	if private[secretsFingerprintSaltKey] == nil {
+		t.Fatalf("Expected the salt to be kept in private state")
+	}
+
+	// The salt is reused, so the fingerprint only changes with the secret.
+	second := model("secret")
+	if diags := setSecretsFingerprint(ctx, second, private); diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", diags)
+	}
+	if fingerprint(first) != fingerprint(second) {
+		t.Errorf("Expected the same fingerprint for the same secret, got %q and %q", fingerprint(first), fingerprint(second))
+	}
+
+	// Another resource gets another salt.
+	other := model("secret")
+	if diags := setSecretsFingerprint(ctx, other, testPrivateState{}); diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", diags)
+	}
+	if fingerprint(first) == fingerprint(other) {
+		t.Errorf("Expected different fingerprints for different resources, got %q twice", fingerprint(first))
+	}
+}
+
+// testPrivateState is the private state of a resource in tests.
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p testPrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+// newTestPrivateState points private at empty private state data, which the
+// framework provides to resources outside of tests.
+func newTestPrivateState[T any](private **T) {
+	*private = new(T)
+}
+
+// credentialTestState builds a credential state with the given attribute
+// values and every other attribute null.
+func credentialTestState(t *testing.T, attributes map[string]tftypes.Value) tfsdk.State {
+	t.Helper()
+
+	ctx := context.Background()
+	schemaResponse := &resource.SchemaResponse{}
+	NewCredentialResource().Schema(ctx, resource.SchemaRequest{}, schemaResponse)
+
+	objectType, ok := schemaResponse.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("Expected schema to be an object type")
+	}
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range attributes {
+		values[name] = value
+	}
+
+	return tfsdk.State{Schema: schemaResponse.Schema, Raw: tftypes.NewValue(objectType, values)}
+}
+
+// credentialTestBlock builds the value of a credential block with the given
+// field values and every other field null.
+func credentialTestBlock(t *testing.T, name string, fields map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	ctx := context.Background()
+	schemaResponse := &resource.SchemaResponse{}
+	NewCredentialResource().Schema(ctx, resource.SchemaRequest{}, schemaResponse)
+
+	objectType, ok := schemaResponse.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("Expected schema to be an object type")
+	}
+	blockType, ok := objectType.AttributeTypes[name].(tftypes.Object)
+	if !ok {
+		t.Fatalf("Expected %s to be an object type", name)
+	}
+
+	values := make(map[string]tftypes.Value, len(blockType.AttributeTypes))
+	for fieldName, fieldType := range blockType.AttributeTypes {
+		values[fieldName] = tftypes.NewValue(fieldType, nil)
+	}
+	for fieldName, value := range fields {
+		values[fieldName] = value
+	}
+
+	return tftypes.NewValue(blockType, values)
+}
@@ -0,0 +1,490 @@
+package provider
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// credentialFieldKind is the Terraform type of a credential field.
+type credentialFieldKind int
+
+const (
+	credentialFieldString credentialFieldKind = iota
+	credentialFieldBool
+	credentialFieldInt64
+)
+
+// credentialField describes one attribute of a credential block and the n8n
+// data key it maps to.
+type credentialField struct {
+	// name is the Terraform attribute name.
+	name string
+	// key is the key in the n8n credential data.
+	key         string
+	description string
+	kind        credentialFieldKind
+	// required fields must be set whenever the block is specified.
+	required  bool
+	sensitive bool
+	// defaultValue is used when the field is not configured. Fields without a
+	// default are omitted from the credential data when not configured.
+	defaultValue interface{}
+	// validators validate the configured value of string fields.
+	validators []validator.String
+}
+
+// credentialBlock describes a credential type block.
+type credentialBlock struct {
+	// name is the Terraform block name.
+	name string
+	// credentialType is the n8n credential type name.
+	credentialType string
+	description    string
+	fields         []credentialField
+	// prepare, when set, derives the n8n credential type and data from the
+	// field values, for blocks whose fields do not map 1:1 to n8n data keys.
+	prepare func(data map[string]interface{}) (string, map[string]interface{}, error)
+	// validators validate the configured block beyond its required fields.
+	validators []validator.Object
+}
+
+// credentialBlocks is the registry of supported credential types. Adding a
+// credential type only requires a new entry here and a matching field in
+// credentialResourceModel.
+//
+//nolint:gosec // G101: These are credential type identifiers and field names, not actual credentials
+var credentialBlocks = []credentialBlock{
+	{
+		name:           "basic_auth",
+		credentialType: "httpBasicAuth",
+		description:    "HTTP Basic Authentication credentials.",
+		fields: []credentialField{
+			{name: "username", key: "user", description: "The username for basic authentication.", required: true},
+			{name: "password", key: "password", description: "The password for basic authentication.", required: true, sensitive: true},
+		},
+	},
+	{
+		name:           "oauth2",
+		credentialType: "oAuth2Api",
+		description:    "OAuth2 API credentials.",
+		fields: []credentialField{
+			{name: "client_id", key: "clientId", description: "The OAuth2 client ID.", required: true},
+			{name: "client_secret", key: "clientSecret", description: "The OAuth2 client secret.", required: true, sensitive: true},
+			{name: "access_token_url", key: "accessTokenUrl", description: "The URL to obtain the access token.", required: true},
+			{name: "auth_url", key: "authUrl", description: "The OAuth2 authorization URL.", required: true},
+			{name: "scope", key: "scope", description: "The OAuth2 scope.", required: true},
+			{name: "auth_query_parameters", key: "authQueryParameters", description: "Additional query parameters for the authorization request.", defaultValue: ""},
+			{name: "send_additional_body_properties", key: "sendAdditionalBodyProperties", description: "Whether to send additional body properties.", kind: credentialFieldBool, defaultValue: false},
+			{name: "additional_body_properties", key: "additionalBodyProperties", description: "Additional body properties to send.", defaultValue: ""},
+		},
+	},
+	{
+		name:           "header_auth",
+		credentialType: "httpHeaderAuth",
+		description:    "HTTP Header Authentication credentials.",
+		fields: []credentialField{
+			{name: "name", key: "name", description: "The header name (e.g., 'Authorization').", required: true},
+			{name: "value", key: "value", description: "The header value (e.g., 'Bearer token').", required: true, sensitive: true},
+		},
+	},
+	{
+		name:           "microsoft_oauth2",
+		credentialType: "microsoftOAuth2Api",
+		description:    "Microsoft (Azure AD / Entra ID) OAuth2 credentials for Microsoft Graph based services.",
+		fields: []credentialField{
+			{name: "service", key: "service", description: "The Microsoft service the credential is for: generic, teams, outlook, onedrive, excel, sharepoint or graph_security. Defaults to generic.", defaultValue: "generic",
+				validators: []validator.String{stringOneOfValidator{values: microsoftOAuth2Services()}}},
+			{name: "tenant_id", key: "tenantId", description: "The directory (tenant) ID, or one of common, organizations or consumers. Defaults to common.", defaultValue: "common"},
+			{name: "client_id", key: "clientId", description: "The application (client) ID.", required: true},
+			{name: "client_secret", key: "clientSecret", description: "The client secret.", required: true, sensitive: true},
+			{name: "scope", key: "scope", description: "Space separated scopes to request. Defaults to the scopes n8n requests for the service."},
+			{name: "resource", key: "resource", description: "The resource to request a token for, for services that use the v1 endpoint."},
+		},
+		prepare: prepareMicrosoftOAuth2,
+	},
+	{
+		name:           "azure_openai",
+		credentialType: "azureOpenAiApi",
+		description:    "Azure OpenAI credentials. The model deployment name is configured on the nodes using the credential, not here.",
+		fields: []credentialField{
+			{name: "api_key", key: "apiKey", description: "The API key of the Azure OpenAI resource.", required: true, sensitive: true},
+			{name: "resource_name", key: "resourceName", description: "The name of the Azure OpenAI resource, used to build https://<resource_name>.openai.azure.com. Either resource_name or endpoint must be set."},
+			{name: "endpoint", key: "endpoint", description: "The full endpoint URL, for custom domains or proxies. Takes precedence over resource_name."},
+			{name: "api_version", key: "apiVersion", description: "The Azure OpenAI API version. Defaults to 2025-03-01-preview.", defaultValue: "2025-03-01-preview"},
+		},
+		prepare: prepareAzureOpenAI,
+	},
+	{
+		name:           "mqtt",
+		credentialType: "mqtt",
+		description:    "MQTT broker credentials.",
+		fields: []credentialField{
+			{name: "protocol", key: "protocol", description: "The protocol to connect with: mqtt, mqtts, ws or wss. Defaults to mqtt.", defaultValue: "mqtt",
+				validators: []validator.String{stringOneOfValidator{values: []string{"mqtt", "mqtts", "ws", "wss"}}}},
+			{name: "host", key: "host", description: "The hostname of the MQTT broker.", required: true},
+			{name: "port", key: "port", description: "The port of the MQTT broker. Defaults to 1883.", kind: credentialFieldInt64, defaultValue: int64(1883)},
+			{name: "username", key: "username", description: "The username to authenticate with."},
+			{name: "password", key: "password", description: "The password to authenticate with.", sensitive: true},
+			{name: "client_id", key: "clientId", description: "The client ID. Generated by n8n when not set."},
+			{name: "clean_session", key: "clean", description: "Whether to start a clean session without persisted subscriptions. Defaults to true.", kind: credentialFieldBool, defaultValue: true},
+			{name: "ssl", key: "ssl", description: "Whether to connect with TLS. Defaults to false.", kind: credentialFieldBool, defaultValue: false},
+			{name: "ca", key: "ca", description: "PEM encoded CA certificate to verify the broker with."},
+			{name: "reject_unauthorized", key: "rejectUnauthorized", description: "Whether to reject broker certificates that cannot be verified. Defaults to true.", kind: credentialFieldBool, defaultValue: true},
+			{name: "client_cert", key: "cert", description: "PEM encoded client certificate for mutual TLS."},
+			{name: "client_key", key: "key", description: "PEM encoded private key of the client certificate.", sensitive: true},
+		},
+	},
+	{
+		name:           "kafka",
+		credentialType: "kafka",
+		description:    "Apache Kafka credentials. SASL authentication is enabled when username is set.",
+		fields: []credentialField{
+			{name: "client_id", key: "clientId", description: "The client ID to identify with.", required: true},
+			{name: "brokers", key: "brokers", description: "Comma separated list of brokers, e.g. 'kafka1:9092,kafka2:9092'.", required: true},
+			{name: "ssl", key: "ssl", description: "Whether to connect with TLS. Defaults to true.", kind: credentialFieldBool, defaultValue: true},
+			{name: "sasl_mechanism", key: "saslMechanism", description: "The SASL mechanism: plain, scram-sha-256 or scram-sha-512. Defaults to plain.", defaultValue: "plain",
+				validators: []validator.String{stringOneOfValidator{values: []string{"plain", "scram-sha-256", "scram-sha-512"}}}},
+			{name: "username", key: "username", description: "The SASL username."},
+			{name: "password", key: "password", description: "The SASL password.", sensitive: true},
+		},
+		prepare: prepareKafka,
+	},
+	{
+		name:           "salesforce",
+		credentialType: "salesforceJwtApi",
+		description:    "Salesforce credentials, using either the OAuth2 JWT bearer flow or the OAuth2 authorization code flow.",
+		fields: []credentialField{
+			{name: "flow", key: "flow", description: "The authentication flow: jwt or oauth2. Defaults to jwt.", defaultValue: "jwt",
+				validators: []validator.String{stringOneOfValidator{values: []string{"jwt", "oauth2"}}}},
+			{name: "environment", key: "environment", description: "The Salesforce environment: production or sandbox. Defaults to production.", defaultValue: "production"},
+			{name: "client_id", key: "clientId", description: "The consumer key of the connected app.", required: true},
+			{name: "client_secret", key: "clientSecret", description: "The consumer secret of the connected app. Required for the oauth2 flow and not allowed for the jwt flow.", sensitive: true},
+			{name: "username", key: "username", description: "The username to issue tokens for. Required for the jwt flow and not allowed for the oauth2 flow."},
+			{name: "private_key", key: "privateKey", description: "PEM encoded private key matching the certificate of the connected app. Required for the jwt flow and not allowed for the oauth2 flow.", sensitive: true},
+		},
+		prepare: prepareSalesforce,
+		validators: []validator.Object{
+			flowAttributesValidator{
+				flow:        "flow",
+				defaultFlow: "jwt",
+				attributes: map[string][]string{
+					"jwt":    {"username", "private_key"},
+					"oauth2": {"client_secret"},
+				},
+			},
+		},
+	},
+	{
+		name:           "tls_certificate",
+		credentialType: "httpSslAuth",
+		description:    "Client TLS certificate credentials for the HTTP Request node, for upstreams protected by mutual TLS.",
+		fields: []credentialField{
+			{name: "client_cert", key: "cert", description: "PEM encoded client certificate.", required: true},
+			{name: "client_key", key: "key", description: "PEM encoded private key of the client certificate.", required: true, sensitive: true},
+			{name: "ca", key: "ca", description: "PEM encoded CA certificate to verify the upstream with."},
+			{name: "passphrase", key: "passphrase", description: "The passphrase of the private key.", sensitive: true},
+		},
+	},
+}
+
+// credentialBlockNames lists the credential type blocks, of which exactly one
+// must be specified.
+var credentialBlockNames = func() []string {
+	names := make([]string, len(credentialBlocks))
+	for i, block := range credentialBlocks {
+		names[i] = block.name
+	}
+	return names
+}()
+
+// schemaBlock generates the schema for the credential block.
+func (b *credentialBlock) schemaBlock() schema.Block {
+	attributes := make(map[string]schema.Attribute, len(b.fields))
+	var required []string
+
+	for i := range b.fields {
+		field := &b.fields[i]
+		attributes[field.name] = field.schemaAttribute()
+		if field.required {
+			required = append(required, field.name)
+		}
+	}
+
+	var validators []validator.Object
+	if len(required) > 0 {
+		validators = append(validators, requiredAttributesValidator{attributes: required})
+	}
+
+	return schema.SingleNestedBlock{
+		Description: b.description,
+		Attributes:  attributes,
+		Validators:  append(validators, b.validators...),
+	}
+}
+
+// schemaAttribute generates the schema for the credential field. Required
+// fields are optional in the schema because the whole block is optional; they
+// are enforced by the block validator instead.
+func (f *credentialField) schemaAttribute() schema.Attribute {
+	hasDefault := f.defaultValue != nil
+
+	switch f.kind {
+	case credentialFieldBool:
+		attribute := schema.BoolAttribute{
+			Description: f.description,
+			Optional:    true,
+			Computed:    hasDefault,
+			Sensitive:   f.sensitive,
+		}
+		if value, ok := f.defaultValue.(bool); ok {
+			attribute.Default = booldefault.StaticBool(value)
+		}
+		return attribute
+	case credentialFieldInt64:
+		attribute := schema.Int64Attribute{
+			Description: f.description,
+			Optional:    true,
+			Computed:    hasDefault,
+			Sensitive:   f.sensitive,
+		}
+		if value, ok := f.defaultValue.(int64); ok {
+			attribute.Default = int64default.StaticInt64(value)
+		}
+		return attribute
+	case credentialFieldString:
+		fallthrough
+	default:
+		attribute := schema.StringAttribute{
+			Description: f.description,
+			Optional:    true,
+			Computed:    hasDefault,
+			Sensitive:   f.sensitive,
+			Validators:  f.validators,
+		}
+		if value, ok := f.defaultValue.(string); ok {
+			attribute.Default = stringdefault.StaticString(value)
+		}
+		return attribute
+	}
+}
+
+// credential returns the n8n credential type and data for a configured block value.
+func (b *credentialBlock) credential(value types.Object) (string, map[string]interface{}, error) {
+	data, err := b.credentialData(value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if b.prepare == nil {
+		return b.credentialType, data, nil
+	}
+
+	return b.prepare(data)
+}
+
+// credentialData converts a configured block value to n8n credential data.
+func (b *credentialBlock) credentialData(value types.Object) (map[string]interface{}, error) {
+	attributes := value.Attributes()
+	data := make(map[string]interface{}, len(b.fields))
+
+	for i := range b.fields {
+		field := &b.fields[i]
+
+		fieldValue, ok := attributes[field.name]
+		if !ok {
+			return nil, fmt.Errorf("missing attribute %s in %s block", field.name, b.name)
+		}
+
+		converted, err := field.credentialValue(fieldValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid attribute %s in %s block: %w", field.name, b.name, err)
+		}
+		if converted == nil {
+			continue
+		}
+
+		data[field.key] = converted
+	}
+
+	return data, nil
+}
+
+// credentialValue converts a field value to its n8n representation, falling back
+// to the default for unset fields. It returns nil for unset fields without a default.
+func (f *credentialField) credentialValue(value attr.Value) (interface{}, error) {
+	if value.IsNull() || value.IsUnknown() {
+		return f.defaultValue, nil
+	}
+
+	switch v := value.(type) {
+	case types.String:
+		return v.ValueString(), nil
+	case types.Bool:
+		return v.ValueBool(), nil
+	case types.Int64:
+		return v.ValueInt64(), nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+// secretFingerprints adds the fingerprint of each configured sensitive field
+// of a block value to fingerprints, keyed by "<block>.<field>". Fields whose
+// value is not known yet get an unknown fingerprint.
+func (b *credentialBlock) secretFingerprints(value types.Object, salt []byte, fingerprints map[string]attr.Value) {
+	attributes := value.Attributes()
+
+	for i := range b.fields {
+		field := &b.fields[i]
+
+		fieldValue, ok := attributes[field.name]
+		if !field.sensitive || !ok || fieldValue.IsNull() {
+			continue
+		}
+
+		key := b.name + "." + field.name
+		if fieldValue.IsUnknown() {
+			fingerprints[key] = types.StringUnknown()
+			continue
+		}
+
+		converted, err := field.credentialValue(fieldValue)
+		if err != nil {
+			continue
+		}
+		fingerprints[key] = types.StringValue(secretFingerprint(salt, key, fmt.Sprint(converted)))
+	}
+}
+
+// secretFingerprint returns a short HMAC identifying a secret value. The
+// HMAC is keyed with the random salt of the resource, which is kept in its
+// private state, so a fingerprint seen in a plan cannot be used to confirm a
+// guessed secret. The field key is included, so the same secret used in two
+// fields does not give away that they are equal.
+func secretFingerprint(salt []byte, key, value string) string {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(key + "\x00" + value))
+	return hex.EncodeToString(mac.Sum(nil)[:6])
+}
+
+// microsoftOAuth2Types maps the microsoft_oauth2 service to its n8n credential type.
+var microsoftOAuth2Types = map[string]string{
+	"generic":        "microsoftOAuth2Api",
+	"teams":          "microsoftTeamsOAuth2Api",
+	"outlook":        "microsoftOutlookOAuth2Api",
+	"onedrive":       "microsoftOneDriveOAuth2Api",
+	"excel":          "microsoftExcelOAuth2Api",
+	"sharepoint":     "microsoftSharePointOAuth2Api",
+	"graph_security": "microsoftGraphSecurityOAuth2Api",
+}
+
+// microsoftOAuth2Services returns the supported microsoft_oauth2 services, sorted.
+func microsoftOAuth2Services() []string {
+	services := make([]string, 0, len(microsoftOAuth2Types))
+	for service := range microsoftOAuth2Types {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	return services
+}
+
+// prepareMicrosoftOAuth2 selects the credential type for the service and builds
+// the tenant specific authorization endpoints.
+func prepareMicrosoftOAuth2(data map[string]interface{}) (string, map[string]interface{}, error) {
+	service, _ := data["service"].(string)
+	credentialType, ok := microsoftOAuth2Types[service]
+	if !ok {
+		return "", nil, fmt.Errorf("unsupported Microsoft service %q", service)
+	}
+
+	tenant, _ := data["tenantId"].(string)
+	prepared := map[string]interface{}{
+		"clientId":            data["clientId"],
+		"clientSecret":        data["clientSecret"],
+		"authUrl":             fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/authorize", url.PathEscape(tenant)),
+		"accessTokenUrl":      fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/token", url.PathEscape(tenant)),
+		"authQueryParameters": "response_mode=query",
+	}
+	if scope, ok := data["scope"]; ok {
+		prepared["scope"] = scope
+	}
+	if resource, ok := data["resource"].(string); ok {
+		prepared["authQueryParameters"] = "response_mode=query&resource=" + url.QueryEscape(resource)
+	}
+
+	return credentialType, prepared, nil
+}
+
+// prepareAzureOpenAI ensures the Azure OpenAI resource can be located.
+func prepareAzureOpenAI(data map[string]interface{}) (string, map[string]interface{}, error) {
+	_, hasResourceName := data["resourceName"]
+	_, hasEndpoint := data["endpoint"]
+	if !hasResourceName && !hasEndpoint {
+		return "", nil, fmt.Errorf("either resource_name or endpoint must be set")
+	}
+
+	return "azureOpenAiApi", data, nil
+}
+
+// prepareKafka enables SASL authentication when a username is configured.
+func prepareKafka(data map[string]interface{}) (string, map[string]interface{}, error) {
+	_, hasUsername := data["username"]
+	data["authentication"] = hasUsername
+	if !hasUsername {
+		delete(data, "saslMechanism")
+	}
+
+	return "kafka", data, nil
+}
+
+// prepareSalesforce selects the credential type for the flow and checks that
+// the fields the flow needs are set.
+func prepareSalesforce(data map[string]interface{}) (string, map[string]interface{}, error) {
+	flow, _ := data["flow"].(string)
+	prepared := map[string]interface{}{
+		"environment": data["environment"],
+		"clientId":    data["clientId"],
+	}
+
+	var credentialType string
+	var required []string
+	switch flow {
+	case "jwt":
+		credentialType = "salesforceJwtApi"
+		required = []string{"username", "privateKey"}
+	case "oauth2":
+		credentialType = "salesforceOAuth2Api"
+		required = []string{"clientSecret"}
+	default:
+		return "", nil, fmt.Errorf("unsupported Salesforce flow %q", flow)
+	}
+
+	for _, key := range required {
+		value, ok := data[key]
+		if !ok {
+			return "", nil, fmt.Errorf("%s is required for the %s flow", salesforceFieldNames[key], flow)
+		}
+		prepared[key] = value
+	}
+
+	return credentialType, prepared, nil
+}
+
+// salesforceFieldNames maps Salesforce data keys to their attribute names for error messages.
+var salesforceFieldNames = map[string]string{
+	"clientSecret": "client_secret",
+	"username":     "username",
+	"privateKey":   "private_key",
+}
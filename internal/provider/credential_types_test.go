@@ -0,0 +1,314 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCredentialBlocksRegistry(t *testing.T) {
+	t.Parallel()
+
+	schemaResponse := &resource.SchemaResponse{}
+	NewCredentialResource().Schema(context.Background(), resource.SchemaRequest{}, schemaResponse)
+
+	modelBlocks := (&credentialResourceModel{}).blockValues()
+	seenTypes := map[string]bool{}
+
+	for i := range credentialBlocks {
+		block := &credentialBlocks[i]
+
+		if block.credentialType == "" {
+			t.Errorf("block %s has no credential type", block.name)
+		}
+		if seenTypes[block.credentialType] {
+			t.Errorf("credential type %s is registered twice", block.credentialType)
+		}
+		seenTypes[block.credentialType] = true
+
+		if _, ok := modelBlocks[block.name]; !ok {
+			t.Errorf("block %s has no field in credentialResourceModel", block.name)
+		}
+
+		schemaBlock, ok := schemaResponse.Schema.Blocks[block.name].(schema.SingleNestedBlock)
+		if !ok {
+			t.Errorf("missing block: %s", block.name)
+			continue
+		}
+
+		seenKeys := map[string]bool{}
+		for j := range block.fields {
+			field := &block.fields[j]
+
+			if _, ok := schemaBlock.Attributes[field.name]; !ok {
+				t.Errorf("block %s is missing attribute %s", block.name, field.name)
+			}
+			if seenKeys[field.key] {
+				t.Errorf("block %s maps data key %s twice", block.name, field.key)
+			}
+			seenKeys[field.key] = true
+		}
+	}
+
+	if len(modelBlocks) != len(credentialBlocks) {
+		t.Errorf("credentialResourceModel has %d blocks, registry has %d", len(modelBlocks), len(credentialBlocks))
+	}
+}
+
+func TestCredentialFieldValidators(t *testing.T) {
+	t.Parallel()
+
+	schemaResponse := &resource.SchemaResponse{}
+	NewCredentialResource().Schema(context.Background(), resource.SchemaRequest{}, schemaResponse)
+
+	tests := []struct {
+		block     string
+		attribute string
+		value     string
+		wantError bool
+	}{
+		{block: "microsoft_oauth2", attribute: "service", value: "teams"},
+		{block: "microsoft_oauth2", attribute: "service", value: "graph_security"},
+		{block: "microsoft_oauth2", attribute: "service", value: "sharepoint_online", wantError: true},
+		{block: "mqtt", attribute: "protocol", value: "wss"},
+		{block: "mqtt", attribute: "protocol", value: "tcp", wantError: true},
+		{block: "kafka", attribute: "sasl_mechanism", value: "scram-sha-512"},
+		{block: "kafka", attribute: "sasl_mechanism", value: "SCRAM-SHA-512", wantError: true},
+		{block: "salesforce", attribute: "flow", value: "oauth2"},
+		{block: "salesforce", attribute: "flow", value: "password", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.block+"."+tt.attribute+"="+tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			block := schemaResponse.Schema.Blocks[tt.block].(schema.SingleNestedBlock)
+			attribute := block.Attributes[tt.attribute].(schema.StringAttribute)
+
+			req := validator.StringRequest{
+				Path:        path.Root(tt.block).AtName(tt.attribute),
+				ConfigValue: types.StringValue(tt.value),
+			}
+			resp := &validator.StringResponse{}
+			for _, v := range attribute.Validators {
+				v.ValidateString(context.Background(), req, resp)
+			}
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("Expected error: %v, got diagnostics: %+v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestCredentialBlockData(t *testing.T) {
+	t.Parallel()
+
+	block := credentialBlock{
+		name:           "example",
+		credentialType: "exampleApi",
+		fields: []credentialField{
+			{name: "token", key: "token", sensitive: true, required: true},
+			{name: "region", key: "region"},
+			{name: "port", key: "port", kind: credentialFieldInt64, defaultValue: int64(443)},
+			{name: "verify", key: "verify", kind: credentialFieldBool, defaultValue: true},
+		},
+	}
+
+	value := types.ObjectValueMust(
+		map[string]attr.Type{
+			"token":  types.StringType,
+			"region": types.StringType,
+			"port":   types.Int64Type,
+			"verify": types.BoolType,
+		},
+		map[string]attr.Value{
+			"token":  types.StringValue("secret"),
+			"region": types.StringNull(),
+			"port":   types.Int64Null(),
+			"verify": types.BoolValue(false),
+		},
+	)
+
+	data, err := block.credentialData(value)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if data["token"] != "secret" {
+		t.Errorf("Expected token to be set, got %v", data["token"])
+	}
+	if _, ok := data["region"]; ok {
+		t.Errorf("Expected unset field without default to be omitted")
+	}
+	if data["port"] != int64(443) {
+		t.Errorf("Expected port to default to 443, got %v", data["port"])
+	}
+	if data["verify"] != false {
+		t.Errorf("Expected verify to be false, got %v", data["verify"])
+	}
+}
+
+func TestCredentialBlockSecretFingerprints(t *testing.T) {
+	t.Parallel()
+
+	block := credentialBlock{
+		name: "example",
+		fields: []credentialField{
+			{name: "token", key: "token", sensitive: true},
+			{name: "private_key", key: "privateKey", sensitive: true},
+			{name: "passphrase", key: "passphrase", sensitive: true},
+			{name: "region", key: "region"},
+		},
+	}
+	attributeTypes := map[string]attr.Type{
+		"token":       types.StringType,
+		"private_key": types.StringType,
+		"passphrase":  types.StringType,
+		"region":      types.StringType,
+	}
+
+	salt := []byte("salt")
+	fingerprints := map[string]attr.Value{}
+	block.secretFingerprints(types.ObjectValueMust(attributeTypes, map[string]attr.Value{
+		"token":       types.StringValue("secret"),
+		"private_key": types.StringUnknown(),
+		"passphrase":  types.StringNull(),
+		"region":      types.StringValue("eu"),
+	}), salt, fingerprints)
+
+	if len(fingerprints) != 2 {
+		t.Fatalf("Expected fingerprints of the configured secrets only, got %v", fingerprints)
+	}
+	token, ok := fingerprints["example.token"].(types.String)
+	if !ok || token.IsUnknown() || len(token.ValueString()) != 12 || token.ValueString() == "secret" {
+		t.Errorf("Expected a 12 character fingerprint of the token, got %v", fingerprints["example.token"])
+	}
+	if !fingerprints["example.private_key"].IsUnknown() {
+		t.Errorf("Expected an unknown fingerprint for an unknown secret, got %v", fingerprints["example.private_key"])
+	}
+
+	if secretFingerprint(salt, "example.token", "secret") == secretFingerprint(salt, "example.token", "rotated") {
+		t.Error("Expected different secrets to have different fingerprints")
+	}
+	if secretFingerprint(salt, "example.token", "secret") == secretFingerprint(salt, "example.other", "secret") {
+		t.Error("Expected the same secret in different fields to have different fingerprints")
+	}
+	if secretFingerprint(salt, "example.token", "secret") == secretFingerprint([]byte("other salt"), "example.token", "secret") {
+		t.Error("Expected the same secret of different resources to have different fingerprints")
+	}
+}
+
+func TestPrepareMicrosoftOAuth2(t *testing.T) {
+	t.Parallel()
+
+	credentialType, data, err := prepareMicrosoftOAuth2(map[string]interface{}{
+		"service":      "teams",
+		"tenantId":     "contoso.onmicrosoft.com",
+		"clientId":     "client",
+		"clientSecret": "secret",
+		"resource":     "https://graph.microsoft.com",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if credentialType != "microsoftTeamsOAuth2Api" {
+		t.Errorf("Expected microsoftTeamsOAuth2Api, got %s", credentialType)
+	}
+	if data["authUrl"] != "https://login.microsoftonline.com/contoso.onmicrosoft.com/oauth2/v2.0/authorize" {
+		t.Errorf("Unexpected authUrl: %v", data["authUrl"])
+	}
+	if data["authQueryParameters"] != "response_mode=query&resource=https%3A%2F%2Fgraph.microsoft.com" {
+		t.Errorf("Unexpected authQueryParameters: %v", data["authQueryParameters"])
+	}
+	if _, ok := data["tenantId"]; ok {
+		t.Errorf("Expected tenantId to be removed from the data")
+	}
+	if _, ok := data["scope"]; ok {
+		t.Errorf("Expected unset scope to be omitted")
+	}
+
+	if _, _, err := prepareMicrosoftOAuth2(map[string]interface{}{"service": "skype"}); err == nil {
+		t.Errorf("Expected error for unsupported service")
+	}
+}
+
+func TestPrepareAzureOpenAI(t *testing.T) {
+	t.Parallel()
+
+	credentialType, data, err := prepareAzureOpenAI(map[string]interface{}{
+		"apiKey":       "secret",
+		"resourceName": "contoso",
+		"apiVersion":   "2025-03-01-preview",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if credentialType != "azureOpenAiApi" || data["resourceName"] != "contoso" {
+		t.Errorf("Unexpected credential: %s %v", credentialType, data)
+	}
+
+	if _, _, err := prepareAzureOpenAI(map[string]interface{}{"apiKey": "secret"}); err == nil {
+		t.Errorf("Expected error without resource_name or endpoint")
+	}
+}
+
+func TestPrepareKafka(t *testing.T) {
+	t.Parallel()
+
+	_, data, err := prepareKafka(map[string]interface{}{"clientId": "n8n", "brokers": "kafka:9092", "saslMechanism": "plain"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if data["authentication"] != false {
+		t.Errorf("Expected authentication to be disabled without username")
+	}
+	if _, ok := data["saslMechanism"]; ok {
+		t.Errorf("Expected saslMechanism to be omitted without username")
+	}
+
+	_, data, err = prepareKafka(map[string]interface{}{"clientId": "n8n", "brokers": "kafka:9092", "saslMechanism": "plain", "username": "user"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if data["authentication"] != true || data["saslMechanism"] != "plain" {
+		t.Errorf("Expected SASL authentication to be enabled, got %v", data)
+	}
+}
+
+func TestPrepareSalesforce(t *testing.T) {
+	t.Parallel()
+
+	credentialType, data, err := prepareSalesforce(map[string]interface{}{
+		"flow":        "jwt",
+		"environment": "sandbox",
+		"clientId":    "client",
+		"username":    "user@example.com",
+		"privateKey":  "key",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if credentialType != "salesforceJwtApi" || data["privateKey"] != "key" {
+		t.Errorf("Unexpected credential: %s %v", credentialType, data)
+	}
+	if _, ok := data["flow"]; ok {
+		t.Errorf("Expected flow to be removed from the data")
+	}
+
+	_, _, err = prepareSalesforce(map[string]interface{}{
+		"flow":        "oauth2",
+		"environment": "production",
+		"clientId":    "client",
+	})
+	if err == nil {
+		t.Errorf("Expected error without client_secret for the oauth2 flow")
+	}
+}
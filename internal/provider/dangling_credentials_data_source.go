@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &danglingCredentialsDataSource{}
+	_ datasource.DataSourceWithConfigure = &danglingCredentialsDataSource{}
+)
+
+// NewDanglingCredentialsDataSource is a helper function to simplify the provider implementation.
+func NewDanglingCredentialsDataSource() datasource.DataSource {
+	return &danglingCredentialsDataSource{}
+}
+
+// danglingCredentialsDataSource is the data source implementation.
+type danglingCredentialsDataSource struct {
+	client *client.Client
+}
+
+// danglingCredentialsDataSourceModel maps the data source schema data.
+type danglingCredentialsDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Workflows     types.List   `tfsdk:"workflows"`
+	CredentialIDs types.Set    `tfsdk:"credential_ids"`
+}
+
+// Metadata returns the data source type name.
+func (d *danglingCredentialsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dangling_credentials"
+}
+
+// Schema defines the schema for the data source.
+func (d *danglingCredentialsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fails the plan when nodes of workflow definitions reference credentials that do not exist on the " +
+			"instance, naming the node and credential. n8n accepts such workflows and only fails when the nodes run. " +
+			"References are matched by credential ID, or by name and type when they have no ID.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier of the check. The SHA-256 hash of the checked workflow definitions.",
+				Computed:    true,
+			},
+			"workflows": schema.ListAttribute{
+				Description: "The workflow JSON documents to check.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"credential_ids": schema.SetAttribute{
+				Description: "Further credential IDs to accept, e.g. `n8n_credential.api.id` for credentials managed in the same " +
+					"configuration. IDs of credentials that are yet to be created are unknown, which defers the check to the apply.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *danglingCredentialsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	n8nClient, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = n8nClient
+}
+
+// Read refreshes the Terraform state with the latest data.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (d *danglingCredentialsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, warnRetries := withRetryWarning(ctx)
+	defer warnRetries(&resp.Diagnostics)
+
+	var state danglingCredentialsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var definitions []string
+	diags = state.Workflows.ElementsAs(ctx, &definitions, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var credentialIDs []string
+	if !state.CredentialIDs.IsNull() {
+		diags = state.CredentialIDs.ElementsAs(ctx, &credentialIDs, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Info(ctx, "Checking credential references", map[string]interface{}{
+		"workflow_count": len(definitions),
+	})
+
+	for i, definition := range definitions {
+		workflowPath := path.Root("workflows").AtListIndex(i)
+
+		var workflow models.Workflow
+		if err := json.Unmarshal([]byte(definition), &workflow); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				workflowPath,
+				"Invalid Workflow Definition",
+				fmt.Sprintf("The workflow definition is not valid JSON: %s", err.Error()),
+			)
+			continue
+		}
+
+		dangling, err := d.client.DanglingCredentialReferences(ctx, &workflow, credentialIDs)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error checking credential references",
+				fmt.Sprintf("Could not check the credential references of workflow %q: %s", workflow.Name, errorDetail(err)),
+			)
+			return
+		}
+		for _, reference := range dangling {
+			resp.Diagnostics.AddAttributeError(
+				workflowPath,
+				"Dangling Credential Reference",
+				danglingCredentialDescription(&workflow, reference),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(definitions, "\n")))
+	state.ID = types.StringValue(hex.EncodeToString(sum[:]))
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Checked credential references", map[string]interface{}{
+		"workflow_count": len(definitions),
+	})
+}
+
+// danglingCredentialDescription describes a credential reference of a
+// workflow node that does not exist on the instance.
+func danglingCredentialDescription(workflow *models.Workflow, reference models.NodeCredential) string {
+	credential := fmt.Sprintf("%q", reference.Reference.Name)
+	switch {
+	case reference.Reference.ID == "":
+	case reference.Reference.Name == "":
+		credential = "ID " + reference.Reference.ID
+	default:
+		credential += " (ID " + reference.Reference.ID + ")"
+	}
+
+	return fmt.Sprintf("Node %q of workflow %q uses the %s credential %s, which does not exist on the instance. "+
+		"Create the credential or point the node at an existing one, e.g. with provider::n8n::inject_credentials.",
+		reference.NodeName, workflow.Name, reference.Type, credential)
+}
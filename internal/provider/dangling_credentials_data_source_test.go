@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/artus-engineering/terraform-provider-n8n/internal/n8ntest"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDanglingCredentialsDataSourceSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResponse := &datasource.SchemaResponse{}
+
+	NewDanglingCredentialsDataSource().Schema(ctx, datasource.SchemaRequest{}, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	for _, name := range []string{"id", "workflows", "credential_ids"} {
+		if _, ok := schemaResponse.Schema.Attributes[name]; !ok {
+			t.Errorf("missing attribute: %s", name)
+		}
+	}
+}
+
+func TestDanglingCredentialsDataSourceMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadataResponse := &datasource.MetadataResponse{}
+	NewDanglingCredentialsDataSource().Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "n8n"}, metadataResponse)
+
+	if metadataResponse.TypeName != "n8n_dangling_credentials" {
+		t.Errorf("Expected TypeName to be 'n8n_dangling_credentials', got '%s'", metadataResponse.TypeName)
+	}
+}
+
+func TestDanglingCredentialsDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	server := n8ntest.NewServer(t)
+	slackID := server.AddCredential(models.Credential{Name: "Slack account", Type: "slackApi"})
+
+	host, apiKey, insecure := server.URL, n8ntest.APIKey, false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	workflow := `{"name":"Notify","nodes":[` +
+		`{"name":"Slack","type":"n8n-nodes-base.slack","credentials":{"slackApi":{"id":"` + slackID + `","name":"Slack account"}}},` +
+		`{"name":"Mail","type":"n8n-nodes-base.emailSend","credentials":{"smtp":{"id":"managed-1","name":"SMTP"}}},` +
+		`{"name":"Jira","type":"n8n-nodes-base.jira","credentials":{"jiraSoftwareCloudApi":{"id":"99","name":"Jira"}}}]}`
+
+	ctx := context.Background()
+	schemaResponse := &datasource.SchemaResponse{}
+	NewDanglingCredentialsDataSource().Schema(ctx, datasource.SchemaRequest{}, schemaResponse)
+	objectType, ok := schemaResponse.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("Expected schema to be an object type")
+	}
+	config := tfsdk.Config{
+		Schema: schemaResponse.Schema,
+		Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id": tftypes.NewValue(tftypes.String, nil),
+			"workflows": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, workflow),
+			}),
+			"credential_ids": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "managed-1"),
+			}),
+		}),
+	}
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema, Raw: config.Raw}}
+	(&danglingCredentialsDataSource{client: n8nClient}).Read(ctx, datasource.ReadRequest{Config: config}, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("Expected 1 error, got %+v", resp.Diagnostics)
+	}
+	detail := resp.Diagnostics.Errors()[0].Detail()
+	if !strings.Contains(detail, `Node "Jira" of workflow "Notify" uses the jiraSoftwareCloudApi credential "Jira" (ID 99)`) {
+		t.Errorf("Unexpected detail: %s", detail)
+	}
+}
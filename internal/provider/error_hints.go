@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+)
+
+// errorHint maps a known n8n API failure to a remediation hint. An error
+// matches when its message contains all of the patterns, case-insensitively.
+type errorHint struct {
+	patterns []string
+	hint     string
+}
+
+// errorHints lists known n8n API failures, most specific first.
+var errorHints = []errorHint{
+	{
+		patterns: []string{"configured as read_only"},
+		hint:     "The provider refuses changes in read_only mode. Run the apply with a provider configuration that does not set read_only.",
+	},
+	{
+		patterns: []string{"status 401", "n8n cloud"},
+		hint: "n8n Cloud rejected the API key. The n8n API is not available during the free trial; " +
+			"on paid plans, create an API key under Settings > n8n API of the workspace.",
+	},
+	{
+		patterns: []string{"status 401"},
+		hint:     "The API key was rejected. Check that api_key is correct, has not expired and belongs to an existing user.",
+	},
+	{
+		patterns: []string{"license"},
+		hint:     "This feature requires an n8n license that includes it. Check the license on the Usage and plan settings page of the instance.",
+	},
+	{
+		patterns: []string{"credential type", "not"},
+		hint:     "The credential type is not known to this n8n instance. Make sure the node or community package providing it is installed.",
+	},
+	{
+		patterns: []string{"must have required property"},
+		hint:     "The credential data does not match the credential type's schema. Compare the block attributes with the schema returned by GET /api/v1/credentials/schema/{type}.",
+	},
+	{
+		patterns: []string{"must not have additional properties"},
+		hint:     "The credential data contains fields the credential type doesn't support. Compare the block attributes with the schema returned by GET /api/v1/credentials/schema/{type}.",
+	},
+	{
+		patterns: []string{"no node to start the workflow"},
+		hint:     "Only workflows with a trigger node can be activated. Add a trigger (e.g. Schedule Trigger or Webhook) or leave the workflow inactive.",
+	},
+	{
+		patterns: []string{"webhook", "conflict"},
+		hint:     "Another active workflow already uses this webhook path and HTTP method. Change the path or deactivate the other workflow.",
+	},
+	{
+		patterns: []string{"webhook", "already"},
+		hint:     "Another active workflow already uses this webhook path and HTTP method. Change the path or deactivate the other workflow.",
+	},
+	{
+		patterns: []string{"modified out-of-band"},
+		hint: "The workflow was changed outside of Terraform, e.g. in the n8n editor. Review the changes shown by terraform plan, then copy them into the configuration " +
+			"to keep them, or set allow_overwrite_remote_changes to overwrite them.",
+	},
+	{
+		patterns: []string{"someone else just updated this workflow"},
+		hint: "The workflow was changed outside of Terraform, e.g. in the n8n editor. Review the changes shown by terraform plan, then copy them into the configuration " +
+			"to keep them, or set allow_overwrite_remote_changes to overwrite them.",
+	},
+	{
+		patterns: []string{"status 403"},
+		hint:     "The API key's user lacks permission for this operation. Use an API key of an owner or admin, or grant the user access to the project.",
+	},
+}
+
+// remediationHint returns a hint for a known n8n API failure, or an empty
+// string when the error is not recognized.
+func remediationHint(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, h := range errorHints {
+		matches := true
+		for _, pattern := range h.patterns {
+			if !strings.Contains(message, pattern) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return h.hint
+		}
+	}
+
+	return ""
+}
+
+// errorDetail renders an error for a diagnostic detail, appending a
+// remediation hint when one is known. Requests n8n rejected with a client
+// error are described as such, with n8n's message, hint and the request ID.
+func errorDetail(err error) string {
+	detail := err.Error()
+
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 {
+		detail = fmt.Sprintf("n8n rejected the request (status %d): %s", apiErr.StatusCode, apiErr.Message)
+		if apiErr.Hint != "" {
+			detail = fmt.Sprintf("%s (hint: %s)", detail, apiErr.Hint)
+		}
+		if apiErr.RequestID != "" {
+			detail = fmt.Sprintf("%s (request ID: %s)", detail, apiErr.RequestID)
+		}
+	}
+
+	hint := remediationHint(err)
+	if hint == "" {
+		return detail
+	}
+	return detail + "\n\nHint: " + hint
+}
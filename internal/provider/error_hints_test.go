@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+)
+
+func TestRemediationHint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		wantHint bool
+		contains string
+	}{
+		{
+			name:     "unauthorized",
+			err:      errors.New(`API error (status 401): {"message":"unauthorized"}`),
+			wantHint: true,
+			contains: "api_key",
+		},
+		{
+			name:     "n8n cloud unauthorized",
+			err:      errors.New(`API error (status 401) from n8n Cloud workspace: {"message":"unauthorized"}`),
+			wantHint: true,
+			contains: "free trial",
+		},
+		{
+			name:     "read only",
+			err:      errors.New("refusing POST credentials: the provider is configured as read_only"),
+			wantHint: true,
+			contains: "read_only",
+		},
+		{
+			name:     "workflow modified",
+			err:      errors.New("workflow 1 has version b instead of a: workflow was modified out-of-band"),
+			wantHint: true,
+			contains: "allow_overwrite_remote_changes",
+		},
+		{
+			name:     "workflow modified in metadata update",
+			err:      errors.New(`API error (status 400): {"message":"Your most recent changes may be lost, because someone else just updated this workflow."}`),
+			wantHint: true,
+			contains: "allow_overwrite_remote_changes",
+		},
+		{
+			name:     "license missing",
+			err:      errors.New(`API error (status 403): {"message":"Your license does not allow for feat:projectRole:admin"}`),
+			wantHint: true,
+			contains: "license",
+		},
+		{
+			name:     "unknown credential type",
+			err:      errors.New(`API error (status 400): {"message":"req.body.type is not a known credential type"}`),
+			wantHint: true,
+			contains: "credential type",
+		},
+		{
+			name:     "no trigger",
+			err:      errors.New(`API error (status 400): {"message":"Workflow has no node to start the workflow - at least one trigger, poller or webhook node is required"}`),
+			wantHint: true,
+			contains: "trigger",
+		},
+		{
+			name:     "webhook conflict",
+			err:      errors.New(`API error (status 409): {"message":"There is a conflict with one of the webhooks."}`),
+			wantHint: true,
+			contains: "webhook path",
+		},
+		{
+			name:     "unknown error",
+			err:      errors.New("error making request: connection refused"),
+			wantHint: false,
+		},
+		{
+			name:     "nil error",
+			err:      nil,
+			wantHint: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			hint := remediationHint(tt.err)
+			if (hint != "") != tt.wantHint {
+				t.Fatalf("Expected hint %v, got %q", tt.wantHint, hint)
+			}
+			if !strings.Contains(hint, tt.contains) {
+				t.Errorf("Expected hint to contain %q, got %q", tt.contains, hint)
+			}
+		})
+	}
+}
+
+func TestErrorDetail(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New(`API error (status 401): {"message":"unauthorized"}`)
+	detail := errorDetail(err)
+	if !strings.HasPrefix(detail, err.Error()) || !strings.Contains(detail, "\n\nHint: ") {
+		t.Errorf("Unexpected detail: %q", detail)
+	}
+
+	plain := errors.New("something else")
+	if errorDetail(plain) != plain.Error() {
+		t.Errorf("Expected unchanged detail, got %q", errorDetail(plain))
+	}
+
+	rejected := fmt.Errorf("error creating credential: %w", &client.APIError{
+		StatusCode: 400,
+		Message:    "'scope' must be a string",
+		Hint:       "Check the scope of the OAuth2 credential",
+		Body:       `{"message":"'scope' must be a string","hint":"Check the scope of the OAuth2 credential","stacktrace":"..."}`,
+	})
+	expected := "n8n rejected the request (status 400): 'scope' must be a string (hint: Check the scope of the OAuth2 credential)"
+	if errorDetail(rejected) != expected {
+		t.Errorf("Expected %q, got %q", expected, errorDetail(rejected))
+	}
+
+	withRequestID := &client.APIError{StatusCode: 404, Message: "Not Found", RequestID: "abc"}
+	expected = "n8n rejected the request (status 404): Not Found (request ID: abc)"
+	if errorDetail(withRequestID) != expected {
+		t.Errorf("Expected %q, got %q", expected, errorDetail(withRequestID))
+	}
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &expressionFunction{}
+
+// NewExpressionFunction is a helper function to simplify the provider implementation.
+func NewExpressionFunction() function.Function {
+	return &expressionFunction{}
+}
+
+// expressionFunction is the function implementation.
+type expressionFunction struct{}
+
+// Metadata returns the function name.
+func (f *expressionFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "expression"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *expressionFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build an n8n expression",
+		MarkdownDescription: "Returns an n8n expression, `={{ code }}`, for use as a node parameter. Each `%s` in `code` is replaced " +
+			"by the next of `values` as a JavaScript string literal, with quotes, backslashes and braces escaped, so values " +
+			"from Terraform cannot break the expression. `%%` renders a literal `%`. For example, " +
+			"`provider::n8n::expression(\"$json.channel ?? %s\", var.default_channel)`. " +
+			"`code` must not contain `}}`, which would end the expression early; separate the braces with a space instead.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "code",
+				Description: "The JavaScript code of the expression, with a %s placeholder for each value.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:        "values",
+			Description: "The values substituted for the placeholders of code.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run builds the expression.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (f *expressionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var code string
+	var values []string
+	resp.Error = req.Arguments.Get(ctx, &code, &values)
+	if resp.Error != nil {
+		return
+	}
+
+	expression, err := buildExpression(code, values)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, expression)
+}
+
+// buildExpression renders code as an n8n expression with each %s replaced by
+// the next value as a JavaScript string literal.
+func buildExpression(code string, values []string) (string, error) {
+	if strings.Contains(code, "}}") {
+		return "", fmt.Errorf("code must not contain }}, which ends the expression, separate the braces with a space")
+	}
+
+	var rendered strings.Builder
+	next := 0
+	for i := 0; i < len(code); i++ {
+		if code[i] != '%' {
+			rendered.WriteByte(code[i])
+			continue
+		}
+
+		i++
+		switch {
+		case i < len(code) && code[i] == '%':
+			rendered.WriteByte('%')
+		case i < len(code) && code[i] == 's':
+			if next == len(values) {
+				return "", fmt.Errorf("code has more %%s placeholders than the %d values given", len(values))
+			}
+			literal, err := javaScriptString(values[next])
+			if err != nil {
+				return "", err
+			}
+			rendered.WriteString(literal)
+			next++
+		default:
+			return "", fmt.Errorf("code may only contain %%s placeholders, escape other percent signs as %%%%")
+		}
+	}
+	if next < len(values) {
+		return "", fmt.Errorf("code has %d %%s placeholders, but %d values were given", next, len(values))
+	}
+
+	return "={{ " + strings.TrimSpace(rendered.String()) + " }}", nil
+}
+
+// javaScriptString renders a value as a JavaScript string literal. Braces are
+// escaped so literals cannot contain the {{ and }} delimiting n8n expressions.
+func javaScriptString(value string) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", fmt.Errorf("error encoding value: %w", err)
+	}
+
+	literal := strings.TrimSuffix(buf.String(), "\n")
+	literal = strings.ReplaceAll(literal, "{", `\u007b`)
+	literal = strings.ReplaceAll(literal, "}", `\u007d`)
+	return literal, nil
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestExpressionFunctionMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadataResponse := &function.MetadataResponse{}
+	NewExpressionFunction().Metadata(context.Background(), function.MetadataRequest{}, metadataResponse)
+
+	if metadataResponse.Name != "expression" {
+		t.Errorf("Expected Name to be 'expression', got '%s'", metadataResponse.Name)
+	}
+}
+
+func TestExpressionFunctionRun(t *testing.T) {
+	t.Parallel()
+
+	values := types.TupleValueMust(
+		[]attr.Type{types.StringType},
+		[]attr.Value{types.StringValue(`#alerts "prod"`)},
+	)
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("$json.channel ?? %s"), values}),
+	}
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.StringUnknown()),
+	}
+
+	NewExpressionFunction().Run(context.Background(), req, resp)
+
+	if resp.Error != nil {
+		t.Fatalf("Unexpected error: %s", resp.Error)
+	}
+	expected := types.StringValue(`={{ $json.channel ?? "#alerts \"prod\"" }}`)
+	if got := resp.Result.Value(); !got.Equal(expected) {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
+
+func TestBuildExpression(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		code     string
+		values   []string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "code only",
+			code:     " $json.total * 100 ",
+			expected: "={{ $json.total * 100 }}",
+		},
+		{
+			name:     "escaped values",
+			code:     "%s + $json.name + %s",
+			values:   []string{"Hi {{ $secrets }}, ", "back\\slash\nnewline <b>"},
+			expected: `={{ "Hi \u007b\u007b $secrets \u007d\u007d, " + $json.name + "back\\slash\nnewline <b>" }}`,
+		},
+		{
+			name:     "literal percent",
+			code:     "$json.ratio + %%",
+			expected: "={{ $json.ratio + % }}",
+		},
+		{
+			name:    "closing braces",
+			code:    "({a: {b: 1}})",
+			wantErr: true,
+		},
+		{
+			name:    "missing value",
+			code:    "%s + %s",
+			values:  []string{"a"},
+			wantErr: true,
+		},
+		{
+			name:    "extra value",
+			code:    "$json.name",
+			values:  []string{"a"},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported verb",
+			code:    "%d",
+			values:  []string{"1"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := buildExpression(tc.code, tc.values)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("Expected error but got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
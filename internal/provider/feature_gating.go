@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// enterpriseFeatureDiagnostics returns an error on the attribute when the
+// instance's license lacks the enterprise feature the attribute needs.
+// Resources check features at plan time, so a missing entitlement fails the
+// plan instead of a request in the middle of the apply with a 403.
+func enterpriseFeatureDiagnostics(ctx context.Context, n8nClient *client.Client, feature client.EnterpriseFeature, attribute path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	err := n8nClient.CheckEnterpriseFeature(ctx, feature)
+	var notLicensed *client.FeatureNotLicensedError
+	if errors.As(err, &notLicensed) {
+		diags.AddAttributeError(
+			attribute,
+			"Feature Not Licensed",
+			fmt.Sprintf("The %s attribute cannot be applied: %s. "+
+				"Check the license on the Usage and plan settings page of the instance, or remove the attribute.",
+				attribute.String(), err.Error()),
+		)
+	}
+	return diags
+}
+
+// internalAPIDiagnostics returns an error on the attribute when the internal
+// API the attribute needs is not enabled, so the plan fails instead of the
+// apply.
+func internalAPIDiagnostics(n8nClient *client.Client, attribute path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !n8nClient.InternalAPIEnabled() {
+		diags.AddAttributeError(
+			attribute,
+			"Internal API Required",
+			fmt.Sprintf("The %s attribute requires the internal API. "+
+				"Set enable_internal_api in the provider configuration, or remove the attribute.", attribute.String()),
+		)
+	}
+	return diags
+}
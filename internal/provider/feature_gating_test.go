@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestEnterpriseFeatureDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"versionCli":"1.80.0","enterprise":{"sharing":false,"variables":true}}}`))
+	}))
+	defer server.Close()
+
+	host, apiKey, insecure := server.URL, "test-api-key", false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	diags := enterpriseFeatureDiagnostics(context.Background(), n8nClient, client.FeatureSharing, path.Root("shared_with"))
+	if !diags.HasError() {
+		t.Fatal("Expected an error for an unlicensed feature")
+	}
+	if detail := diags[0].Detail(); !strings.Contains(detail, "feature sharing not licensed on this instance") {
+		t.Errorf("Expected the detail to name the feature, got %q", detail)
+	}
+
+	diags = enterpriseFeatureDiagnostics(context.Background(), n8nClient, client.FeatureVariables, path.Root("value"))
+	if diags.HasError() {
+		t.Errorf("Expected no error for a licensed feature, got %+v", diags)
+	}
+}
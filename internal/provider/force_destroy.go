@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// forceDestroyAttribute returns the force_destroy attribute shared by resources
+// whose deletion is blocked by other objects in n8n, such as projects with
+// workflows or tags in use. dependents describes those objects and effect what
+// happens to them when the resource is force destroyed, e.g. "Their nodes are
+// left without a credential." Like every resource attribute, the value only
+// applies to a destroy once it has been applied to the state.
+func forceDestroyAttribute(dependents, effect string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: fmt.Sprintf("Whether to delete the resource while %s. %s "+
+			"When false, deletion fails and lists them. When unset, deletion proceeds with a warning for each of them. "+
+			"Must be applied before the destroy to take effect.", dependents, effect),
+		Optional: true,
+	}
+}
+
+// destroyDependent is an object blocking the deletion of a resource.
+type destroyDependent struct {
+	// Description identifies the object, e.g. `workflow "Sync" (ID 7)`.
+	Description string
+	// Detail is an optional detail, e.g. the nodes using a credential.
+	Detail string
+}
+
+// String returns the description and detail of the dependent.
+func (d destroyDependent) String() string {
+	if d.Detail == "" {
+		return d.Description
+	}
+	return fmt.Sprintf("%s: %s", d.Description, d.Detail)
+}
+
+// forceDestroyDiagnostics reports the dependents of a resource being destroyed
+// according to its force_destroy value: nothing when true, an error listing all
+// dependents when false and a warning per dependent when unset. subject names
+// the resource, e.g. "credential ID 42", and remedy tells how to release the
+// dependents without force_destroy.
+func forceDestroyDiagnostics(forceDestroy types.Bool, subject, remedy string, dependents []destroyDependent) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(dependents) == 0 || forceDestroy.ValueBool() {
+		return diags
+	}
+
+	if forceDestroy.IsNull() || forceDestroy.IsUnknown() {
+		for _, dependent := range dependents {
+			diags.AddWarning(
+				"Deleting "+subject+" with dependents",
+				fmt.Sprintf("%s depends on %s. %s", dependent, subject, remedy),
+			)
+		}
+		return diags
+	}
+
+	descriptions := make([]string, 0, len(dependents))
+	for _, dependent := range dependents {
+		descriptions = append(descriptions, "  - "+dependent.String())
+	}
+	diags.AddError(
+		"Cannot delete "+subject,
+		fmt.Sprintf("The following objects depend on %s:\n%s\n\n%s "+
+			"Alternatively, set force_destroy = true and apply it before destroying.",
+			subject, strings.Join(descriptions, "\n"), remedy),
+	)
+	return diags
+}
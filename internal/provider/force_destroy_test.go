@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestForceDestroyDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	dependents := []destroyDependent{
+		{Description: `Workflow "Sync" (ID 7)`, Detail: "nodes Slack"},
+		{Description: `Workflow "Report" (ID 9)`},
+	}
+	remedy := "Update the workflows to use the replacement credential."
+
+	diags := forceDestroyDiagnostics(types.BoolValue(true), "credential ID 42", remedy, dependents)
+	if len(diags) != 0 {
+		t.Errorf("Expected no diagnostics when forced, got %+v", diags)
+	}
+
+	diags = forceDestroyDiagnostics(types.BoolNull(), "credential ID 42", remedy, dependents)
+	if diags.HasError() || diags.WarningsCount() != 2 {
+		t.Errorf("Expected 2 warnings when unset, got %+v", diags)
+	}
+
+	diags = forceDestroyDiagnostics(types.BoolValue(false), "credential ID 42", remedy, dependents)
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("Expected 1 error when disabled, got %+v", diags)
+	}
+	detail := diags.Errors()[0].Detail()
+	for _, expected := range []string{`Workflow "Sync" (ID 7): nodes Slack`, `Workflow "Report" (ID 9)`, remedy, "force_destroy = true"} {
+		if !strings.Contains(detail, expected) {
+			t.Errorf("Expected error detail to contain %q, got %q", expected, detail)
+		}
+	}
+
+	diags = forceDestroyDiagnostics(types.BoolValue(false), "credential ID 42", remedy, nil)
+	if len(diags) != 0 {
+		t.Errorf("Expected no diagnostics without dependents, got %+v", diags)
+	}
+}
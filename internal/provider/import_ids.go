@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// importIDSeparator separates the parts of composite import IDs, e.g.
+// "project_id/credential_id" for project-scoped resources or
+// "workflow_id/tag_id" for associations.
+const importIDSeparator = "/"
+
+// parseImportID splits an import ID into the parts named by one of the
+// accepted formats, e.g. [][]string{{"credential_id"}, {"project_id",
+// "credential_id"}}. The format with the matching number of parts is used.
+// The error lists every accepted format.
+func parseImportID(id string, formats ...[]string) (map[string]string, error) {
+	parts := strings.Split(id, importIDSeparator)
+	for _, format := range formats {
+		if len(format) != len(parts) {
+			continue
+		}
+
+		values := make(map[string]string, len(format))
+		for i, name := range format {
+			if parts[i] == "" {
+				return nil, fmt.Errorf("invalid import ID %q: %s must not be empty. Expected %s", id, name, importIDFormats(formats))
+			}
+			values[name] = parts[i]
+		}
+		return values, nil
+	}
+
+	return nil, fmt.Errorf("invalid import ID %q. Expected %s", id, importIDFormats(formats))
+}
+
+// importIDFormats describes the accepted import ID formats, e.g.
+// "credential_id or project_id/credential_id".
+func importIDFormats(formats [][]string) string {
+	descriptions := make([]string, len(formats))
+	for i, format := range formats {
+		descriptions[i] = strings.Join(format, importIDSeparator)
+	}
+	return strings.Join(descriptions, " or ")
+}
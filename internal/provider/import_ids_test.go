@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseImportID(t *testing.T) {
+	t.Parallel()
+
+	parts, err := parseImportID("42", credentialImportIDFormats...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if parts["credential_id"] != "42" || len(parts) != 1 {
+		t.Errorf("Expected the credential ID only, got %v", parts)
+	}
+
+	parts, err = parseImportID("project-1/42", credentialImportIDFormats...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if parts["project_id"] != "project-1" || parts["credential_id"] != "42" {
+		t.Errorf("Expected the project and credential ID, got %v", parts)
+	}
+
+	for _, id := range []string{"a/b/c", "/42", "project-1/"} {
+		_, err := parseImportID(id, credentialImportIDFormats...)
+		if err == nil {
+			t.Errorf("Expected an error for import ID %q", id)
+			continue
+		}
+		if !strings.Contains(err.Error(), "credential_id or project_id/credential_id") {
+			t.Errorf("Expected the error to list the accepted formats, got %q", err)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &injectCredentialsFunction{}
+
+// NewInjectCredentialsFunction is a helper function to simplify the provider implementation.
+func NewInjectCredentialsFunction() function.Function {
+	return &injectCredentialsFunction{}
+}
+
+// injectCredentialsFunction is the function implementation.
+type injectCredentialsFunction struct{}
+
+// Metadata returns the function name.
+func (f *injectCredentialsFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "inject_credentials"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *injectCredentialsFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Point the credential references of a workflow at other credentials",
+		MarkdownDescription: "Returns the workflow JSON document with the credential references of its nodes rewritten. " +
+			"References whose credential name or ID is a key of `credentials` are pointed at the mapped credential ID, " +
+			"e.g. `n8n_credential.slack.id`, so workflows exported from one instance can be promoted to another.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "workflow",
+				Description: "The workflow JSON document, e.g. as exported from the n8n editor.",
+			},
+			function.MapParameter{
+				Name:        "credentials",
+				Description: "The credential IDs to reference, keyed by the credential names or IDs referenced in the workflow.",
+				ElementType: types.StringType,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run rewrites the credential references of the workflow.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (f *injectCredentialsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var workflow string
+	var credentials map[string]string
+	resp.Error = req.Arguments.Get(ctx, &workflow, &credentials)
+	if resp.Error != nil {
+		return
+	}
+
+	injected, err := models.InjectCredentials([]byte(workflow), credentials)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, string(injected))
+}
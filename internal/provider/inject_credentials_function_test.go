@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestInjectCredentialsFunctionMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadataResponse := &function.MetadataResponse{}
+	NewInjectCredentialsFunction().Metadata(context.Background(), function.MetadataRequest{}, metadataResponse)
+
+	if metadataResponse.Name != "inject_credentials" {
+		t.Errorf("Expected Name to be 'inject_credentials', got '%s'", metadataResponse.Name)
+	}
+}
+
+func TestInjectCredentialsFunctionRun(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		workflow string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "rewrites references",
+			workflow: `{"name":"Notify","nodes":[{"name":"Slack","credentials":{"slackApi":{"id":"dev-1","name":"Slack account"}}}],"connections":{}}`,
+			expected: `{"connections":{},"name":"Notify","nodes":[{"credentials":{"slackApi":{"id":"prod-1","name":"Slack account"}},"name":"Slack"}]}`,
+		},
+		{
+			name:     "invalid JSON",
+			workflow: `not json`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			credentials := types.MapValueMust(types.StringType, map[string]attr.Value{
+				"Slack account": types.StringValue("prod-1"),
+			})
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tc.workflow), credentials}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewInjectCredentialsFunction().Run(context.Background(), req, resp)
+
+			if tc.wantErr {
+				if resp.Error == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("Unexpected error: %s", resp.Error)
+			}
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(tc.expected)) {
+				t.Errorf("Expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &instanceFeaturesDataSource{}
+	_ datasource.DataSourceWithConfigure = &instanceFeaturesDataSource{}
+)
+
+// NewInstanceFeaturesDataSource is a helper function to simplify the provider implementation.
+func NewInstanceFeaturesDataSource() datasource.DataSource {
+	return &instanceFeaturesDataSource{}
+}
+
+// instanceFeaturesDataSource is the data source implementation.
+type instanceFeaturesDataSource struct {
+	client *client.Client
+}
+
+// instanceFeaturesDataSourceModel maps the data source schema data.
+type instanceFeaturesDataSourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Version          types.String `tfsdk:"version"`
+	TeamProjects     types.Bool   `tfsdk:"team_projects"`
+	TeamProjectLimit types.Int64  `tfsdk:"team_project_limit"`
+	Sharing          types.Bool   `tfsdk:"sharing"`
+	Variables        types.Bool   `tfsdk:"variables"`
+	ExternalSecrets  types.Bool   `tfsdk:"external_secrets"`
+	SourceControl    types.Bool   `tfsdk:"source_control"`
+	SSO              types.Bool   `tfsdk:"sso"`
+	SAML             types.Bool   `tfsdk:"saml"`
+	OIDC             types.Bool   `tfsdk:"oidc"`
+	LDAP             types.Bool   `tfsdk:"ldap"`
+	LogStreaming     types.Bool   `tfsdk:"log_streaming"`
+	WorkflowHistory  types.Bool   `tfsdk:"workflow_history"`
+}
+
+// Metadata returns the data source type name.
+func (d *instanceFeaturesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance_features"
+}
+
+// Schema defines the schema for the data source.
+func (d *instanceFeaturesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	featureAttribute := func(feature string) schema.BoolAttribute {
+		return schema.BoolAttribute{
+			Description: fmt.Sprintf("Whether the %s feature is licensed and enabled on the instance.", feature),
+			Computed:    true,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Reports which enterprise features are licensed and enabled on the n8n instance, so configurations can " +
+			"create resources conditionally, e.g. `count = data.n8n_instance_features.this.variables ? 1 : 0`. Recent n8n " +
+			"versions only report the features to signed-in users, which requires enable_internal_api in the provider configuration.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier of the data source. The URL of the instance.",
+				Computed:    true,
+			},
+			"version": schema.StringAttribute{
+				Description: "The version of the instance, e.g. \"1.80.0\". Null when it could not be detected.",
+				Computed:    true,
+			},
+			"team_projects": schema.BoolAttribute{
+				Description: "Whether the license allows team projects.",
+				Computed:    true,
+			},
+			"team_project_limit": schema.Int64Attribute{
+				Description: "The number of team projects the license allows, -1 for unlimited.",
+				Computed:    true,
+			},
+			"sharing":          featureAttribute("sharing"),
+			"variables":        featureAttribute("variables"),
+			"external_secrets": featureAttribute("external secrets"),
+			"source_control":   featureAttribute("source control"),
+			"sso": schema.BoolAttribute{
+				Description: "Whether any single sign-on method, SAML, OIDC or LDAP, is licensed and enabled on the instance.",
+				Computed:    true,
+			},
+			"saml":             featureAttribute("SAML"),
+			"oidc":             featureAttribute("OIDC"),
+			"ldap":             featureAttribute("LDAP"),
+			"log_streaming":    featureAttribute("log streaming"),
+			"workflow_history": featureAttribute("workflow history"),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *instanceFeaturesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	n8nClient, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = n8nClient
+}
+
+// Read refreshes the Terraform state with the latest data.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (d *instanceFeaturesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, warnRetries := withRetryWarning(ctx)
+	defer warnRetries(&resp.Diagnostics)
+
+	tflog.Info(ctx, "Reading instance features")
+
+	settings, err := d.client.GetEnterpriseSettings(ctx)
+	if errors.Is(err, client.ErrEnterpriseSettingsUnavailable) {
+		resp.Diagnostics.AddError(
+			"Instance Features Unavailable",
+			"The n8n instance only reports its enterprise features to signed-in users. "+
+				"Set enable_internal_api, email and password in the provider configuration.",
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading instance features",
+			fmt.Sprintf("Could not read the instance settings: %s", errorDetail(err)),
+		)
+		return
+	}
+
+	state := instanceFeaturesValue(settings)
+	state.ID = types.StringValue(d.client.Host)
+	state.Version = types.StringNull()
+	if version, ok := d.client.Version(); ok {
+		state.Version = types.StringValue(version.String())
+	}
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// instanceFeaturesValue maps the enterprise settings to the data source model.
+func instanceFeaturesValue(settings *models.EnterpriseSettings) instanceFeaturesDataSourceModel {
+	return instanceFeaturesDataSourceModel{
+		TeamProjects:     types.BoolValue(settings.TeamProjects()),
+		TeamProjectLimit: types.Int64Value(int64(settings.Projects.Team.Limit)),
+		Sharing:          types.BoolValue(settings.Sharing),
+		Variables:        types.BoolValue(settings.Variables),
+		ExternalSecrets:  types.BoolValue(settings.ExternalSecrets),
+		SourceControl:    types.BoolValue(settings.SourceControl),
+		SSO:              types.BoolValue(settings.SSO()),
+		SAML:             types.BoolValue(settings.SAML),
+		OIDC:             types.BoolValue(settings.OIDC),
+		LDAP:             types.BoolValue(settings.LDAP),
+		LogStreaming:     types.BoolValue(settings.LogStreaming),
+		WorkflowHistory:  types.BoolValue(settings.WorkflowHistory),
+	}
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestInstanceFeaturesDataSourceSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResponse := &datasource.SchemaResponse{}
+
+	NewInstanceFeaturesDataSource().Schema(ctx, datasource.SchemaRequest{}, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	for _, name := range []string{"id", "version", "team_projects", "team_project_limit", "sharing", "variables",
+		"external_secrets", "source_control", "sso", "saml", "oidc", "ldap", "log_streaming", "workflow_history"} {
+		if _, ok := schemaResponse.Schema.Attributes[name]; !ok {
+			t.Errorf("missing attribute: %s", name)
+		}
+	}
+}
+
+func TestInstanceFeaturesDataSourceMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadataResponse := &datasource.MetadataResponse{}
+	NewInstanceFeaturesDataSource().Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "n8n"}, metadataResponse)
+
+	if metadataResponse.TypeName != "n8n_instance_features" {
+		t.Errorf("Expected TypeName to be 'n8n_instance_features', got '%s'", metadataResponse.TypeName)
+	}
+}
+
+func TestInstanceFeaturesValue(t *testing.T) {
+	t.Parallel()
+
+	settings := &models.EnterpriseSettings{Variables: true, OIDC: true}
+	settings.Projects.Team.Limit = 3
+
+	value := instanceFeaturesValue(settings)
+	if !value.Variables.ValueBool() || value.SourceControl.ValueBool() || value.ExternalSecrets.ValueBool() {
+		t.Errorf("Unexpected features: %+v", value)
+	}
+	if !value.SSO.ValueBool() || !value.OIDC.ValueBool() || value.SAML.ValueBool() {
+		t.Errorf("Expected SSO through OIDC, got %+v", value)
+	}
+	if !value.TeamProjects.ValueBool() || value.TeamProjectLimit.ValueInt64() != 3 {
+		t.Errorf("Expected 3 team projects, got %+v", value)
+	}
+}
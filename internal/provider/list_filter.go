@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// listFilterModel maps the filter block shared by data sources listing
+// objects, such as credentials, workflows, users or executions.
+type listFilterModel struct {
+	NameRegex    types.String `tfsdk:"name_regex"`
+	Tag          types.String `tfsdk:"tag"`
+	ProjectID    types.String `tfsdk:"project_id"`
+	CreatedAfter types.String `tfsdk:"created_after"`
+}
+
+// listFilterBlock returns the filter block of list data sources. objects
+// names what is listed, e.g. "workflows". Every data source listing objects
+// uses this block rather than its own filter attributes, so filters behave
+// the same everywhere.
+func listFilterBlock(objects string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: fmt.Sprintf("Restricts the %s to those matching all of the given criteria. Lists all %s when not specified.", objects, objects),
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "Regular expression (RE2 syntax) the name must match, e.g. \"^prod-\".",
+				Optional:    true,
+				Validators: []validator.String{
+					regexValidator{},
+				},
+			},
+			"tag": schema.StringAttribute{
+				Description: "Name of a tag the object must have. Objects without tags, such as credentials, never match.",
+				Optional:    true,
+			},
+			"project_id": schema.StringAttribute{
+				Description: "ID of the project owning the object. Only objects for which n8n reports the owning project match.",
+				Optional:    true,
+			},
+			"created_after": schema.StringAttribute{
+				Description: "RFC 3339 timestamp the object must have been created after, e.g. \"2024-01-01T00:00:00Z\".",
+				Optional:    true,
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+		},
+	}
+}
+
+// listFilterItem holds the properties of a listed object that filters match.
+type listFilterItem struct {
+	Name      string
+	Tags      []string
+	ProjectID string
+	CreatedAt string
+}
+
+// workflowFilterItem returns the properties of a workflow that filters match.
+func workflowFilterItem(workflow *models.Workflow) listFilterItem {
+	item := listFilterItem{
+		Name:      workflow.Name,
+		Tags:      workflow.TagNames(),
+		CreatedAt: workflow.CreatedAt,
+	}
+	if workflow.HomeProject != nil {
+		item.ProjectID = workflow.HomeProject.ID
+	}
+	return item
+}
+
+// listFilter is a compiled filter block.
+type listFilter struct {
+	nameRegex    *regexp.Regexp
+	tag          string
+	projectID    string
+	createdAfter time.Time
+}
+
+// newListFilter compiles the filter block value. A null block matches every
+// object.
+func newListFilter(ctx context.Context, value types.Object) (*listFilter, diag.Diagnostics) {
+	filter := &listFilter{}
+	if value.IsNull() || value.IsUnknown() {
+		return filter, nil
+	}
+
+	var model listFilterModel
+	diags := value.As(ctx, &model, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if !model.NameRegex.IsNull() {
+		nameRegex, err := regexp.Compile(model.NameRegex.ValueString())
+		if err != nil {
+			diags.AddError("Invalid Filter", fmt.Sprintf("The name_regex value is not a valid regular expression: %s", err.Error()))
+			return nil, diags
+		}
+		filter.nameRegex = nameRegex
+	}
+
+	if !model.CreatedAfter.IsNull() {
+		createdAfter, err := time.Parse(time.RFC3339, model.CreatedAfter.ValueString())
+		if err != nil {
+			diags.AddError("Invalid Filter", fmt.Sprintf("The created_after value is not an RFC 3339 timestamp: %s", err.Error()))
+			return nil, diags
+		}
+		filter.createdAfter = createdAfter
+	}
+
+	filter.tag = model.Tag.ValueString()
+	filter.projectID = model.ProjectID.ValueString()
+	return filter, diags
+}
+
+// matches reports whether the object matches every criterion of the filter.
+// Objects whose creation time is unknown do not match created_after.
+func (f *listFilter) matches(item listFilterItem) bool {
+	if f.nameRegex != nil && !f.nameRegex.MatchString(item.Name) {
+		return false
+	}
+	if f.tag != "" && !slices.Contains(item.Tags, f.tag) {
+		return false
+	}
+	if f.projectID != "" && item.ProjectID != f.projectID {
+		return false
+	}
+	if !f.createdAfter.IsZero() {
+		createdAt, err := time.Parse(time.RFC3339, item.CreatedAt)
+		if err != nil || !createdAt.After(f.createdAfter) {
+			return false
+		}
+	}
+	return true
+}
+
+// regexValidator validates that a string is a valid regular expression.
+type regexValidator struct{}
+
+// Description returns a human-readable description of the validator.
+func (v regexValidator) Description(_ context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+// MarkdownDescription returns a markdown formatted human-readable description of the validator.
+func (v regexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements the validation logic.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (v regexValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Regular Expression",
+			fmt.Sprintf("The value %q is not a valid regular expression: %s", req.ConfigValue.ValueString(), err.Error()),
+		)
+	}
+}
+
+// rfc3339Validator validates that a string is an RFC 3339 timestamp.
+type rfc3339Validator struct{}
+
+// Description returns a human-readable description of the validator.
+func (v rfc3339Validator) Description(_ context.Context) string {
+	return "value must be an RFC 3339 timestamp such as \"2024-01-01T00:00:00Z\""
+}
+
+// MarkdownDescription returns a markdown formatted human-readable description of the validator.
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements the validation logic.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (v rfc3339Validator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp",
+			fmt.Sprintf("The value %q is not an RFC 3339 timestamp: %s", req.ConfigValue.ValueString(), err.Error()),
+		)
+	}
+}
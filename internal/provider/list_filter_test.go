@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// listFilterValue returns a filter block value with the given attributes set.
+func listFilterValue(values map[string]string) types.Object {
+	attributes := map[string]attr.Value{
+		"name_regex":    types.StringNull(),
+		"tag":           types.StringNull(),
+		"project_id":    types.StringNull(),
+		"created_after": types.StringNull(),
+	}
+	attributeTypes := make(map[string]attr.Type, len(attributes))
+	for name := range attributes {
+		attributeTypes[name] = types.StringType
+	}
+	for name, value := range values {
+		attributes[name] = types.StringValue(value)
+	}
+	return types.ObjectValueMust(attributeTypes, attributes)
+}
+
+func TestListFilter(t *testing.T) {
+	t.Parallel()
+
+	workflow := &models.Workflow{
+		Name:        "prod-sync",
+		Tags:        []models.WorkflowTag{{ID: "1", Name: "billing"}},
+		HomeProject: &models.Project{ID: "project-1"},
+		CreatedAt:   "2024-06-01T10:00:00.000Z",
+	}
+
+	tests := []struct {
+		name    string
+		filter  types.Object
+		matches bool
+	}{
+		{"no filter", types.ObjectNull(nil), true},
+		{"matching name", listFilterValue(map[string]string{"name_regex": "^prod-"}), true},
+		{"other name", listFilterValue(map[string]string{"name_regex": "^staging-"}), false},
+		{"matching tag", listFilterValue(map[string]string{"tag": "billing"}), true},
+		{"other tag", listFilterValue(map[string]string{"tag": "marketing"}), false},
+		{"matching project", listFilterValue(map[string]string{"project_id": "project-1"}), true},
+		{"other project", listFilterValue(map[string]string{"project_id": "project-2"}), false},
+		{"created after", listFilterValue(map[string]string{"created_after": "2024-01-01T00:00:00Z"}), true},
+		{"created before", listFilterValue(map[string]string{"created_after": "2025-01-01T00:00:00Z"}), false},
+		{"all criteria", listFilterValue(map[string]string{"name_regex": "sync", "tag": "billing", "project_id": "project-1"}), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			filter, diags := newListFilter(context.Background(), test.filter)
+			if diags.HasError() {
+				t.Fatalf("Unexpected diagnostics: %+v", diags)
+			}
+			if matches := filter.matches(workflowFilterItem(workflow)); matches != test.matches {
+				t.Errorf("Expected matches to be %t, got %t", test.matches, matches)
+			}
+		})
+	}
+}
+
+func TestListFilterProjectRequiresReportedProject(t *testing.T) {
+	t.Parallel()
+
+	filter, diags := newListFilter(context.Background(), listFilterValue(map[string]string{"project_id": "project-1"}))
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", diags)
+	}
+	if filter.matches(workflowFilterItem(&models.Workflow{Name: "unknown project"})) {
+		t.Error("Expected workflows without a reported project not to match")
+	}
+}
+
+func TestListFilterInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, values := range []map[string]string{
+		{"name_regex": "("},
+		{"created_after": "yesterday"},
+	} {
+		if _, diags := newListFilter(context.Background(), listFilterValue(values)); !diags.HasError() {
+			t.Errorf("Expected an error for filter %v", values)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package provider
+
+import (
+	"cmp"
+	"slices"
+)
+
+// sortedByID returns a copy of the listed objects ordered by ID. Every data
+// source listing objects orders its results this way, so the order the API
+// returns them in never changes the data source and for_each over the results
+// keeps stable addresses. IDs are used rather than names as they are unique.
+func sortedByID[T any](items []T, id func(*T) string) []T {
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, func(a, b T) int {
+		return cmp.Compare(id(&a), id(&b))
+	})
+	return sorted
+}
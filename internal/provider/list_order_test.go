@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+)
+
+func TestSortedByID(t *testing.T) {
+	t.Parallel()
+
+	workflows := []models.Workflow{{ID: "c", Name: "first"}, {ID: "a", Name: "second"}, {ID: "b", Name: "third"}}
+	sorted := sortedByID(workflows, func(workflow *models.Workflow) string { return workflow.ID })
+
+	ids := make([]string, len(sorted))
+	for i := range sorted {
+		ids[i] = sorted[i].ID
+	}
+	if !slices.Equal(ids, []string{"a", "b", "c"}) {
+		t.Errorf("Expected workflows ordered by ID, got %v", ids)
+	}
+	if workflows[0].ID != "c" {
+		t.Errorf("Expected the listed workflows to be left unchanged, got %v", workflows)
+	}
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// normalizeOptionsParameter returns the optional trailing parameter of the
+// functions comparing and normalizing workflow definitions.
+func normalizeOptionsParameter() function.Parameter {
+	return function.MapParameter{
+		Name:        "options",
+		ElementType: types.BoolType,
+		Description: "An optional object of options, e.g. `{ ignore_ui_layout = false }`. " +
+			"`ignore_ui_layout` selects whether node positions and sticky notes, which change whenever the canvas is rearranged in the editor, are ignored; defaults to true. " +
+			"`ignore_pin_data` selects whether pinData, the test data pinned to nodes in the editor, is ignored; defaults to true.",
+	}
+}
+
+// functionNormalizeOptions returns the normalize options selected by the
+// options argument of a function. Without the argument, the UI layout and
+// pinned test data are ignored.
+func functionNormalizeOptions(arguments []map[string]bool) (models.NormalizeOptions, error) {
+	options := models.NormalizeOptions{
+		IgnoreUILayout: true,
+		IgnorePinData:  true,
+	}
+
+	if len(arguments) > 1 {
+		return options, fmt.Errorf("at most one options object may be passed, got %d", len(arguments))
+	}
+	for _, argument := range arguments {
+		for name, value := range argument {
+			switch name {
+			case "ignore_ui_layout":
+				options.IgnoreUILayout = value
+			case "ignore_pin_data":
+				options.IgnorePinData = value
+			default:
+				return options, fmt.Errorf("unsupported option %q", name)
+			}
+		}
+	}
+
+	return options, nil
+}
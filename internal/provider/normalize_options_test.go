@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFunctionNormalizeOptions(t *testing.T) {
+	t.Parallel()
+
+	options, err := functionNormalizeOptions(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !options.IgnoreUILayout || !options.IgnorePinData {
+		t.Errorf("Expected the UI layout and pinned data to be ignored by default, got %+v", options)
+	}
+
+	options, err = functionNormalizeOptions([]map[string]bool{{"ignore_ui_layout": false, "ignore_pin_data": false}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if options.IgnoreUILayout || options.IgnorePinData {
+		t.Errorf("Expected the UI layout and pinned data to be compared, got %+v", options)
+	}
+
+	if _, err := functionNormalizeOptions([]map[string]bool{{"ignore_layout": true}}); err == nil {
+		t.Errorf("Expected error for an unsupported option")
+	}
+	if _, err := functionNormalizeOptions([]map[string]bool{{}, {}}); err == nil {
+		t.Errorf("Expected error for more than one options object")
+	}
+}
+
+// normalizeOptionsArgument builds the variadic options argument of a function
+// call.
+func normalizeOptionsArgument(options ...map[string]bool) attr.Value {
+	elementTypes := make([]attr.Type, len(options))
+	elements := make([]attr.Value, len(options))
+	for i, option := range options {
+		values := make(map[string]attr.Value, len(option))
+		for name, value := range option {
+			values[name] = types.BoolValue(value)
+		}
+		elementTypes[i] = types.MapType{ElemType: types.BoolType}
+		elements[i] = types.MapValueMust(types.BoolType, values)
+	}
+	return types.TupleValueMust(elementTypes, elements)
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &normalizeWorkflowFunction{}
+
+// NewNormalizeWorkflowFunction is a helper function to simplify the provider implementation.
+func NewNormalizeWorkflowFunction() function.Function {
+	return &normalizeWorkflowFunction{}
+}
+
+// normalizeWorkflowFunction is the function implementation.
+type normalizeWorkflowFunction struct{}
+
+// Metadata returns the function name.
+func (f *normalizeWorkflowFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_workflow"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *normalizeWorkflowFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalize a workflow definition",
+		MarkdownDescription: "Returns the canonical form of a workflow JSON document for use in comparisons, hashes and " +
+			"`for_each` keys. Object keys are sorted, numbers are rendered in their shortest form and nodes are ordered by name. " +
+			"Server-managed fields, fields holding n8n's defaults and static data are removed, " +
+			"and so are node positions and sticky notes unless `ignore_ui_layout` is false, and pinned test data unless `ignore_pin_data` is false.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "workflow",
+				Description: "The workflow JSON document, e.g. as exported from the n8n editor.",
+			},
+		},
+		VariadicParameter: normalizeOptionsParameter(),
+		Return:            function.StringReturn{},
+	}
+}
+
+// Run normalizes the workflow definition.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (f *normalizeWorkflowFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var workflow string
+	var arguments []map[string]bool
+	resp.Error = req.Arguments.Get(ctx, &workflow, &arguments)
+	if resp.Error != nil {
+		return
+	}
+
+	options, err := functionNormalizeOptions(arguments)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	normalized, err := models.NormalizeWorkflowDefinition([]byte(workflow), options)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, string(normalized))
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeWorkflowFunctionMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadataResponse := &function.MetadataResponse{}
+	NewNormalizeWorkflowFunction().Metadata(context.Background(), function.MetadataRequest{}, metadataResponse)
+
+	if metadataResponse.Name != "normalize_workflow" {
+		t.Errorf("Expected Name to be 'normalize_workflow', got '%s'", metadataResponse.Name)
+	}
+}
+
+func TestNormalizeWorkflowFunctionRun(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		workflow string
+		options  []map[string]bool
+		expected string
+		wantErr  bool
+	}{
+		{
+			name: "normalizes",
+			workflow: `{"id":"1","name":"Sync","connections":{},"pinData":{"Start":[]},"nodes":[
+				{"name":"Start","type":"n8n-nodes-base.manualTrigger","position":[240,300],"typeVersion":1.0,"disabled":false},
+				{"name":"Note","type":"n8n-nodes-base.stickyNote","position":[0,0]}
+			]}`,
+			expected: `{"connections":{},"name":"Sync","nodes":[{"name":"Start","type":"n8n-nodes-base.manualTrigger","typeVersion":1}]}`,
+		},
+		{
+			name:     "keeps the UI layout",
+			workflow: `{"name":"Sync","connections":{},"nodes":[{"name":"Start","type":"n8n-nodes-base.manualTrigger","position":[240,300]}]}`,
+			options:  []map[string]bool{{"ignore_ui_layout": false}},
+			expected: `{"connections":{},"name":"Sync","nodes":[{"name":"Start","position":[240,300],"type":"n8n-nodes-base.manualTrigger"}]}`,
+		},
+		{
+			name:     "keeps pinned data",
+			workflow: `{"name":"Sync","connections":{},"pinData":{"Start":[{"json":{"id":1}}]},"nodes":[]}`,
+			options:  []map[string]bool{{"ignore_pin_data": false}},
+			expected: `{"connections":{},"name":"Sync","nodes":[],"pinData":{"Start":[{"json":{"id":1}}]}}`,
+		},
+		{
+			name:     "unsupported option",
+			workflow: `{"nodes":[]}`,
+			options:  []map[string]bool{{"ignore_everything": true}},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid JSON",
+			workflow: `{"nodes":`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tc.workflow), normalizeOptionsArgument(tc.options...)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewNormalizeWorkflowFunction().Run(context.Background(), req, resp)
+
+			if tc.wantErr {
+				if resp.Error == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("Unexpected error: %s", resp.Error)
+			}
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(tc.expected)) {
+				t.Errorf("Expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
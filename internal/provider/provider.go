@@ -1,21 +1,36 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// httpTranscriptEnvVar names the environment variable holding the path of a
+// file to which the sanitized HTTP transcript is written, e.g. for a bug report.
+const httpTranscriptEnvVar = "N8N_PROVIDER_HTTP_TRANSCRIPT"
+
 // Ensure the implementation satisfies the expected interfaces
 var (
-	_ provider.Provider = &n8nProvider{}
+	_ provider.Provider              = &n8nProvider{}
+	_ provider.ProviderWithFunctions = &n8nProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -37,9 +52,35 @@ type n8nProvider struct {
 
 // n8nProviderModel maps provider schema data to a Go type.
 type n8nProviderModel struct {
-	Host     types.String `tfsdk:"host"`
-	APIKey   types.String `tfsdk:"api_key"`
-	Insecure types.Bool   `tfsdk:"insecure"`
+	Host          types.String `tfsdk:"host"`
+	APIKey        types.String `tfsdk:"api_key"`
+	APIKeyFile    types.String `tfsdk:"api_key_file"`
+	APIKeyCommand types.List   `tfsdk:"api_key_command"`
+	Insecure      types.Bool   `tfsdk:"insecure"`
+
+	MaxRetries            types.Int64  `tfsdk:"max_retries"`
+	RetryMinWait          types.String `tfsdk:"retry_min_wait"`
+	RetryMaxWait          types.String `tfsdk:"retry_max_wait"`
+	MaxConcurrentRequests types.Int64  `tfsdk:"max_concurrent_requests"`
+	PageSize              types.Int64  `tfsdk:"page_size"`
+	ActivationTimeout     types.String `tfsdk:"activation_timeout"`
+
+	ClientCertPEM       types.String `tfsdk:"client_cert_pem"`
+	ClientKeyPEM        types.String `tfsdk:"client_key_pem"`
+	ProxyURL            types.String `tfsdk:"proxy_url"`
+	WebhookBaseURL      types.String `tfsdk:"webhook_base_url"`
+	TLSServerCertSHA256 types.String `tfsdk:"tls_server_cert_sha256"`
+	ExtraHeaders        types.Map    `tfsdk:"extra_headers"`
+	ReadOnly            types.Bool   `tfsdk:"read_only"`
+	StrictReads         types.Bool   `tfsdk:"strict_reads"`
+	CompressRequests    types.Bool   `tfsdk:"compress_requests"`
+	AppendUserAgent     types.String `tfsdk:"append_user_agent"`
+	AuditLogFile        types.String `tfsdk:"audit_log_file"`
+	SkipValidation      types.Bool   `tfsdk:"skip_validation"`
+
+	EnableInternalAPI types.Bool   `tfsdk:"enable_internal_api"`
+	Email             types.String `tfsdk:"email"`
+	Password          types.String `tfsdk:"password"`
 }
 
 // Metadata returns the provider type name.
@@ -54,17 +95,155 @@ func (p *n8nProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *
 		Description: "Interact with n8n API to manage credentials and other resources.",
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				Description: "The n8n instance host URL (e.g., https://n8n.example.com).",
-				Required:    true,
+				Description: "The n8n instance host URL (e.g., https://n8n.example.com). For n8n Cloud, use the workspace URL (e.g., https://acme.app.n8n.cloud). " +
+					"For co-located instances, a unix domain socket may be used (e.g., unix:///var/run/n8n.sock). " +
+					"May also be provided via the N8N_HOST environment variable.",
+				Optional: true,
 			},
 			"api_key": schema.StringAttribute{
-				Description: "The API key for authenticating with n8n.",
-				Required:    true,
+				Description: "The API key for authenticating with n8n. May also be provided via the N8N_API_KEY environment variable.",
+				Optional:    true,
 				Sensitive:   true,
 			},
+			"api_key_file": schema.StringAttribute{
+				Description: "Path to a file containing the API key, e.g. a mounted Kubernetes secret. Trailing newlines are trimmed. " +
+					"Conflicts with api_key.",
+				Optional: true,
+			},
+			"api_key_command": schema.ListAttribute{
+				Description: "Command and arguments executed at configure time whose standard output is the API key, " +
+					"e.g. [\"vault\", \"kv\", \"get\", \"-field=api_key\", \"secret/n8n\"]. The command is not run through a shell. " +
+					"Trailing whitespace is trimmed. Conflicts with api_key and api_key_file.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
 			"insecure": schema.BoolAttribute{
-				Description: "Allow insecure HTTPS connections. Defaults to false.",
+				Description: "Allow insecure HTTPS connections. May also be provided via the N8N_INSECURE environment variable. Defaults to false.",
+				Optional:    true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Maximum number of retries for transient failures such as 500, 502, 503 and 504 responses or reset connections, waiting with random jitter between retries. " +
+					"Rate limited (429) requests are retried after the wait requested by the Retry-After header. Operations whose requests only succeeded after retries report a warning. " +
+					"Set to 0 to disable retries. Defaults to 3.",
+				Optional: true,
+			},
+			"retry_min_wait": schema.StringAttribute{
+				Description: "Wait before the first retry, doubled for every further retry. Defaults to \"1s\".",
+				Optional:    true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"retry_max_wait": schema.StringAttribute{
+				Description: "Maximum wait between retries. Defaults to \"30s\".",
+				Optional:    true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"activation_timeout": schema.StringAttribute{
+				Description: "Maximum time to wait for a workflow to become active after activating it, including the registration of its triggers and webhooks. " +
+					"The timeouts of the n8n_workflow resource still apply. Defaults to \"2m\".",
+				Optional: true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of API requests in flight at the same time, independently of Terraform's -parallelism. " +
+					"Lower this for instances backed by SQLite, which fail under many concurrent writes. Defaults to unlimited.",
+				Optional: true,
+			},
+			"page_size": schema.Int64Attribute{
+				Description: fmt.Sprintf("Number of objects requested per page when listing credentials, workflows and other objects, between 1 and %d. "+
+					"Larger pages need fewer requests on large instances, smaller pages keep each response small. Defaults to %d.", client.MaxPageSize, client.DefaultPageSize),
+				Optional: true,
+			},
+			"client_cert_pem": schema.StringAttribute{
+				Description: "PEM encoded client certificate presented to n8n instances protected by mutual TLS. Requires client_key_pem.",
+				Optional:    true,
+			},
+			"client_key_pem": schema.StringAttribute{
+				Description: "PEM encoded private key of client_cert_pem.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"tls_server_cert_sha256": schema.StringAttribute{
+				Description: "SHA-256 fingerprint of the server certificate, in hex with or without colons. " +
+					"When set, only this certificate is accepted and the certificate chain is not verified otherwise, " +
+					"a safer alternative to insecure for self-signed deployments.",
+				Optional: true,
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of the proxy to reach n8n through, e.g. http://proxy.example.com:3128. " +
+					"Defaults to the proxy configured by the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.",
+				Optional: true,
+			},
+			"webhook_base_url": schema.StringAttribute{
+				Description: "Base URL n8n serves webhooks under, e.g. https://hooks.example.com, for instances where it differs from host: " +
+					"when n8n sets WEBHOOK_URL, or when host is a unix domain socket, whose webhook URLs otherwise start with http://localhost. " +
+					"Used for the webhook URLs the provider reports. Defaults to host.",
+				Optional: true,
+			},
+			"extra_headers": schema.MapAttribute{
+				Description: "Additional headers attached to every API request, e.g. for Cloudflare Access, WAF tokens or tenant routing. " +
+					"Headers the provider sets itself, i.e. " + strings.Join(client.ReservedHeaders, ", ") + ", cannot be overridden.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.Map{
+					reservedHeadersValidator{},
+				},
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "Refuse all API calls that would change the instance, so plans can be run safely by less privileged pipelines. " +
+					"Applies that need to create, update or delete objects fail. Defaults to false.",
+				Optional: true,
+			},
+			"strict_reads": schema.BoolAttribute{
+				Description: "Fail refreshes when an object cannot be read for any reason other than having been deleted, e.g. an expired API key or an unavailable instance, after retries. " +
+					"By default, resources keep their last known state and only log a warning, so such failures look like successful refreshes. Defaults to false.",
+				Optional: true,
+			},
+			"compress_requests": schema.BoolAttribute{
+				Description: "Gzip large request bodies, such as big workflow definitions, which speeds up applies over slow links. " +
+					"Responses are always compressed when n8n supports it. Defaults to false.",
+				Optional: true,
+			},
+			"append_user_agent": schema.StringAttribute{
+				Description: "Text appended to the User-Agent header identifying the provider, e.g. the name of the pipeline, " +
+					"so requests can be attributed in n8n's logs. The TF_APPEND_USER_AGENT environment variable is appended as well.",
+				Optional: true,
+			},
+			"audit_log_file": schema.StringAttribute{
+				Description: "Path to a file to which a JSON record is appended for every API call that changes the instance, " +
+					"one record per line, holding the timestamp, operation, resource type and ID, and outcome. " +
+					"Use it to review the changes of an apply. The file is created if it does not exist. Disabled when unset.",
+				Optional: true,
+			},
+			"skip_validation": schema.BoolAttribute{
+				Description: "Skip checking at configure time that the API is reachable and accepts the API key, " +
+					"and skip detecting the n8n version. Useful for plan-only runs without network access. Defaults to false.",
+				Optional: true,
+			},
+			"enable_internal_api": schema.BoolAttribute{
+				Description: "Allow the provider to use n8n's internal REST API (/rest) for operations the public API does not support. " +
+					"The internal API is unversioned and may break between n8n releases. Requires email and password. Defaults to false.",
+				Optional: true,
+			},
+			"email": schema.StringAttribute{
+				Description: "The email of the n8n user used for session authentication against the internal REST API. " +
+					"When no API key is configured, the provider logs in with email and password. The first request to the public API " +
+					"creates an API key valid for one hour, labeled terraform-provider-n8n followed by a suffix unique to the run, " +
+					"so concurrent runs never revoke each other's keys. Expired keys are replaced and deleted, and the expired keys of earlier runs " +
+					"are deleted when the provider is configured, unless skip_validation is set. With read_only, no key is created and credentials are read " +
+					"through the internal API instead. This also enables the internal API.",
+				Optional: true,
+			},
+			"password": schema.StringAttribute{
+				Description: "The password of the n8n user used for session authentication against the internal REST API.",
 				Optional:    true,
+				Sensitive:   true,
 			},
 		},
 	}
@@ -102,16 +281,95 @@ func (p *n8nProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		)
 	}
 
+	if config.APIKeyFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_key_file"),
+			"Unknown n8n API Key File",
+			"The provider cannot create the n8n API client as there is an unknown configuration value for the n8n API key file. "+
+				"Either apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if config.APIKeyCommand.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_key_command"),
+			"Unknown n8n API Key Command",
+			"The provider cannot create the n8n API client as there is an unknown configuration value for the n8n API key command. "+
+				"Either apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	apiKeySources := 0
+	for _, configured := range []bool{!config.APIKey.IsNull(), !config.APIKeyFile.IsNull(), !config.APIKeyCommand.IsNull()} {
+		if configured {
+			apiKeySources++
+		}
+	}
+	if apiKeySources > 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_key"),
+			"Conflicting n8n API Key Configuration",
+			"Only one of api_key, api_key_file and api_key_command may be set.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Get required values (they are required in schema, so they should be present)
-	host := config.Host.ValueString()
-	apiKey := config.APIKey.ValueString()
-
-	// Get optional insecure value
+	// Default values to environment variables, but override
+	// with Terraform configuration value if set.
+	host := os.Getenv("N8N_HOST")
+	apiKey := os.Getenv("N8N_API_KEY")
 	insecure := false
+
+	if value := os.Getenv("N8N_INSECURE"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("insecure"),
+				"Invalid N8N_INSECURE Environment Variable",
+				"The N8N_INSECURE environment variable must be a boolean such as true or false, got: "+value,
+			)
+		}
+		insecure = parsed
+	}
+
+	if !config.Host.IsNull() {
+		host = config.Host.ValueString()
+	}
+
+	if !config.APIKey.IsNull() {
+		apiKey = config.APIKey.ValueString()
+	}
+
+	if !config.APIKeyFile.IsNull() {
+		content, err := os.ReadFile(config.APIKeyFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_key_file"),
+				"Unable to Read n8n API Key File",
+				"The provider cannot read the n8n API key file: "+err.Error(),
+			)
+		}
+		apiKey = strings.TrimRight(string(content), "\r\n")
+	}
+
+	if !config.APIKeyCommand.IsNull() {
+		var command []string
+		resp.Diagnostics.Append(config.APIKeyCommand.ElementsAs(ctx, &command, false)...)
+
+		output, err := runAPIKeyCommand(ctx, command)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_key_command"),
+				"Unable to Run n8n API Key Command",
+				"The provider cannot retrieve the n8n API key from the command: "+err.Error(),
+			)
+		}
+		apiKey = output
+	}
+
 	if !config.Insecure.IsNull() {
 		insecure = config.Insecure.ValueBool()
 	}
@@ -121,20 +379,169 @@ func (p *n8nProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		resp.Diagnostics.AddAttributeError(
 			path.Root("host"),
 			"Missing n8n API Host",
-			"The provider cannot create the n8n API client as there is an empty value for the n8n API host. "+
-				"Ensure the host value is not empty.",
+			"The provider cannot create the n8n API client as there is a missing or empty value for the n8n API host. "+
+				"Set the host value in the configuration or use the N8N_HOST environment variable. "+
+				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
-	if apiKey == "" {
+	// Without an API key, the provider logs in with email and password and
+	// creates an API key for itself.
+	sessionAuth := apiKey == "" && config.Email.ValueString() != "" && config.Password.ValueString() != ""
+
+	if apiKey == "" && !sessionAuth {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_key"),
 			"Missing n8n API Key",
-			"The provider cannot create the n8n API client as there is an empty value for the n8n API key. "+
-				"Ensure the api_key value is not empty.",
+			"The provider cannot create the n8n API client as there is a missing or empty value for the n8n API key. "+
+				"Set the api_key value in the configuration or use the N8N_API_KEY environment variable, "+
+				"or set email and password to authenticate with a user session. "+
+				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
+	maxRetries := int64(client.DefaultMaxRetries)
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		maxRetries = config.MaxRetries.ValueInt64()
+	}
+
+	retryMinWait := durationValue(config.RetryMinWait, client.DefaultRetryMinWait)
+	retryMaxWait := durationValue(config.RetryMaxWait, client.DefaultRetryMaxWait)
+
+	if maxRetries < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid Max Retries",
+			"The max_retries value must not be negative.",
+		)
+	}
+
+	if retryMinWait > retryMaxWait {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_min_wait"),
+			"Invalid Retry Wait",
+			fmt.Sprintf("The retry_min_wait value (%s) must not be greater than retry_max_wait (%s).", retryMinWait, retryMaxWait),
+		)
+	}
+
+	opts := []client.Option{
+		client.WithRetry(int(maxRetries), retryMinWait, retryMaxWait),
+		client.WithUserAgent(userAgent(p.version, req.TerraformVersion, config.AppendUserAgent.ValueString())),
+		client.WithRequestHook(logRequestTiming),
+	}
+
+	if !config.MaxConcurrentRequests.IsNull() && !config.MaxConcurrentRequests.IsUnknown() {
+		maxConcurrentRequests := config.MaxConcurrentRequests.ValueInt64()
+		if maxConcurrentRequests < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid Max Concurrent Requests",
+				"The max_concurrent_requests value must be at least 1.",
+			)
+		}
+		opts = append(opts, client.WithMaxConcurrency(int(maxConcurrentRequests)))
+	}
+
+	if !config.PageSize.IsNull() && !config.PageSize.IsUnknown() {
+		pageSize := config.PageSize.ValueInt64()
+		if pageSize < 1 || pageSize > client.MaxPageSize {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("page_size"),
+				"Invalid Page Size",
+				fmt.Sprintf("The page_size value must be between 1 and %d.", client.MaxPageSize),
+			)
+		} else {
+			opts = append(opts, client.WithPageSize(int(pageSize)))
+		}
+	}
+
+	if !config.ActivationTimeout.IsNull() && !config.ActivationTimeout.IsUnknown() {
+		opts = append(opts, client.WithOperationTimeout(client.OperationActivateWorkflow, durationValue(config.ActivationTimeout, 0)))
+	}
+
+	if !config.ClientCertPEM.IsNull() || !config.ClientKeyPEM.IsNull() {
+		certPEM := config.ClientCertPEM.ValueString()
+		keyPEM := config.ClientKeyPEM.ValueString()
+
+		if certPEM == "" || keyPEM == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("client_cert_pem"),
+				"Incomplete n8n Client Certificate",
+				"Both client_cert_pem and client_key_pem must be set to use mutual TLS.",
+			)
+		}
+
+		opts = append(opts, client.WithClientCertificate(certPEM, keyPEM))
+	}
+
+	if !config.TLSServerCertSHA256.IsNull() && !config.TLSServerCertSHA256.IsUnknown() {
+		opts = append(opts, client.WithServerCertificateSHA256(config.TLSServerCertSHA256.ValueString()))
+	}
+
+	if !config.ProxyURL.IsNull() && !config.ProxyURL.IsUnknown() {
+		opts = append(opts, client.WithProxy(config.ProxyURL.ValueString()))
+	}
+
+	if !config.WebhookBaseURL.IsNull() && !config.WebhookBaseURL.IsUnknown() {
+		opts = append(opts, client.WithWebhookBaseURL(config.WebhookBaseURL.ValueString()))
+	}
+
+	if !config.ExtraHeaders.IsNull() && !config.ExtraHeaders.IsUnknown() {
+		headers := make(map[string]string, len(config.ExtraHeaders.Elements()))
+		resp.Diagnostics.Append(config.ExtraHeaders.ElementsAs(ctx, &headers, false)...)
+		opts = append(opts, client.WithHeaders(headers))
+	}
+
+	if config.ReadOnly.ValueBool() {
+		opts = append(opts, client.WithReadOnly())
+	}
+
+	if config.StrictReads.ValueBool() {
+		opts = append(opts, client.WithStrictReads())
+	}
+
+	if config.CompressRequests.ValueBool() {
+		opts = append(opts, client.WithRequestCompression())
+	}
+
+	if !config.AuditLogFile.IsNull() && !config.AuditLogFile.IsUnknown() {
+		opts = append(opts, client.WithAuditLog(config.AuditLogFile.ValueString()))
+	}
+
+	// The transcript is only enabled through the environment, so it can be
+	// captured for a bug report without changing the configuration.
+	if transcriptPath := os.Getenv(httpTranscriptEnvVar); transcriptPath != "" {
+		tflog.Warn(ctx, "Writing HTTP transcript", map[string]any{"path": transcriptPath})
+		opts = append(opts, client.WithTranscript(transcriptPath))
+	}
+
+	if sessionAuth {
+		opts = append(opts, client.WithSessionAuth(config.Email.ValueString(), config.Password.ValueString()))
+	} else if config.EnableInternalAPI.ValueBool() {
+		email := config.Email.ValueString()
+		password := config.Password.ValueString()
+
+		if email == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("email"),
+				"Missing n8n User Email",
+				"The provider requires an email to use the n8n internal API. "+
+					"Set the email value or disable enable_internal_api.",
+			)
+		}
+
+		if password == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password"),
+				"Missing n8n User Password",
+				"The provider requires a password to use the n8n internal API. "+
+					"Set the password value or disable enable_internal_api.",
+			)
+		}
+
+		opts = append(opts, client.WithInternalAPI(email, password))
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -146,7 +553,7 @@ func (p *n8nProvider) Configure(ctx context.Context, req provider.ConfigureReque
 	tflog.Debug(ctx, "Creating n8n client")
 
 	// Create a new n8n client using the configuration values
-	n8nClient, err := client.NewClient(&host, &apiKey, &insecure)
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, opts...)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create n8n API Client",
@@ -157,6 +564,35 @@ func (p *n8nProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		return
 	}
 
+	if !config.SkipValidation.ValueBool() {
+		if err := n8nClient.Ping(ctx); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Connect to n8n",
+				fmt.Sprintf("The provider could not use the n8n API at %s: %s\n\n"+
+					"Check the host and api_key values, or set skip_validation to true to skip this check.", n8nClient.Host, errorDetail(err)),
+			)
+			return
+		}
+
+		// Resources fall back to the oldest supported behavior when the version
+		// cannot be detected, e.g. when /rest is blocked by a reverse proxy.
+		version, err := n8nClient.DetectVersion(ctx)
+		if err != nil {
+			tflog.Warn(ctx, "Could not detect n8n version", map[string]any{"error": err.Error()})
+		} else {
+			tflog.Info(ctx, "Detected n8n version", map[string]any{"version": version.String()})
+		}
+
+		// Keys created for session authentication are not deleted when a run
+		// ends, so those of earlier runs are deleted once they expired.
+		deleted, err := n8nClient.DeleteStaleSessionAPIKeys(ctx)
+		if err != nil {
+			tflog.Warn(ctx, "Could not delete expired session API keys", map[string]any{"error": err.Error()})
+		} else if deleted > 0 {
+			tflog.Info(ctx, "Deleted expired session API keys", map[string]any{"count": deleted})
+		}
+	}
+
 	// Make the n8n client available during DataSource and Resource
 	// type Configure methods.
 	resp.ResourceData = n8nClient
@@ -165,16 +601,69 @@ func (p *n8nProvider) Configure(ctx context.Context, req provider.ConfigureReque
 	tflog.Info(ctx, "Configured n8n client", map[string]any{"success": true})
 }
 
+// runAPIKeyCommand executes the command and returns its trimmed standard output.
+func runAPIKeyCommand(ctx context.Context, command []string) (string, error) {
+	if len(command) == 0 || command[0] == "" {
+		return "", fmt.Errorf("the command must not be empty")
+	}
+
+	var stdout, stderr bytes.Buffer
+	//nolint:gosec // G204: Running a user-configured command is the purpose of api_key_command
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("%w: %s", err, message)
+		}
+		return "", err
+	}
+
+	return strings.TrimRightFunc(stdout.String(), unicode.IsSpace), nil
+}
+
+// durationValue parses a duration attribute, falling back to defaultValue when
+// the attribute is not set. Invalid values are rejected by durationValidator.
+func durationValue(value types.String, defaultValue time.Duration) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		return defaultValue
+	}
+	return duration
+}
+
 // Resources defines the provider resources.
 func (p *n8nProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewCredentialResource,
+		NewWorkflowResource,
 	}
 }
 
 // DataSources defines the provider data sources.
 func (p *n8nProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
-		// NewCredentialDataSource,
+		NewWorkflowBackupDataSource,
+		NewWorkflowExportDataSource,
+		NewWebhookCollisionsDataSource,
+		NewDanglingCredentialsDataSource,
+		NewInstanceFeaturesDataSource,
+	}
+}
+
+// Functions defines the provider functions.
+func (p *n8nProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewNormalizeWorkflowFunction,
+		NewInjectCredentialsFunction,
+		NewRenderWorkflowFunction,
+		NewWorkflowEqualFunction,
+		NewValidateWorkflowFunction,
+		NewExpressionFunction,
 	}
 }
@@ -0,0 +1,366 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProviderConfigureEnvironmentFallback(t *testing.T) {
+	t.Setenv("N8N_HOST", "https://env.example.com")
+	t.Setenv("N8N_API_KEY", "env-api-key")
+	t.Setenv("N8N_INSECURE", "true")
+
+	resp := configureProvider(t, map[string]tftypes.Value{})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", resp.Diagnostics)
+	}
+
+	n8nClient, ok := resp.ResourceData.(*client.Client)
+	if !ok {
+		t.Fatalf("Expected *client.Client, got %T", resp.ResourceData)
+	}
+	if n8nClient.Host != "https://env.example.com" || n8nClient.APIKey != "env-api-key" || !n8nClient.Insecure {
+		t.Errorf("Expected client to be configured from the environment, got %+v", n8nClient)
+	}
+}
+
+func TestProviderConfigureOverridesEnvironment(t *testing.T) {
+	t.Setenv("N8N_HOST", "https://env.example.com")
+	t.Setenv("N8N_API_KEY", "env-api-key")
+	t.Setenv("N8N_INSECURE", "true")
+
+	resp := configureProvider(t, map[string]tftypes.Value{
+		"host":     tftypes.NewValue(tftypes.String, "https://config.example.com"),
+		"insecure": tftypes.NewValue(tftypes.Bool, false),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", resp.Diagnostics)
+	}
+
+	n8nClient, ok := resp.ResourceData.(*client.Client)
+	if !ok {
+		t.Fatalf("Expected *client.Client, got %T", resp.ResourceData)
+	}
+	if n8nClient.Host != "https://config.example.com" || n8nClient.APIKey != "env-api-key" || n8nClient.Insecure {
+		t.Errorf("Expected configuration to take precedence, got %+v", n8nClient)
+	}
+}
+
+func TestProviderConfigureMissingValues(t *testing.T) {
+	t.Setenv("N8N_HOST", "")
+	t.Setenv("N8N_API_KEY", "")
+	t.Setenv("N8N_INSECURE", "not-a-bool")
+
+	resp := configureProvider(t, map[string]tftypes.Value{})
+	if resp.Diagnostics.ErrorsCount() != 3 {
+		t.Errorf("Expected 3 errors, got diagnostics: %+v", resp.Diagnostics)
+	}
+}
+
+func TestProviderConfigureAPIKeyFile(t *testing.T) {
+	t.Setenv("N8N_HOST", "https://env.example.com")
+	t.Setenv("N8N_API_KEY", "env-api-key")
+
+	apiKeyFile := filepath.Join(t.TempDir(), "api-key")
+	if err := os.WriteFile(apiKeyFile, []byte("file-api-key\n"), 0o600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	resp := configureProvider(t, map[string]tftypes.Value{
+		"api_key_file": tftypes.NewValue(tftypes.String, apiKeyFile),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", resp.Diagnostics)
+	}
+
+	n8nClient, ok := resp.ResourceData.(*client.Client)
+	if !ok {
+		t.Fatalf("Expected *client.Client, got %T", resp.ResourceData)
+	}
+	if n8nClient.APIKey != "file-api-key" {
+		t.Errorf("Expected API key to be read from file with the newline trimmed, got %q", n8nClient.APIKey)
+	}
+
+	resp = configureProvider(t, map[string]tftypes.Value{
+		"api_key":      tftypes.NewValue(tftypes.String, "config-api-key"),
+		"api_key_file": tftypes.NewValue(tftypes.String, apiKeyFile),
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("Expected error when both api_key and api_key_file are set")
+	}
+
+	resp = configureProvider(t, map[string]tftypes.Value{
+		"api_key_file": tftypes.NewValue(tftypes.String, filepath.Join(t.TempDir(), "missing")),
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("Expected error for a missing api_key_file")
+	}
+}
+
+func TestProviderConfigureRetryPolicy(t *testing.T) {
+	t.Setenv("N8N_HOST", "https://env.example.com")
+	t.Setenv("N8N_API_KEY", "env-api-key")
+
+	resp := configureProvider(t, map[string]tftypes.Value{
+		"max_retries":    tftypes.NewValue(tftypes.Number, 5),
+		"retry_min_wait": tftypes.NewValue(tftypes.String, "2s"),
+		"retry_max_wait": tftypes.NewValue(tftypes.String, "1m"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", resp.Diagnostics)
+	}
+
+	resp = configureProvider(t, map[string]tftypes.Value{
+		"max_retries":    tftypes.NewValue(tftypes.Number, -1),
+		"retry_min_wait": tftypes.NewValue(tftypes.String, "1m"),
+		"retry_max_wait": tftypes.NewValue(tftypes.String, "1s"),
+	})
+	if resp.Diagnostics.ErrorsCount() != 2 {
+		t.Errorf("Expected 2 errors, got diagnostics: %+v", resp.Diagnostics)
+	}
+}
+
+func TestProviderConfigureActivationTimeout(t *testing.T) {
+	t.Setenv("N8N_HOST", "https://env.example.com")
+	t.Setenv("N8N_API_KEY", "env-api-key")
+
+	resp := configureProvider(t, map[string]tftypes.Value{
+		"activation_timeout": tftypes.NewValue(tftypes.String, "10m"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", resp.Diagnostics)
+	}
+}
+
+func TestProviderConfigureWebhookBaseURL(t *testing.T) {
+	t.Setenv("N8N_HOST", "https://env.example.com")
+	t.Setenv("N8N_API_KEY", "env-api-key")
+
+	resp := configureProvider(t, map[string]tftypes.Value{
+		"webhook_base_url": tftypes.NewValue(tftypes.String, "https://hooks.example.com/"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", resp.Diagnostics)
+	}
+	if got := resp.ResourceData.(*client.Client).WebhookBaseURL(); got != "https://hooks.example.com" {
+		t.Errorf("Expected the configured webhook base URL, got %q", got)
+	}
+}
+
+func TestProviderConfigureMaxConcurrentRequests(t *testing.T) {
+	t.Setenv("N8N_HOST", "https://env.example.com")
+	t.Setenv("N8N_API_KEY", "env-api-key")
+
+	resp := configureProvider(t, map[string]tftypes.Value{
+		"max_concurrent_requests": tftypes.NewValue(tftypes.Number, 2),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", resp.Diagnostics)
+	}
+
+	resp = configureProvider(t, map[string]tftypes.Value{
+		"max_concurrent_requests": tftypes.NewValue(tftypes.Number, 0),
+	})
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Errorf("Expected 1 error, got diagnostics: %+v", resp.Diagnostics)
+	}
+}
+
+func TestProviderConfigurePageSize(t *testing.T) {
+	t.Setenv("N8N_HOST", "https://env.example.com")
+	t.Setenv("N8N_API_KEY", "env-api-key")
+
+	resp := configureProvider(t, map[string]tftypes.Value{
+		"page_size": tftypes.NewValue(tftypes.Number, 250),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", resp.Diagnostics)
+	}
+
+	resp = configureProvider(t, map[string]tftypes.Value{
+		"page_size": tftypes.NewValue(tftypes.Number, 500),
+	})
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Errorf("Expected 1 error, got diagnostics: %+v", resp.Diagnostics)
+	}
+}
+
+func TestProviderConfigureAuditLogFile(t *testing.T) {
+	t.Setenv("N8N_HOST", "https://env.example.com")
+	t.Setenv("N8N_API_KEY", "env-api-key")
+
+	resp := configureProvider(t, map[string]tftypes.Value{
+		"audit_log_file": tftypes.NewValue(tftypes.String, filepath.Join(t.TempDir(), "audit.log")),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", resp.Diagnostics)
+	}
+
+	resp = configureProvider(t, map[string]tftypes.Value{
+		"audit_log_file": tftypes.NewValue(tftypes.String, filepath.Join(t.TempDir(), "missing", "audit.log")),
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("Expected error for an audit log in a missing directory")
+	}
+}
+
+func TestProviderConfigureIncompleteClientCertificate(t *testing.T) {
+	t.Setenv("N8N_HOST", "https://env.example.com")
+	t.Setenv("N8N_API_KEY", "env-api-key")
+
+	resp := configureProvider(t, map[string]tftypes.Value{
+		"client_cert_pem": tftypes.NewValue(tftypes.String, "certificate"),
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("Expected error when client_key_pem is missing")
+	}
+}
+
+func TestProviderConfigureSessionAuth(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /rest/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "n8n-auth", Value: "session", Path: "/"})
+	})
+	mux.HandleFunc("/rest/api-keys", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no API key requests while configuring, got %s %s", r.Method, r.URL.Path)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Setenv("N8N_HOST", server.URL)
+	t.Setenv("N8N_API_KEY", "")
+
+	resp := configureProvider(t, map[string]tftypes.Value{
+		"email":    tftypes.NewValue(tftypes.String, "owner@example.com"),
+		"password": tftypes.NewValue(tftypes.String, "secret"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", resp.Diagnostics)
+	}
+
+	n8nClient, ok := resp.ResourceData.(*client.Client)
+	if !ok {
+		t.Fatalf("Expected *client.Client, got %T", resp.ResourceData)
+	}
+	if !n8nClient.InternalAPIEnabled() || n8nClient.APIKey != "" {
+		t.Errorf("Expected session authentication without an API key, got %q", n8nClient.APIKey)
+	}
+}
+
+func TestProviderConfigureAPIKeyCommand(t *testing.T) {
+	t.Setenv("N8N_HOST", "https://env.example.com")
+	t.Setenv("N8N_API_KEY", "")
+
+	resp := configureProvider(t, map[string]tftypes.Value{
+		"api_key_command": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "echo"),
+			tftypes.NewValue(tftypes.String, "command-api-key"),
+		}),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", resp.Diagnostics)
+	}
+
+	n8nClient, ok := resp.ResourceData.(*client.Client)
+	if !ok {
+		t.Fatalf("Expected *client.Client, got %T", resp.ResourceData)
+	}
+	if n8nClient.APIKey != "command-api-key" {
+		t.Errorf("Expected API key from the command output, got %q", n8nClient.APIKey)
+	}
+}
+
+func TestRunAPIKeyCommand(t *testing.T) {
+	if _, err := runAPIKeyCommand(context.Background(), nil); err == nil {
+		t.Errorf("Expected error for empty command")
+	}
+	if _, err := runAPIKeyCommand(context.Background(), []string{"false"}); err == nil {
+		t.Errorf("Expected error for failing command")
+	}
+}
+
+func TestProviderConfigureValidation(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/credentials", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-N8N-API-KEY") != "valid-api-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Setenv("N8N_HOST", server.URL)
+	t.Setenv("N8N_API_KEY", "valid-api-key")
+
+	resp := configureProvider(t, map[string]tftypes.Value{
+		"skip_validation": tftypes.NewValue(tftypes.Bool, false),
+		"max_retries":     tftypes.NewValue(tftypes.Number, 0),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", resp.Diagnostics)
+	}
+
+	t.Setenv("N8N_API_KEY", "invalid-api-key")
+
+	resp = configureProvider(t, map[string]tftypes.Value{
+		"skip_validation": tftypes.NewValue(tftypes.Bool, false),
+		"max_retries":     tftypes.NewValue(tftypes.Number, 0),
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("Expected error for a rejected API key")
+	}
+	if resp.ResourceData != nil {
+		t.Errorf("Expected no client to be configured")
+	}
+}
+
+// configureProvider runs Configure with the given attributes set and every
+// other attribute null.
+func configureProvider(t *testing.T, attributes map[string]tftypes.Value) *provider.ConfigureResponse {
+	t.Helper()
+
+	ctx := context.Background()
+	p := New("test")()
+
+	schemaResponse := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResponse)
+
+	objectType, ok := schemaResponse.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("Expected schema to be an object type")
+	}
+
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	// Tests talk to hosts that don't exist unless they opt in to validation.
+	values["skip_validation"] = tftypes.NewValue(tftypes.Bool, true)
+	for name, value := range attributes {
+		values[name] = value
+	}
+
+	req := provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResponse.Schema,
+			Raw:    tftypes.NewValue(objectType, values),
+		},
+	}
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, req, resp)
+
+	return resp
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &renderWorkflowFunction{}
+
+// NewRenderWorkflowFunction is a helper function to simplify the provider implementation.
+func NewRenderWorkflowFunction() function.Function {
+	return &renderWorkflowFunction{}
+}
+
+// renderWorkflowFunction is the function implementation.
+type renderWorkflowFunction struct{}
+
+// Metadata returns the function name.
+func (f *renderWorkflowFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "render_workflow"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *renderWorkflowFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Substitute template variables in a workflow definition",
+		MarkdownDescription: "Returns the workflow JSON document with `${name}` placeholders in its string values replaced " +
+			"by the values of `vars`, e.g. URLs, channel names or schedule expressions, so one exported workflow can serve " +
+			"several environments. Only names in `vars` are substituted, so JavaScript template literals in code nodes are " +
+			"left alone; `$${name}` renders a literal `${name}`. Terraform interpolates `${...}` in string literals, so " +
+			"read the workflow with `file()` or escape placeholders written inline as `$${name}`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "workflow",
+				Description: "The workflow JSON document, e.g. as exported from the n8n editor.",
+			},
+			function.MapParameter{
+				Name:        "vars",
+				Description: "The values to substitute, keyed by placeholder name.",
+				ElementType: types.StringType,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run substitutes the template variables of the workflow.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (f *renderWorkflowFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var workflow string
+	var vars map[string]string
+	resp.Error = req.Arguments.Get(ctx, &workflow, &vars)
+	if resp.Error != nil {
+		return
+	}
+
+	rendered, err := models.RenderWorkflowTemplate([]byte(workflow), vars)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, string(rendered))
+}
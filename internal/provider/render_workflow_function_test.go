@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRenderWorkflowFunctionMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadataResponse := &function.MetadataResponse{}
+	NewRenderWorkflowFunction().Metadata(context.Background(), function.MetadataRequest{}, metadataResponse)
+
+	if metadataResponse.Name != "render_workflow" {
+		t.Errorf("Expected Name to be 'render_workflow', got '%s'", metadataResponse.Name)
+	}
+}
+
+func TestRenderWorkflowFunctionRun(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		workflow string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "substitutes variables",
+			workflow: `{"name":"Notify","nodes":[{"name":"Slack","parameters":{"channel":"${channel}","text":"${unknown}"}}],"connections":{}}`,
+			expected: `{"connections":{},"name":"Notify","nodes":[{"name":"Slack","parameters":{"channel":"#alerts-prod","text":"${unknown}"}}]}`,
+		},
+		{
+			name:     "invalid JSON",
+			workflow: `not json`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			vars := types.MapValueMust(types.StringType, map[string]attr.Value{
+				"channel": types.StringValue("#alerts-prod"),
+			})
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tc.workflow), vars}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewRenderWorkflowFunction().Run(context.Background(), req, resp)
+
+			if tc.wantErr {
+				if resp.Error == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("Unexpected error: %s", resp.Error)
+			}
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(tc.expected)) {
+				t.Errorf("Expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
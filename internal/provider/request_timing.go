@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// logRequestTiming is a client.RequestHook logging the outcome and duration
+// of every attempt of an API request at DEBUG level, so slow endpoints can be
+// spotted in the logs of CI pipelines without tracing the full exchange.
+func logRequestTiming(ctx context.Context, req *http.Request) (context.Context, func(statusCode int, err error)) {
+	start := time.Now()
+
+	return ctx, func(statusCode int, err error) {
+		fields := map[string]interface{}{
+			"method":      req.Method,
+			"path":        req.URL.Path,
+			"status":      statusCode,
+			"duration_ms": time.Since(start).Milliseconds(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		}
+
+		tflog.Debug(ctx, "Completed n8n API request", fields)
+	}
+}
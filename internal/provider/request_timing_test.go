@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLogRequestTiming(t *testing.T) {
+	t.Parallel()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://n8n.example.com/api/v1/workflows/42", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	_, finish := logRequestTiming(ctx, req)
+	finish(http.StatusNotFound, nil)
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 log entry, got %d", len(entries))
+	}
+
+	entry := entries[0]
+	if entry["@message"] != "Completed n8n API request" || entry["method"] != "GET" || entry["path"] != "/api/v1/workflows/42" {
+		t.Errorf("Unexpected log entry: %v", entry)
+	}
+	if status, ok := entry["status"].(float64); !ok || status != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %v", http.StatusNotFound, entry["status"])
+	}
+	if _, ok := entry["duration_ms"].(float64); !ok {
+		t.Errorf("Expected the duration to be logged, got %v", entry)
+	}
+	if _, ok := entry["error"]; ok {
+		t.Errorf("Expected no error to be logged, got %v", entry["error"])
+	}
+}
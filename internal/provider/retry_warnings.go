@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// withRetryWarning returns a context recording the retries of the API calls
+// made with it, and a function adding a warning that summarizes them to the
+// diagnostics. Operations defer the function, so operators learn that the
+// instance is rate limiting or struggling before applies start to fail.
+func withRetryWarning(ctx context.Context) (context.Context, func(*diag.Diagnostics)) {
+	ctx, report := client.ContextWithRetryReport(ctx)
+	return ctx, func(diags *diag.Diagnostics) {
+		if report.Retries() == 0 {
+			return
+		}
+		diags.AddWarning(
+			"n8n API Requests Were Retried",
+			report.Summary()+". The n8n instance may be under pressure; "+
+				"consider lowering max_concurrent_requests or the -parallelism of Terraform, or scaling the instance.",
+		)
+	}
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestWithRetryWarning(t *testing.T) {
+	t.Parallel()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	host, apiKey, insecure := server.URL, "test-api-key", false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(1, time.Millisecond, time.Millisecond))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var diags diag.Diagnostics
+	ctx, warnRetries := withRetryWarning(context.Background())
+	if _, err := n8nClient.ListCredentials(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	warnRetries(&diags)
+
+	if diags.WarningsCount() != 1 || diags.HasError() {
+		t.Fatalf("Expected a single warning, got %+v", diags)
+	}
+	if detail := diags[0].Detail(); !strings.Contains(detail, "1 API call succeeded after 1 retry") || !strings.Contains(detail, "status 502") {
+		t.Errorf("Unexpected detail: %q", detail)
+	}
+
+	diags = nil
+	_, warnRetries = withRetryWarning(context.Background())
+	warnRetries(&diags)
+	if len(diags) != 0 {
+		t.Errorf("Expected no warning without retries, got %+v", diags)
+	}
+}
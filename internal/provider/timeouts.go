@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Timeout operation names used as attribute names in the timeouts block.
+const (
+	timeoutCreate = "create"
+	timeoutUpdate = "update"
+	timeoutDelete = "delete"
+)
+
+// timeoutsModel represents the timeouts block.
+type timeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// timeoutsBlock returns the schema for the timeouts block shared by resources.
+func timeoutsBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		Description: "Timeouts for resource operations. Values are duration strings such as \"30s\" or \"5m\".",
+		Attributes: map[string]schema.Attribute{
+			timeoutCreate: schema.StringAttribute{
+				Description: "Timeout for creating the resource, including retries. Not bounded when unset.",
+				Optional:    true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			timeoutUpdate: schema.StringAttribute{
+				Description: "Timeout for updating the resource, including retries. Not bounded when unset.",
+				Optional:    true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			timeoutDelete: schema.StringAttribute{
+				Description: "Timeout for deleting the resource, including retries. Not bounded when unset.",
+				Optional:    true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+		},
+	}
+}
+
+// resolveTimeout returns the configured timeout for the given operation, or
+// defaultTimeout when the timeouts block or the attribute is not set.
+func resolveTimeout(ctx context.Context, timeouts types.Object, operation string, defaultTimeout time.Duration) (time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if timeouts.IsNull() || timeouts.IsUnknown() {
+		return defaultTimeout, diags
+	}
+
+	var model timeoutsModel
+	diags.Append(timeouts.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return defaultTimeout, diags
+	}
+
+	var value types.String
+	switch operation {
+	case timeoutCreate:
+		value = model.Create
+	case timeoutUpdate:
+		value = model.Update
+	case timeoutDelete:
+		value = model.Delete
+	}
+
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return defaultTimeout, diags
+	}
+
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("timeouts").AtName(operation),
+			"Invalid Timeout",
+			fmt.Sprintf("Could not parse %s timeout %q: %s", operation, value.ValueString(), err.Error()),
+		)
+		return defaultTimeout, diags
+	}
+
+	return timeout, diags
+}
+
+// withOperationTimeout bounds ctx by the timeout configured for the operation.
+// Without one, the operation is not bounded as a whole, since it may send many
+// requests, retries included; the client still times out each request that
+// has no deadline after client.DefaultTimeout.
+func withOperationTimeout(ctx context.Context, timeouts types.Object, operation string) (context.Context, context.CancelFunc, diag.Diagnostics) {
+	timeout, diags := resolveTimeout(ctx, timeouts, operation, 0)
+	if diags.HasError() || timeout == 0 {
+		return ctx, func() {}, diags
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, diags
+}
+
+// durationValidator validates that a string is a valid Go duration.
+type durationValidator struct{}
+
+// Description returns a human-readable description of the validator.
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a valid duration string such as \"30s\" or \"5m\""
+}
+
+// MarkdownDescription returns a markdown formatted human-readable description of the validator.
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements the validation logic.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (v durationValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	duration, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("The value %q is not a valid duration: %s", req.ConfigValue.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if duration <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("The value %q must be a positive duration.", req.ConfigValue.ValueString()),
+		)
+	}
+}
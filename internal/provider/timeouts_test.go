@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResolveTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	attrTypes := map[string]attr.Type{
+		"create": types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	}
+
+	tests := []struct {
+		name      string
+		timeouts  types.Object
+		operation string
+		want      time.Duration
+		wantError bool
+	}{
+		{
+			name:      "null block uses default",
+			timeouts:  types.ObjectNull(attrTypes),
+			operation: timeoutCreate,
+			want:      time.Minute,
+		},
+		{
+			name: "unset attribute uses default",
+			timeouts: types.ObjectValueMust(attrTypes, map[string]attr.Value{
+				"create": types.StringValue("10m"),
+				"update": types.StringNull(),
+				"delete": types.StringNull(),
+			}),
+			operation: timeoutDelete,
+			want:      time.Minute,
+		},
+		{
+			name: "configured attribute",
+			timeouts: types.ObjectValueMust(attrTypes, map[string]attr.Value{
+				"create": types.StringValue("10m"),
+				"update": types.StringNull(),
+				"delete": types.StringNull(),
+			}),
+			operation: timeoutCreate,
+			want:      10 * time.Minute,
+		},
+		{
+			name: "invalid duration",
+			timeouts: types.ObjectValueMust(attrTypes, map[string]attr.Value{
+				"create": types.StringNull(),
+				"update": types.StringValue("soon"),
+				"delete": types.StringNull(),
+			}),
+			operation: timeoutUpdate,
+			want:      time.Minute,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, diags := resolveTimeout(ctx, tt.timeouts, tt.operation, time.Minute)
+			if diags.HasError() != tt.wantError {
+				t.Fatalf("Expected error %v, got diagnostics: %+v", tt.wantError, diags)
+			}
+			if got != tt.want {
+				t.Errorf("Expected timeout %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDurationValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"30s":   false,
+		"1h30m": false,
+		"0s":    true,
+		"-5m":   true,
+		"five":  true,
+	}
+
+	for value, wantError := range tests {
+		req := validator.StringRequest{
+			Path:        path.Root("timeouts").AtName("create"),
+			ConfigValue: types.StringValue(value),
+		}
+		resp := &validator.StringResponse{}
+
+		durationValidator{}.ValidateString(context.Background(), req, resp)
+
+		if resp.Diagnostics.HasError() != wantError {
+			t.Errorf("Value %q: expected error %v, got diagnostics: %+v", value, wantError, resp.Diagnostics)
+		}
+	}
+}
+
+func TestWithOperationTimeout(t *testing.T) {
+	t.Parallel()
+
+	attrTypes := map[string]attr.Type{
+		"create": types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	}
+	timeouts := types.ObjectValueMust(attrTypes, map[string]attr.Value{
+		"create": types.StringValue("10m"),
+		"update": types.StringNull(),
+		"delete": types.StringNull(),
+	})
+
+	ctx, cancel, diags := withOperationTimeout(context.Background(), timeouts, timeoutCreate)
+	defer cancel()
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %+v", diags)
+	}
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > 10*time.Minute {
+		t.Errorf("Expected a deadline within 10m, got %v (%t)", deadline, ok)
+	}
+
+	// Without a configured timeout, only the requests are bounded, by the client.
+	for _, value := range []types.Object{timeouts, types.ObjectNull(attrTypes)} {
+		ctx, cancel, diags := withOperationTimeout(context.Background(), value, timeoutDelete)
+		defer cancel()
+		if diags.HasError() {
+			t.Fatalf("Unexpected diagnostics: %+v", diags)
+		}
+		if _, ok := ctx.Deadline(); ok {
+			t.Errorf("Expected no deadline without a delete timeout")
+		}
+	}
+}
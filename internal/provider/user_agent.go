@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"strings"
+)
+
+// frameworkModule is the module path of the Terraform plugin framework.
+const frameworkModule = "github.com/hashicorp/terraform-plugin-framework"
+
+// userAgent builds the User-Agent sent to n8n, identifying the provider, the
+// Terraform version and the plugin framework version. The value of the
+// TF_APPEND_USER_AGENT environment variable and appendUserAgent are added at
+// the end.
+func userAgent(providerVersion, terraformVersion, appendUserAgent string) string {
+	parts := []string{
+		fmt.Sprintf("terraform-provider-n8n/%s (+https://registry.terraform.io/providers/artus-engineering/n8n)", providerVersion),
+	}
+	if terraformVersion != "" {
+		parts = append(parts, "Terraform/"+terraformVersion)
+	}
+	if version := frameworkVersion(); version != "" {
+		parts = append(parts, "terraform-plugin-framework/"+version)
+	}
+
+	for _, extra := range []string{os.Getenv("TF_APPEND_USER_AGENT"), appendUserAgent} {
+		if extra = strings.TrimSpace(extra); extra != "" {
+			parts = append(parts, extra)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// frameworkVersion returns the version of the plugin framework the provider
+// was built with, or an empty string when it is unknown.
+func frameworkVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path == frameworkModule {
+			return strings.TrimPrefix(dep.Version, "v")
+		}
+	}
+	return ""
+}
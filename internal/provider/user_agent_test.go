@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUserAgent(t *testing.T) {
+	t.Setenv("TF_APPEND_USER_AGENT", "ci/42")
+
+	got := userAgent("1.2.3", "1.9.0", " pipeline/deploy ")
+
+	if !strings.HasPrefix(got, "terraform-provider-n8n/1.2.3 (+https://registry.terraform.io/providers/artus-engineering/n8n) Terraform/1.9.0") {
+		t.Errorf("Unexpected user agent prefix: %s", got)
+	}
+	if !strings.HasSuffix(got, " ci/42 pipeline/deploy") {
+		t.Errorf("Expected appended user agents at the end, got: %s", got)
+	}
+
+	t.Setenv("TF_APPEND_USER_AGENT", "")
+	if got := userAgent("dev", "", ""); strings.Contains(got, "Terraform/") {
+		t.Errorf("Expected no Terraform version when unknown, got: %s", got)
+	}
+}
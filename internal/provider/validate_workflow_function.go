@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &validateWorkflowFunction{}
+
+// NewValidateWorkflowFunction is a helper function to simplify the provider implementation.
+func NewValidateWorkflowFunction() function.Function {
+	return &validateWorkflowFunction{}
+}
+
+// validateWorkflowFunction is the function implementation.
+type validateWorkflowFunction struct{}
+
+// Metadata returns the function name.
+func (f *validateWorkflowFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_workflow"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *validateWorkflowFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Validate the structure of a workflow definition",
+		MarkdownDescription: "Returns the structural problems of a workflow JSON document, or an empty list when there are none: " +
+			"the document must be valid JSON with nodes, node names must be unique, a trigger node must be present and " +
+			"connections may only connect nodes of the workflow. Invalid documents do not fail the function, so it can be " +
+			"used in `validation` blocks, e.g. `length(provider::n8n::validate_workflow(var.workflow)) == 0`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "workflow",
+				Description: "The workflow JSON document, e.g. as exported from the n8n editor.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+// Run validates the workflow definition.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (f *validateWorkflowFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var workflow string
+	resp.Error = req.Arguments.Get(ctx, &workflow)
+	if resp.Error != nil {
+		return
+	}
+
+	problems := models.ValidateWorkflowDefinition([]byte(workflow))
+
+	messages := make([]string, len(problems))
+	for i, problem := range problems {
+		messages[i] = problem.Error()
+	}
+
+	resp.Error = resp.Result.Set(ctx, messages)
+}
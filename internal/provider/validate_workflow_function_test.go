@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateWorkflowFunctionMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadataResponse := &function.MetadataResponse{}
+	NewValidateWorkflowFunction().Metadata(context.Background(), function.MetadataRequest{}, metadataResponse)
+
+	if metadataResponse.Name != "validate_workflow" {
+		t.Errorf("Expected Name to be 'validate_workflow', got '%s'", metadataResponse.Name)
+	}
+}
+
+func TestValidateWorkflowFunctionRun(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		workflow string
+		expected []string
+	}{
+		{
+			name:     "valid",
+			workflow: `{"nodes":[{"name":"Start","type":"n8n-nodes-base.manualTrigger"}],"connections":{}}`,
+			expected: []string{},
+		},
+		{
+			name: "invalid",
+			workflow: `{"nodes":[{"name":"Set","type":"n8n-nodes-base.set"}],
+				"connections":{"Set":{"main":[[{"node":"Missing","type":"main","index":0}]]}}}`,
+			expected: []string{
+				"workflow definition has no trigger node",
+				`node "Set" is connected to unknown node "Missing"`,
+			},
+		},
+		{
+			name:     "invalid JSON",
+			workflow: `{`,
+			expected: []string{"error parsing workflow definition: unexpected end of JSON input"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tc.workflow)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ListUnknown(types.StringType)),
+			}
+
+			NewValidateWorkflowFunction().Run(context.Background(), req, resp)
+
+			if resp.Error != nil {
+				t.Fatalf("Unexpected error: %s", resp.Error)
+			}
+
+			elements := make([]attr.Value, len(tc.expected))
+			for i, message := range tc.expected {
+				elements[i] = types.StringValue(message)
+			}
+			expected := types.ListValueMust(types.StringType, elements)
+			if got := resp.Result.Value(); !got.Equal(expected) {
+				t.Errorf("Expected %s, got %s", expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,371 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// exactlyOneBlockValidator is a resource config validator that ensures exactly
+// one of the given single nested blocks is configured. noun names what the
+// blocks describe in error messages, e.g. "credential".
+type exactlyOneBlockValidator struct {
+	noun   string
+	blocks []string
+}
+
+var _ resource.ConfigValidator = exactlyOneBlockValidator{}
+
+// Description returns a human-readable description of the validator.
+func (v exactlyOneBlockValidator) Description(_ context.Context) string {
+	return "Exactly one of these blocks must be specified: " + formatBlockList(v.blocks)
+}
+
+// MarkdownDescription returns a markdown formatted human-readable description of the validator.
+func (v exactlyOneBlockValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource implements the validation logic.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (v exactlyOneBlockValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	blockNames := []string{}
+
+	for _, name := range v.blocks {
+		var block types.Object
+		diags := req.Config.GetAttribute(ctx, path.Root(name), &block)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		// An unknown block may still resolve to a configured one, so defer
+		// the decision until the value is known.
+		if block.IsUnknown() {
+			return
+		}
+
+		if !block.IsNull() {
+			blockNames = append(blockNames, name)
+		}
+	}
+
+	if len(blockNames) == 0 {
+		resp.Diagnostics.AddError(
+			"Missing Block",
+			fmt.Sprintf("Exactly one %s block must be specified: %s", v.noun, formatBlockList(v.blocks)),
+		)
+		return
+	}
+	if len(blockNames) > 1 {
+		resp.Diagnostics.AddError(
+			"Multiple Blocks",
+			fmt.Sprintf("Exactly one %s block must be specified, but %d were found (%s). Please specify only one of: %s", v.noun, len(blockNames), strings.Join(blockNames, ", "), formatBlockList(v.blocks)),
+		)
+	}
+}
+
+// requiredAttributesValidator is an object validator that ensures the given
+// attributes are set whenever the block itself is configured.
+type requiredAttributesValidator struct {
+	attributes []string
+}
+
+var _ validator.Object = requiredAttributesValidator{}
+
+// Description returns a human-readable description of the validator.
+func (v requiredAttributesValidator) Description(_ context.Context) string {
+	return "When the block is specified, these attributes are required: " + strings.Join(v.attributes, ", ")
+}
+
+// MarkdownDescription returns a markdown formatted human-readable description of the validator.
+func (v requiredAttributesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateObject implements the validation logic.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (v requiredAttributesValidator) ValidateObject(_ context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	attributes := req.ConfigValue.Attributes()
+	for _, name := range v.attributes {
+		value, ok := attributes[name]
+		if ok && !value.IsNull() {
+			continue
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			req.Path.AtName(name),
+			"Missing Required Attribute",
+			fmt.Sprintf("The %s attribute is required when using the %s block.", name, req.Path.String()),
+		)
+	}
+}
+
+// flowAttributesValidator is an object validator for blocks whose attributes
+// depend on a selector attribute, such as the authentication flow. attributes
+// maps each value of the selector to the attributes the value requires; those
+// of other values do not apply and must not be set, so they are not silently
+// dropped from the credential data.
+type flowAttributesValidator struct {
+	flow        string
+	defaultFlow string
+	attributes  map[string][]string
+}
+
+var _ validator.Object = flowAttributesValidator{}
+
+// Description returns a human-readable description of the validator.
+func (v flowAttributesValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("The attributes required and allowed depend on %s.", v.flow)
+}
+
+// MarkdownDescription returns a markdown formatted human-readable description of the validator.
+func (v flowAttributesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateObject implements the validation logic.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (v flowAttributesValidator) ValidateObject(_ context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	attributes := req.ConfigValue.Attributes()
+
+	flow := v.defaultFlow
+	if value, ok := attributes[v.flow].(types.String); ok && !value.IsNull() {
+		if value.IsUnknown() {
+			return
+		}
+		flow = value.ValueString()
+	}
+
+	// Unsupported flows are reported by the validator of the flow attribute.
+	required, ok := v.attributes[flow]
+	if !ok {
+		return
+	}
+
+	for _, name := range required {
+		if value, ok := attributes[name]; ok && value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtName(name),
+				"Missing Required Attribute",
+				fmt.Sprintf("The %s attribute is required for the %s %s.", name, flow, v.flow),
+			)
+		}
+	}
+
+	flows := make([]string, 0, len(v.attributes))
+	for other := range v.attributes {
+		flows = append(flows, other)
+	}
+	sort.Strings(flows)
+
+	for _, other := range flows {
+		if other == flow {
+			continue
+		}
+		for _, name := range v.attributes[other] {
+			if slices.Contains(required, name) {
+				continue
+			}
+			if value, ok := attributes[name]; ok && !value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					req.Path.AtName(name),
+					"Unsupported Attribute",
+					fmt.Sprintf("The %s attribute only applies to the %s %s, but %s is %s.", name, other, v.flow, v.flow, flow),
+				)
+			}
+		}
+	}
+}
+
+// stringOneOfValidator validates that a string is one of the given values.
+type stringOneOfValidator struct {
+	values []string
+}
+
+var _ validator.String = stringOneOfValidator{}
+
+// Description returns a human-readable description of the validator.
+func (v stringOneOfValidator) Description(_ context.Context) string {
+	return "value must be one of: " + strings.Join(v.values, ", ")
+}
+
+// MarkdownDescription returns a markdown formatted human-readable description of the validator.
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements the validation logic.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (v stringOneOfValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, value := range v.values {
+		if req.ConfigValue.ValueString() == value {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("The value %q is not supported, expected %s.", req.ConfigValue.ValueString(), formatBlockList(v.values)),
+	)
+}
+
+// reservedHeadersValidator validates that a map of headers does not set any
+// header the client sets itself.
+type reservedHeadersValidator struct{}
+
+var _ validator.Map = reservedHeadersValidator{}
+
+// Description returns a human-readable description of the validator.
+func (v reservedHeadersValidator) Description(_ context.Context) string {
+	return "headers must not include: " + strings.Join(client.ReservedHeaders, ", ")
+}
+
+// MarkdownDescription returns a markdown formatted human-readable description of the validator.
+func (v reservedHeadersValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateMap implements the validation logic.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (v reservedHeadersValidator) ValidateMap(_ context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	names := make([]string, 0, len(req.ConfigValue.Elements()))
+	for name := range req.ConfigValue.Elements() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if client.IsReservedHeader(name) {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(name),
+				"Reserved Header",
+				fmt.Sprintf("The header %s is set by the provider and cannot be overridden. Reserved headers are: %s.", name, strings.Join(client.ReservedHeaders, ", ")),
+			)
+		}
+	}
+}
+
+// int64AtLeastValidator validates that an integer is at least min.
+type int64AtLeastValidator struct {
+	min int64
+}
+
+var _ validator.Int64 = int64AtLeastValidator{}
+
+// Description returns a human-readable description of the validator.
+func (v int64AtLeastValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be at least %d", v.min)
+}
+
+// MarkdownDescription returns a markdown formatted human-readable description of the validator.
+func (v int64AtLeastValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateInt64 implements the validation logic.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (v int64AtLeastValidator) ValidateInt64(_ context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if req.ConfigValue.ValueInt64() < v.min {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("The value %d is not supported, expected at least %d.", req.ConfigValue.ValueInt64(), v.min),
+		)
+	}
+}
+
+// conflictingBoolsValidator is a resource config validator that ensures at
+// most one of the given boolean attributes is true.
+type conflictingBoolsValidator struct {
+	attributes []string
+}
+
+var _ resource.ConfigValidator = conflictingBoolsValidator{}
+
+// Description returns a human-readable description of the validator.
+func (v conflictingBoolsValidator) Description(_ context.Context) string {
+	return "At most one of these attributes may be true: " + formatBlockList(v.attributes)
+}
+
+// MarkdownDescription returns a markdown formatted human-readable description of the validator.
+func (v conflictingBoolsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource implements the validation logic.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (v conflictingBoolsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	set := []string{}
+
+	for _, name := range v.attributes {
+		var value types.Bool
+		diags := req.Config.GetAttribute(ctx, path.Root(name), &value)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		if value.ValueBool() {
+			set = append(set, name)
+		}
+	}
+
+	if len(set) > 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(set[len(set)-1]),
+			"Conflicting Attributes",
+			fmt.Sprintf("At most one of %s may be true, but %s are.", formatBlockList(v.attributes), strings.Join(set, " and ")),
+		)
+	}
+}
+
+// formatBlockList renders block names as "a, b, or c".
+func formatBlockList(names []string) string {
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return names[0]
+	case 2:
+		return names[0] + " or " + names[1]
+	default:
+		return strings.Join(names[:len(names)-1], ", ") + ", or " + names[len(names)-1]
+	}
+}
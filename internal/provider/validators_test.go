@@ -0,0 +1,408 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestExactlyOneBlockValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		blocks    map[string]map[string]tftypes.Value
+		wantError bool
+	}{
+		{
+			name:      "no blocks",
+			blocks:    map[string]map[string]tftypes.Value{},
+			wantError: true,
+		},
+		{
+			name: "one block",
+			blocks: map[string]map[string]tftypes.Value{
+				"header_auth": {
+					"name":  tftypes.NewValue(tftypes.String, "Authorization"),
+					"value": tftypes.NewValue(tftypes.String, "Bearer token"),
+				},
+			},
+			wantError: false,
+		},
+		{
+			name: "two blocks",
+			blocks: map[string]map[string]tftypes.Value{
+				"basic_auth": {
+					"username": tftypes.NewValue(tftypes.String, "user"),
+					"password": tftypes.NewValue(tftypes.String, "pass"),
+				},
+				"header_auth": {
+					"name":  tftypes.NewValue(tftypes.String, "Authorization"),
+					"value": tftypes.NewValue(tftypes.String, "Bearer token"),
+				},
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			req := resource.ValidateConfigRequest{
+				Config: credentialConfig(t, tt.blocks),
+			}
+			resp := &resource.ValidateConfigResponse{}
+
+			exactlyOneBlockValidator{noun: "credential", blocks: credentialBlockNames}.ValidateResource(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("Expected error %v, got diagnostics: %+v", tt.wantError, resp.Diagnostics)
+			}
+			if tt.wantError && !strings.Contains(resp.Diagnostics[0].Detail(), "Exactly one credential block") {
+				t.Errorf("Expected the error to name the credential blocks, got %q", resp.Diagnostics[0].Detail())
+			}
+		})
+	}
+}
+
+func TestRequiredAttributesValidator(t *testing.T) {
+	t.Parallel()
+
+	attrTypes := map[string]attr.Type{
+		"username": types.StringType,
+		"password": types.StringType,
+	}
+
+	tests := []struct {
+		name       string
+		value      types.Object
+		wantErrors int
+	}{
+		{
+			name:       "null block",
+			value:      types.ObjectNull(attrTypes),
+			wantErrors: 0,
+		},
+		{
+			name: "all attributes set",
+			value: types.ObjectValueMust(attrTypes, map[string]attr.Value{
+				"username": types.StringValue("user"),
+				"password": types.StringValue("pass"),
+			}),
+			wantErrors: 0,
+		},
+		{
+			name: "unknown attribute",
+			value: types.ObjectValueMust(attrTypes, map[string]attr.Value{
+				"username": types.StringValue("user"),
+				"password": types.StringUnknown(),
+			}),
+			wantErrors: 0,
+		},
+		{
+			name: "missing attributes",
+			value: types.ObjectValueMust(attrTypes, map[string]attr.Value{
+				"username": types.StringNull(),
+				"password": types.StringNull(),
+			}),
+			wantErrors: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.ObjectRequest{
+				Path:        path.Root("basic_auth"),
+				ConfigValue: tt.value,
+			}
+			resp := &validator.ObjectResponse{}
+
+			requiredAttributesValidator{attributes: []string{"username", "password"}}.ValidateObject(context.Background(), req, resp)
+
+			if resp.Diagnostics.ErrorsCount() != tt.wantErrors {
+				t.Errorf("Expected %d errors, got diagnostics: %+v", tt.wantErrors, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestFlowAttributesValidator(t *testing.T) {
+	t.Parallel()
+
+	attributeTypes := map[string]attr.Type{
+		"flow":          types.StringType,
+		"client_secret": types.StringType,
+		"username":      types.StringType,
+		"private_key":   types.StringType,
+	}
+	salesforce := func(flow, clientSecret, username, privateKey types.String) types.Object {
+		return types.ObjectValueMust(attributeTypes, map[string]attr.Value{
+			"flow":          flow,
+			"client_secret": clientSecret,
+			"username":      username,
+			"private_key":   privateKey,
+		})
+	}
+	null := types.StringNull()
+	set := types.StringValue("value")
+
+	tests := []struct {
+		name       string
+		value      types.Object
+		wantErrors int
+	}{
+		{name: "null block", value: types.ObjectNull(attributeTypes)},
+		{name: "default flow", value: salesforce(null, null, set, set)},
+		{name: "default flow missing attributes", value: salesforce(null, null, null, null), wantErrors: 2},
+		{name: "jwt with client_secret", value: salesforce(types.StringValue("jwt"), set, set, set), wantErrors: 1},
+		{name: "oauth2", value: salesforce(types.StringValue("oauth2"), set, null, null)},
+		{name: "oauth2 with jwt attributes", value: salesforce(types.StringValue("oauth2"), set, set, set), wantErrors: 2},
+		{name: "unknown flow", value: salesforce(types.StringUnknown(), set, set, set)},
+		{name: "unsupported flow", value: salesforce(types.StringValue("saml"), set, set, set)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.ObjectRequest{
+				Path:        path.Root("salesforce"),
+				ConfigValue: tt.value,
+			}
+			resp := &validator.ObjectResponse{}
+
+			flowAttributesValidator{
+				flow:        "flow",
+				defaultFlow: "jwt",
+				attributes: map[string][]string{
+					"jwt":    {"username", "private_key"},
+					"oauth2": {"client_secret"},
+				},
+			}.ValidateObject(context.Background(), req, resp)
+
+			if got := resp.Diagnostics.ErrorsCount(); got != tt.wantErrors {
+				t.Errorf("Expected %d errors, got diagnostics: %+v", tt.wantErrors, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestStringOneOfValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		value     types.String
+		wantError bool
+	}{
+		{name: "null", value: types.StringNull()},
+		{name: "unknown", value: types.StringUnknown()},
+		{name: "allowed", value: types.StringValue("warn")},
+		{name: "not allowed", value: types.StringValue("ignore"), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("name_conflict"),
+				ConfigValue: tt.value,
+			}
+			resp := &validator.StringResponse{}
+
+			stringOneOfValidator{values: []string{"warn", "error"}}.ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("Expected error: %v, got diagnostics: %+v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestReservedHeadersValidator(t *testing.T) {
+	t.Parallel()
+
+	headers := func(names ...string) types.Map {
+		elements := make(map[string]attr.Value, len(names))
+		for _, name := range names {
+			elements[name] = types.StringValue("value")
+		}
+		return types.MapValueMust(types.StringType, elements)
+	}
+
+	tests := []struct {
+		name       string
+		value      types.Map
+		wantErrors int
+	}{
+		{name: "null", value: types.MapNull(types.StringType)},
+		{name: "unknown", value: types.MapUnknown(types.StringType)},
+		{name: "custom headers", value: headers("cf-access-token", "X-Tenant")},
+		{name: "api key", value: headers("x-n8n-api-key"), wantErrors: 1},
+		{name: "encoding headers", value: headers("content-type", "Content-Encoding", "X-Tenant"), wantErrors: 2},
+		{name: "session and identification headers", value: headers("Cookie", "user-agent", "X-Request-ID"), wantErrors: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.MapRequest{
+				Path:        path.Root("extra_headers"),
+				ConfigValue: tt.value,
+			}
+			resp := &validator.MapResponse{}
+
+			reservedHeadersValidator{}.ValidateMap(context.Background(), req, resp)
+
+			if got := resp.Diagnostics.ErrorsCount(); got != tt.wantErrors {
+				t.Errorf("Expected %d errors, got diagnostics: %+v", tt.wantErrors, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestInt64AtLeastValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		value     types.Int64
+		wantError bool
+	}{
+		{name: "null", value: types.Int64Null()},
+		{name: "unknown", value: types.Int64Unknown()},
+		{name: "minimum", value: types.Int64Value(0)},
+		{name: "above minimum", value: types.Int64Value(30)},
+		{name: "below minimum", value: types.Int64Value(-1), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.Int64Request{
+				Path:        path.Root("retention_days"),
+				ConfigValue: tt.value,
+			}
+			resp := &validator.Int64Response{}
+
+			int64AtLeastValidator{min: 0}.ValidateInt64(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("Expected error: %v, got diagnostics: %+v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestConflictingBoolsValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		active    tftypes.Value
+		archived  tftypes.Value
+		wantError bool
+	}{
+		{name: "unset", active: tftypes.NewValue(tftypes.Bool, nil), archived: tftypes.NewValue(tftypes.Bool, nil)},
+		{name: "one true", active: tftypes.NewValue(tftypes.Bool, true), archived: tftypes.NewValue(tftypes.Bool, false)},
+		{name: "unknown", active: tftypes.NewValue(tftypes.Bool, true), archived: tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue)},
+		{name: "both true", active: tftypes.NewValue(tftypes.Bool, true), archived: tftypes.NewValue(tftypes.Bool, true), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := workflowTestState(t, map[string]tftypes.Value{
+				"active":   tt.active,
+				"archived": tt.archived,
+			})
+			req := resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw},
+			}
+			resp := &resource.ValidateConfigResponse{}
+
+			conflictingBoolsValidator{attributes: []string{"active", "archived"}}.ValidateResource(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("Expected error: %v, got diagnostics: %+v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestFormatBlockList(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string][]string{
+		"":                     nil,
+		"a":                    {"a"},
+		"a or b":               {"a", "b"},
+		"a, b, or c":           {"a", "b", "c"},
+		"a, b, c, or d":        {"a", "b", "c", "d"},
+		"basic_auth or oauth2": {"basic_auth", "oauth2"},
+	}
+
+	for want, names := range tests {
+		if got := formatBlockList(names); got != want {
+			t.Errorf("formatBlockList(%v) = %q, want %q", names, got, want)
+		}
+	}
+}
+
+// credentialConfig builds a credential resource configuration with the given
+// blocks set and every other attribute null.
+func credentialConfig(t *testing.T, blocks map[string]map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	ctx := context.Background()
+	schemaResponse := &resource.SchemaResponse{}
+	NewCredentialResource().Schema(ctx, resource.SchemaRequest{}, schemaResponse)
+
+	objectType, ok := schemaResponse.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("Expected schema to be an object type")
+	}
+
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+
+	for name, blockValues := range blocks {
+		blockType, ok := objectType.AttributeTypes[name].(tftypes.Object)
+		if !ok {
+			t.Fatalf("Expected %s to be an object type", name)
+		}
+
+		attributes := make(map[string]tftypes.Value, len(blockType.AttributeTypes))
+		for attrName, attrType := range blockType.AttributeTypes {
+			attributes[attrName] = tftypes.NewValue(attrType, nil)
+		}
+		for attrName, value := range blockValues {
+			attributes[attrName] = value
+		}
+
+		values[name] = tftypes.NewValue(blockType, attributes)
+	}
+
+	return tfsdk.Config{
+		Schema: schemaResponse.Schema,
+		Raw:    tftypes.NewValue(objectType, values),
+	}
+}
@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &webhookCollisionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &webhookCollisionsDataSource{}
+)
+
+// NewWebhookCollisionsDataSource is a helper function to simplify the provider implementation.
+func NewWebhookCollisionsDataSource() datasource.DataSource {
+	return &webhookCollisionsDataSource{}
+}
+
+// webhookCollisionsDataSource is the data source implementation.
+type webhookCollisionsDataSource struct {
+	client *client.Client
+}
+
+// webhookCollisionsDataSourceModel maps the data source schema data.
+type webhookCollisionsDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Workflows  types.List   `tfsdk:"workflows"`
+	Collisions types.List   `tfsdk:"collisions"`
+}
+
+// Metadata returns the data source type name.
+func (d *webhookCollisionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook_collisions"
+}
+
+// Schema defines the schema for the data source.
+func (d *webhookCollisionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Finds webhook triggers of workflow definitions that listen on the same HTTP method and path as each other " +
+			"or as an active workflow on the instance. n8n only reports such collisions when activating the workflow; this data " +
+			"source reports them at plan time, e.g. in a postcondition: `length(self.collisions) == 0`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier of the check. The SHA-256 hash of the collisions.",
+				Computed:    true,
+			},
+			"workflows": schema.ListAttribute{
+				Description: "The workflow JSON documents managed in Terraform. Active workflows on the instance with the ID of a " +
+					"document, or with its name when it has no ID, are the same workflow and are not compared with it.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"collisions": schema.ListAttribute{
+				Description: "A description of each HTTP method and path more than one webhook trigger listens on, ordered by HTTP method and path. " +
+					"Empty when there are no collisions.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *webhookCollisionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	n8nClient, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = n8nClient
+}
+
+// Read refreshes the Terraform state with the latest data.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (d *webhookCollisionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, warnRetries := withRetryWarning(ctx)
+	defer warnRetries(&resp.Diagnostics)
+
+	var state webhookCollisionsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var definitions []string
+	diags = state.Workflows.ElementsAs(ctx, &definitions, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed := make([]models.Workflow, len(definitions))
+	for i, definition := range definitions {
+		if err := json.Unmarshal([]byte(definition), &managed[i]); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("workflows").AtListIndex(i),
+				"Invalid Workflow Definition",
+				fmt.Sprintf("The workflow definition is not valid JSON: %s", err.Error()),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Checking webhook collisions", map[string]interface{}{
+		"workflow_count": len(managed),
+	})
+
+	existing, err := d.client.ListWorkflows(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading workflows",
+			fmt.Sprintf("Could not list workflows, unexpected error: %s", errorDetail(err)),
+		)
+		return
+	}
+
+	collisions, err := webhookCollisions(managed, existing)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error checking webhook collisions",
+			fmt.Sprintf("Could not read the webhook triggers of workflows: %s", err.Error()),
+		)
+		return
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(collisions, "\n")))
+	state.ID = types.StringValue(hex.EncodeToString(sum[:]))
+	state.Collisions, diags = types.ListValueFrom(ctx, types.StringType, collisions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Checked webhook collisions", map[string]interface{}{
+		"collision_count": len(collisions),
+	})
+}
+
+// webhookListener is a webhook trigger listening on an endpoint.
+type webhookListener struct {
+	description string
+	endpoint    models.WebhookEndpoint
+}
+
+// webhookCollisions describes every endpoint more than one webhook trigger of
+// the managed workflows listens on, including triggers of active existing
+// workflows. Existing workflows that are one of the managed workflows, by ID
+// or by name when the definition has no ID, are left out, as are collisions
+// between existing workflows only, which n8n already refused.
+func webhookCollisions(managed, existing []models.Workflow) ([]string, error) {
+	listeners := make(map[string][]webhookListener)
+	managedIDs := make(map[string]bool)
+	managedNames := make(map[string]bool)
+
+	for i := range managed {
+		workflow := &managed[i]
+		if workflow.ID != "" {
+			managedIDs[workflow.ID] = true
+		} else {
+			managedNames[workflow.Name] = true
+		}
+
+		endpoints, err := workflow.WebhookEndpoints()
+		if err != nil {
+			return nil, err
+		}
+		for _, endpoint := range endpoints {
+			key := endpoint.Method + " " + endpoint.Path
+			listeners[key] = append(listeners[key], webhookListener{
+				description: fmt.Sprintf("workflow %q (node %q)", workflow.Name, endpoint.NodeName),
+				endpoint:    endpoint,
+			})
+		}
+	}
+
+	for i := range existing {
+		workflow := &existing[i]
+		if !workflow.Active || managedIDs[workflow.ID] || managedNames[workflow.Name] {
+			continue
+		}
+
+		endpoints, err := workflow.WebhookEndpoints()
+		if err != nil {
+			return nil, err
+		}
+		for _, endpoint := range endpoints {
+			key := endpoint.Method + " " + endpoint.Path
+			// Only endpoints of managed workflows can collide.
+			if _, ok := listeners[key]; !ok {
+				continue
+			}
+			listeners[key] = append(listeners[key], webhookListener{
+				description: fmt.Sprintf("active workflow %q (ID %s, node %q)", workflow.Name, workflow.ID, endpoint.NodeName),
+				endpoint:    endpoint,
+			})
+		}
+	}
+
+	collisions := []string{}
+	for _, keyListeners := range listeners {
+		if len(keyListeners) < 2 {
+			continue
+		}
+
+		descriptions := make([]string, len(keyListeners))
+		for i, listener := range keyListeners {
+			descriptions[i] = listener.description
+		}
+		endpoint := keyListeners[0].endpoint
+		collisions = append(collisions, fmt.Sprintf("%s /webhook/%s is used by %s and %s",
+			endpoint.Method, endpoint.Path,
+			strings.Join(descriptions[:len(descriptions)-1], ", "), descriptions[len(descriptions)-1]))
+	}
+	sort.Strings(collisions)
+
+	return collisions, nil
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestWebhookCollisionsDataSourceSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResponse := &datasource.SchemaResponse{}
+
+	NewWebhookCollisionsDataSource().Schema(ctx, datasource.SchemaRequest{}, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	for _, name := range []string{"id", "workflows", "collisions"} {
+		if _, ok := schemaResponse.Schema.Attributes[name]; !ok {
+			t.Errorf("missing attribute: %s", name)
+		}
+	}
+}
+
+func TestWebhookCollisionsDataSourceMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadataResponse := &datasource.MetadataResponse{}
+	NewWebhookCollisionsDataSource().Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "n8n"}, metadataResponse)
+
+	if metadataResponse.TypeName != "n8n_webhook_collisions" {
+		t.Errorf("Expected TypeName to be 'n8n_webhook_collisions', got '%s'", metadataResponse.TypeName)
+	}
+}
+
+func TestWebhookCollisions(t *testing.T) {
+	t.Parallel()
+
+	webhook := func(name, method, path string) string {
+		return `{"name":"` + name + `","type":"n8n-nodes-base.webhook","parameters":{"httpMethod":"` + method + `","path":"` + path + `"}}`
+	}
+	workflow := func(id, name string, active bool, nodes ...string) models.Workflow {
+		raw := "["
+		for i, node := range nodes {
+			if i > 0 {
+				raw += ","
+			}
+			raw += node
+		}
+		return models.Workflow{ID: id, Name: name, Active: active, Nodes: json.RawMessage(raw + "]")}
+	}
+
+	managed := []models.Workflow{
+		workflow("", "orders", false, webhook("Orders", "POST", "orders"), webhook("Status", "GET", "status")),
+		workflow("", "legacy orders", false, webhook("Orders", "POST", "/orders")),
+		workflow("5", "billing", false, webhook("Invoice", "POST", "invoices")),
+	}
+	existing := []models.Workflow{
+		// The managed workflows themselves, by name and by ID.
+		workflow("1", "orders", true, webhook("Orders", "POST", "orders")),
+		workflow("5", "billing (renamed)", true, webhook("Invoice", "POST", "invoices")),
+		workflow("7", "status page", true, webhook("Hook", "GET", "status")),
+		workflow("8", "inactive", false, webhook("Hook", "POST", "invoices")),
+		workflow("9", "unrelated", true, webhook("Hook", "PUT", "orders")),
+	}
+
+	collisions, err := webhookCollisions(managed, existing)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		`GET /webhook/status is used by workflow "orders" (node "Status") and active workflow "status page" (ID 7, node "Hook")`,
+		`POST /webhook/orders is used by workflow "orders" (node "Orders") and workflow "legacy orders" (node "Orders")`,
+	}
+	if len(collisions) != len(expected) {
+		t.Fatalf("Expected %d collisions, got %q", len(expected), collisions)
+	}
+	for i := range expected {
+		if collisions[i] != expected[i] {
+			t.Errorf("Expected %q, got %q", expected[i], collisions[i])
+		}
+	}
+}
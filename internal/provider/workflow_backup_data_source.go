@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &workflowBackupDataSource{}
+	_ datasource.DataSourceWithConfigure = &workflowBackupDataSource{}
+)
+
+// NewWorkflowBackupDataSource is a helper function to simplify the provider implementation.
+func NewWorkflowBackupDataSource() datasource.DataSource {
+	return &workflowBackupDataSource{}
+}
+
+// workflowBackupDataSource is the data source implementation.
+type workflowBackupDataSource struct {
+	client *client.Client
+}
+
+// workflowBackupDataSourceModel maps the data source schema data.
+type workflowBackupDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Compress      types.Bool   `tfsdk:"compress"`
+	RetentionDays types.Int64  `tfsdk:"retention_days"`
+	PageSize      types.Int64  `tfsdk:"page_size"`
+	Content       types.String `tfsdk:"content"`
+	SHA256        types.String `tfsdk:"sha256"`
+	WorkflowCount types.Int64  `tfsdk:"workflow_count"`
+	GeneratedAt   types.String `tfsdk:"generated_at"`
+	ExpiresAt     types.String `tfsdk:"expires_at"`
+	Filter        types.Object `tfsdk:"filter"`
+}
+
+// workflowBackup is the document produced by the data source.
+type workflowBackup struct {
+	Workflows []models.Workflow `json:"workflows"`
+}
+
+// Metadata returns the data source type name.
+func (d *workflowBackupDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_backup"
+}
+
+// Schema defines the schema for the data source.
+func (d *workflowBackupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Snapshots the workflow definitions of the n8n instance into a single JSON document, " +
+			"suitable for archiving with other providers (e.g. to S3 or Git). Includes all workflows unless filtered.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier of the backup. Equal to sha256.",
+				Computed:    true,
+			},
+			"compress": schema.BoolAttribute{
+				Description: "Whether to gzip the document and base64 encode the result. Defaults to false.",
+				Optional:    true,
+			},
+			"retention_days": schema.Int64Attribute{
+				Description: "Number of days the backup should be retained, at least 0. Used to compute expires_at.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64AtLeastValidator{min: 0},
+				},
+			},
+			"page_size": schema.Int64Attribute{
+				Description: fmt.Sprintf("Number of workflows requested per page, between 1 and %d. "+
+					"Workflow definitions can be large, so smaller pages keep each response small. Defaults to the page_size of the provider.", client.MaxPageSize),
+				Optional: true,
+			},
+			"content": schema.StringAttribute{
+				Description: "The backup document, with workflows ordered by ID whatever order n8n lists them in. Plain JSON, or base64 encoded gzip when compress is true.",
+				Computed:    true,
+			},
+			"sha256": schema.StringAttribute{
+				Description: "The SHA-256 hash of the uncompressed JSON document. Changes whenever a workflow in the backup changes, including its active state, updatedAt and versionId.",
+				Computed:    true,
+			},
+			"workflow_count": schema.Int64Attribute{
+				Description: "The number of workflows in the backup.",
+				Computed:    true,
+			},
+			"generated_at": schema.StringAttribute{
+				Description: "The RFC 3339 timestamp at which the backup was taken.",
+				Computed:    true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "The RFC 3339 timestamp after which the backup may be deleted. Null when retention_days is not set.",
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": listFilterBlock("workflows"),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *workflowBackupDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	n8nClient, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = n8nClient
+}
+
+// Read refreshes the Terraform state with the latest data.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (d *workflowBackupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, warnRetries := withRetryWarning(ctx)
+	defer warnRetries(&resp.Diagnostics)
+
+	var state workflowBackupDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, diags := newListFilter(ctx, state.Filter)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listCtx := ctx
+	if !state.PageSize.IsNull() {
+		var err error
+		listCtx, err = client.ContextWithPageSize(ctx, int(state.PageSize.ValueInt64()))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("page_size"), "Invalid Page Size", err.Error())
+			return
+		}
+	}
+
+	tflog.Info(ctx, "Reading workflows for backup")
+
+	listed, err := d.client.ListWorkflows(listCtx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading workflows",
+			fmt.Sprintf("Could not list workflows, unexpected error: %s", errorDetail(err)),
+		)
+		return
+	}
+
+	workflows := make([]models.Workflow, 0, len(listed))
+	for i := range listed {
+		if filter.matches(workflowFilterItem(&listed[i])) {
+			workflows = append(workflows, listed[i])
+		}
+	}
+
+	content, hash, err := buildWorkflowBackup(workflows, state.Compress.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error building workflow backup",
+			fmt.Sprintf("Could not build workflow backup: %s", err.Error()),
+		)
+		return
+	}
+
+	generatedAt := time.Now().UTC()
+
+	state.ID = types.StringValue(hash)
+	state.Content = types.StringValue(content)
+	state.SHA256 = types.StringValue(hash)
+	state.WorkflowCount = types.Int64Value(int64(len(workflows)))
+	state.GeneratedAt = types.StringValue(generatedAt.Format(time.RFC3339))
+	state.ExpiresAt = types.StringNull()
+	if !state.RetentionDays.IsNull() {
+		expiresAt := generatedAt.AddDate(0, 0, int(state.RetentionDays.ValueInt64()))
+		state.ExpiresAt = types.StringValue(expiresAt.Format(time.RFC3339))
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Read workflows for backup", map[string]interface{}{
+		"workflow_count": len(workflows),
+		"sha256":         hash,
+	})
+}
+
+// buildWorkflowBackup renders the workflows as a backup document ordered by ID
+// and returns the content and the SHA-256 hash of the uncompressed JSON.
+func buildWorkflowBackup(workflows []models.Workflow, compress bool) (string, string, error) {
+	sorted := sortedByID(workflows, func(workflow *models.Workflow) string { return workflow.ID })
+
+	document, err := json.Marshal(workflowBackup{Workflows: sorted})
+	if err != nil {
+		return "", "", fmt.Errorf("error marshaling backup: %w", err)
+	}
+
+	sum := sha256.Sum256(document)
+	hash := hex.EncodeToString(sum[:])
+
+	if !compress {
+		return string(document), hash, nil
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(document); err != nil {
+		return "", "", fmt.Errorf("error compressing backup: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", "", fmt.Errorf("error compressing backup: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), hash, nil
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestWorkflowBackupDataSourceSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResponse := &datasource.SchemaResponse{}
+
+	NewWorkflowBackupDataSource().Schema(ctx, datasource.SchemaRequest{}, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	for _, name := range []string{"id", "compress", "retention_days", "content", "sha256", "workflow_count", "generated_at", "expires_at"} {
+		if _, ok := schemaResponse.Schema.Attributes[name]; !ok {
+			t.Errorf("missing attribute: %s", name)
+		}
+	}
+}
+
+func TestWorkflowBackupDataSourceMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadataResponse := &datasource.MetadataResponse{}
+	NewWorkflowBackupDataSource().Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "n8n"}, metadataResponse)
+
+	if metadataResponse.TypeName != "n8n_workflow_backup" {
+		t.Errorf("Expected TypeName to be 'n8n_workflow_backup', got '%s'", metadataResponse.TypeName)
+	}
+}
+
+func TestBuildWorkflowBackup(t *testing.T) {
+	t.Parallel()
+
+	workflows := []models.Workflow{
+		{ID: "2", Name: "second", Nodes: json.RawMessage(`[]`), Connections: json.RawMessage(`{}`)},
+		{ID: "1", Name: "first", Nodes: json.RawMessage(`[]`), Connections: json.RawMessage(`{}`)},
+	}
+	reversed := []models.Workflow{workflows[1], workflows[0]}
+
+	content, hash, err := buildWorkflowBackup(workflows, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	_, reversedHash, err := buildWorkflowBackup(reversed, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if hash != reversedHash {
+		t.Errorf("Expected hash to be independent of input order, got %s and %s", hash, reversedHash)
+	}
+
+	var backup workflowBackup
+	if err := json.Unmarshal([]byte(content), &backup); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(backup.Workflows) != 2 || backup.Workflows[0].ID != "1" {
+		t.Errorf("Expected workflows ordered by ID, got %+v", backup.Workflows)
+	}
+
+	compressed, compressedHash, err := buildWorkflowBackup(workflows, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if compressedHash != hash {
+		t.Errorf("Expected hash of uncompressed document, got %s", compressedHash)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(compressed)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(decompressed) != content {
+		t.Errorf("Expected decompressed content to match uncompressed document")
+	}
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &workflowEqualFunction{}
+
+// NewWorkflowEqualFunction is a helper function to simplify the provider implementation.
+func NewWorkflowEqualFunction() function.Function {
+	return &workflowEqualFunction{}
+}
+
+// workflowEqualFunction is the function implementation.
+type workflowEqualFunction struct{}
+
+// Metadata returns the function name.
+func (f *workflowEqualFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "workflow_equal"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *workflowEqualFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compare two workflow definitions",
+		MarkdownDescription: "Returns whether two workflow JSON documents are semantically equal, e.g. a definition kept in Git " +
+			"and the current definition from `n8n_workflow_export`. Formatting, key and node order, server-managed fields, " +
+			"fields holding n8n's defaults and static data are ignored, " +
+			"and so are node positions and sticky notes unless `ignore_ui_layout` is false, and pinned test data unless `ignore_pin_data` is false.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "a",
+				Description: "The first workflow JSON document.",
+			},
+			function.StringParameter{
+				Name:        "b",
+				Description: "The second workflow JSON document.",
+			},
+		},
+		VariadicParameter: normalizeOptionsParameter(),
+		Return:            function.BoolReturn{},
+	}
+}
+
+// Run compares the workflow definitions.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (f *workflowEqualFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b string
+	var arguments []map[string]bool
+	resp.Error = req.Arguments.Get(ctx, &a, &b, &arguments)
+	if resp.Error != nil {
+		return
+	}
+
+	options, err := functionNormalizeOptions(arguments)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(2, err.Error())
+		return
+	}
+
+	// The definitions are normalized separately, rather than with
+	// WorkflowDefinitionsEqual, to report which argument is invalid.
+	normalizedA, err := models.NormalizeWorkflowDefinition([]byte(a), options)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	normalizedB, err := models.NormalizeWorkflowDefinition([]byte(b), options)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, bytes.Equal(normalizedA, normalizedB))
+}
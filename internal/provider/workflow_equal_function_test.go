@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestWorkflowEqualFunctionMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadataResponse := &function.MetadataResponse{}
+	NewWorkflowEqualFunction().Metadata(context.Background(), function.MetadataRequest{}, metadataResponse)
+
+	if metadataResponse.Name != "workflow_equal" {
+		t.Errorf("Expected Name to be 'workflow_equal', got '%s'", metadataResponse.Name)
+	}
+}
+
+func TestWorkflowEqualFunctionRun(t *testing.T) {
+	t.Parallel()
+
+	exported := `{"name":"Sync","nodes":[{"name":"Start","type":"n8n-nodes-base.manualTrigger","position":[240,300]}],"connections":{},"pinData":{"Start":[]}}`
+
+	testCases := []struct {
+		name     string
+		b        string
+		options  []map[string]bool
+		expected bool
+		wantErrB bool
+	}{
+		{
+			name:     "equal ignoring layout and runtime data",
+			b:        `{"id":"1","name":"Sync","connections":{},"staticData":{"lastId":3},"nodes":[{"type":"n8n-nodes-base.manualTrigger","name":"Start","position":[600,120]}]}`,
+			expected: true,
+		},
+		{
+			name:     "moved nodes with the UI layout compared",
+			b:        `{"name":"Sync","nodes":[{"name":"Start","type":"n8n-nodes-base.manualTrigger","position":[600,120]}],"connections":{}}`,
+			options:  []map[string]bool{{"ignore_ui_layout": false}},
+			expected: false,
+		},
+		{
+			name:     "different",
+			b:        `{"name":"Sync","nodes":[{"name":"Begin","type":"n8n-nodes-base.manualTrigger"}],"connections":{}}`,
+			expected: false,
+		},
+		{
+			name:     "invalid JSON",
+			b:        `[]`,
+			wantErrB: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(exported), types.StringValue(tc.b), normalizeOptionsArgument(tc.options...)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.BoolUnknown()),
+			}
+
+			NewWorkflowEqualFunction().Run(context.Background(), req, resp)
+
+			if tc.wantErrB {
+				if resp.Error == nil || resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != 1 {
+					t.Errorf("Expected error for the second argument, got %v", resp.Error)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("Unexpected error: %s", resp.Error)
+			}
+			if got := resp.Result.Value(); !got.Equal(types.BoolValue(tc.expected)) {
+				t.Errorf("Expected %t, got %s", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &workflowExportDataSource{}
+	_ datasource.DataSourceWithConfigure = &workflowExportDataSource{}
+)
+
+// NewWorkflowExportDataSource is a helper function to simplify the provider implementation.
+func NewWorkflowExportDataSource() datasource.DataSource {
+	return &workflowExportDataSource{}
+}
+
+// workflowExportDataSource is the data source implementation.
+type workflowExportDataSource struct {
+	client *client.Client
+}
+
+// workflowExportDataSourceModel maps the data source schema data.
+type workflowExportDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	WorkflowIDs    types.Set    `tfsdk:"workflow_ids"`
+	IgnoreUILayout types.Bool   `tfsdk:"ignore_ui_layout"`
+	Definitions    types.Map    `tfsdk:"definitions"`
+	WebhookURLs    types.List   `tfsdk:"webhook_urls"`
+}
+
+// workflowWebhookURLModel maps an element of webhook_urls.
+type workflowWebhookURLModel struct {
+	WorkflowID    types.String `tfsdk:"workflow_id"`
+	NodeName      types.String `tfsdk:"node_name"`
+	ProductionURL types.String `tfsdk:"production_url"`
+	TestURL       types.String `tfsdk:"test_url"`
+}
+
+// workflowWebhookURLType is the object type of the elements of webhook_urls.
+var workflowWebhookURLType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"workflow_id":    types.StringType,
+	"node_name":      types.StringType,
+	"production_url": types.StringType,
+	"test_url":       types.StringType,
+}}
+
+// Metadata returns the data source type name.
+func (d *workflowExportDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_export"
+}
+
+// Schema defines the schema for the data source.
+func (d *workflowExportDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exports the current definitions of workflows in normalized form, for backups and for diffing " +
+			"against the copies kept in Git. Server-managed fields, static data and pinned test data are left out, " +
+			"so definitions only change when the workflow does.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier of the export. The SHA-256 hash of the exported definitions.",
+				Computed:    true,
+			},
+			"workflow_ids": schema.SetAttribute{
+				Description: "The IDs of the workflows to export.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"ignore_ui_layout": schema.BoolAttribute{
+				Description: "Whether to leave out node positions and sticky notes, so rearranging the canvas does not change the export. Defaults to false.",
+				Optional:    true,
+			},
+			"definitions": schema.MapAttribute{
+				Description: "The normalized JSON definition of each workflow, keyed by workflow ID.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"webhook_urls": schema.ListNestedAttribute{
+				Description: "The endpoints of the webhook triggers of the workflows, ordered by workflow ID, e.g. to feed DNS " +
+					"records or monitors. The URLs use the webhook_base_url of the provider configuration, which defaults to its host; " +
+					"set it for instances serving webhooks on a separate host through WEBHOOK_URL, or reached through a unix domain socket.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"workflow_id": schema.StringAttribute{
+							Description: "The ID of the workflow.",
+							Computed:    true,
+						},
+						"node_name": schema.StringAttribute{
+							Description: "The name of the webhook node.",
+							Computed:    true,
+						},
+						"production_url": schema.StringAttribute{
+							Description: "The URL called once the workflow is active.",
+							Computed:    true,
+						},
+						"test_url": schema.StringAttribute{
+							Description: "The URL called while the workflow listens for a test event in the editor.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *workflowExportDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	n8nClient, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = n8nClient
+}
+
+// Read refreshes the Terraform state with the latest data.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (d *workflowExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, warnRetries := withRetryWarning(ctx)
+	defer warnRetries(&resp.Diagnostics)
+
+	var state workflowExportDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var workflowIDs []string
+	diags = state.WorkflowIDs.ElementsAs(ctx, &workflowIDs, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Exporting workflows", map[string]interface{}{
+		"workflow_ids": workflowIDs,
+	})
+
+	slices.Sort(workflowIDs)
+
+	definitions := make(map[string]string, len(workflowIDs))
+	var webhookURLs []workflowWebhookURLModel
+	for _, id := range workflowIDs {
+		workflow, err := d.client.GetWorkflow(ctx, id)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading workflow",
+				fmt.Sprintf("Could not read workflow ID %s: %s", id, errorDetail(err)),
+			)
+			return
+		}
+
+		definition, err := exportWorkflow(workflow, state.IgnoreUILayout.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error exporting workflow",
+				fmt.Sprintf("Could not export workflow ID %s: %s", id, err.Error()),
+			)
+			return
+		}
+		definitions[id] = definition
+
+		urls, err := workflowWebhookURLs(workflow, d.client.WebhookBaseURL())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error exporting workflow",
+				fmt.Sprintf("Could not read webhook URLs of workflow ID %s: %s", id, err.Error()),
+			)
+			return
+		}
+		webhookURLs = append(webhookURLs, urls...)
+	}
+
+	// Maps are encoded with sorted keys, so the hash does not depend on the
+	// order of workflow_ids.
+	document, err := json.Marshal(definitions)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error exporting workflows",
+			fmt.Sprintf("Could not encode exported workflows: %s", err.Error()),
+		)
+		return
+	}
+	sum := sha256.Sum256(document)
+
+	state.ID = types.StringValue(hex.EncodeToString(sum[:]))
+	state.Definitions, diags = types.MapValueFrom(ctx, types.StringType, definitions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.WebhookURLs, diags = types.ListValueFrom(ctx, workflowWebhookURLType, webhookURLs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Exported workflows", map[string]interface{}{
+		"workflow_count": len(definitions),
+	})
+}
+
+// exportWorkflow renders the normalized definition of a workflow. Pinned test
+// data may hold production records, so it is never exported.
+func exportWorkflow(workflow *models.Workflow, ignoreUILayout bool) (string, error) {
+	document, err := json.Marshal(workflow)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling workflow: %w", err)
+	}
+
+	definition, err := models.NormalizeWorkflowDefinition(document, models.NormalizeOptions{
+		IgnoreUILayout: ignoreUILayout,
+		IgnorePinData:  true,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(definition), nil
+}
+
+// workflowWebhookURLs returns the endpoints of the webhook triggers of a
+// workflow on the instance at baseURL.
+func workflowWebhookURLs(workflow *models.Workflow, baseURL string) ([]workflowWebhookURLModel, error) {
+	urls, err := workflow.WebhookURLs(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookURLs := make([]workflowWebhookURLModel, len(urls))
+	for i, url := range urls {
+		webhookURLs[i] = workflowWebhookURLModel{
+			WorkflowID:    types.StringValue(workflow.ID),
+			NodeName:      types.StringValue(url.NodeName),
+			ProductionURL: types.StringValue(url.Production),
+			TestURL:       types.StringValue(url.Test),
+		}
+	}
+	return webhookURLs, nil
+}
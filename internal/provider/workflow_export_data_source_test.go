@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestWorkflowExportDataSourceSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResponse := &datasource.SchemaResponse{}
+
+	NewWorkflowExportDataSource().Schema(ctx, datasource.SchemaRequest{}, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	for _, name := range []string{"id", "workflow_ids", "ignore_ui_layout", "definitions", "webhook_urls"} {
+		if _, ok := schemaResponse.Schema.Attributes[name]; !ok {
+			t.Errorf("missing attribute: %s", name)
+		}
+	}
+}
+
+func TestWorkflowExportDataSourceMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadataResponse := &datasource.MetadataResponse{}
+	NewWorkflowExportDataSource().Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "n8n"}, metadataResponse)
+
+	if metadataResponse.TypeName != "n8n_workflow_export" {
+		t.Errorf("Expected TypeName to be 'n8n_workflow_export', got '%s'", metadataResponse.TypeName)
+	}
+}
+
+func TestExportWorkflow(t *testing.T) {
+	t.Parallel()
+
+	workflow := &models.Workflow{
+		ID:        "1",
+		Name:      "Sync",
+		Active:    true,
+		VersionID: "v7",
+		Nodes: json.RawMessage(`[
+			{"name":"Start","type":"n8n-nodes-base.manualTrigger","position":[240,300],"disabled":false},
+			{"name":"Note","type":"n8n-nodes-base.stickyNote","position":[0,0]}
+		]`),
+		Connections: json.RawMessage(`{}`),
+		StaticData:  json.RawMessage(`{"lastId":5}`),
+		UpdatedAt:   "2024-01-01T00:00:00.000Z",
+	}
+
+	definition, err := exportWorkflow(workflow, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `{"connections":{},"name":"Sync","nodes":[` +
+		`{"name":"Note","position":[0,0],"type":"n8n-nodes-base.stickyNote"},` +
+		`{"name":"Start","position":[240,300],"type":"n8n-nodes-base.manualTrigger"}]}`
+	if definition != expected {
+		t.Errorf("Expected %s, got %s", expected, definition)
+	}
+
+	definition, err = exportWorkflow(workflow, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected = `{"connections":{},"name":"Sync","nodes":[{"name":"Start","type":"n8n-nodes-base.manualTrigger"}]}`
+	if definition != expected {
+		t.Errorf("Expected %s, got %s", expected, definition)
+	}
+}
+
+func TestWorkflowWebhookURLs(t *testing.T) {
+	t.Parallel()
+
+	workflow := &models.Workflow{
+		ID: "7",
+		Nodes: json.RawMessage(`[
+			{"name":"Orders","type":"n8n-nodes-base.webhook","parameters":{"path":"orders"}},
+			{"name":"Set","type":"n8n-nodes-base.set","parameters":{}}
+		]`),
+	}
+
+	urls, err := workflowWebhookURLs(workflow, "https://n8n.example.com/")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(urls) != 1 {
+		t.Fatalf("Expected 1 webhook URL, got %d", len(urls))
+	}
+
+	url := urls[0]
+	if url.WorkflowID.ValueString() != "7" || url.NodeName.ValueString() != "Orders" {
+		t.Errorf("Unexpected webhook: %+v", url)
+	}
+	if url.ProductionURL.ValueString() != "https://n8n.example.com/webhook/orders" {
+		t.Errorf("Unexpected production URL: %s", url.ProductionURL.ValueString())
+	}
+	if url.TestURL.ValueString() != "https://n8n.example.com/webhook-test/orders" {
+		t.Errorf("Unexpected test URL: %s", url.TestURL.ValueString())
+	}
+}
@@ -0,0 +1,1318 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &workflowResource{}
+	_ resource.ResourceWithConfigure   = &workflowResource{}
+	_ resource.ResourceWithImportState = &workflowResource{}
+	_ resource.ResourceWithModifyPlan  = &workflowResource{}
+
+	_ resource.ResourceWithConfigValidators = &workflowResource{}
+)
+
+// NewWorkflowResource is a helper function to simplify the provider implementation.
+func NewWorkflowResource() resource.Resource {
+	return &workflowResource{}
+}
+
+// workflowResource is the resource implementation.
+type workflowResource struct {
+	client *client.Client
+}
+
+// workflowResourceModel maps the resource schema data.
+type workflowResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Definition types.String `tfsdk:"definition"`
+	ProjectID  types.String `tfsdk:"project_id"`
+	Active     types.Bool   `tfsdk:"active"`
+	Archived   types.Bool   `tfsdk:"archived"`
+	VersionID  types.String `tfsdk:"version_id"`
+	Timeouts   types.Object `tfsdk:"timeouts"`
+
+	ArchiveOnDestroy      types.Bool   `tfsdk:"archive_on_destroy"`
+	ForceDestroy          types.Bool   `tfsdk:"force_destroy"`
+	RecentExecutionWindow types.String `tfsdk:"recent_execution_window"`
+
+	ExecutionOrder           types.String `tfsdk:"execution_order"`
+	SaveDataErrorExecution   types.String `tfsdk:"save_data_error_execution"`
+	SaveDataSuccessExecution types.String `tfsdk:"save_data_success_execution"`
+	SaveManualExecutions     types.Bool   `tfsdk:"save_manual_executions"`
+	SaveExecutionProgress    types.Bool   `tfsdk:"save_execution_progress"`
+	CallerPolicy             types.String `tfsdk:"caller_policy"`
+	CallerIDs                types.Set    `tfsdk:"caller_ids"`
+
+	AllowOverwriteRemoteChanges types.Bool `tfsdk:"allow_overwrite_remote_changes"`
+}
+
+// workflowSettingAttributes maps workflow settings to the attributes
+// managing them.
+var workflowSettingAttributes = map[string]string{
+	"executionOrder":           "execution_order",
+	"saveDataErrorExecution":   "save_data_error_execution",
+	"saveDataSuccessExecution": "save_data_success_execution",
+	"saveManualExecutions":     "save_manual_executions",
+	"saveExecutionProgress":    "save_execution_progress",
+	"callerPolicy":             "caller_policy",
+	"callerIds":                "caller_ids",
+}
+
+// workflowDefinition is the part of a workflow document the resource manages.
+type workflowDefinition struct {
+	Nodes       json.RawMessage `json:"nodes"`
+	Connections json.RawMessage `json:"connections"`
+	Settings    json.RawMessage `json:"settings,omitempty"`
+}
+
+// Metadata returns the resource type name.
+func (r *workflowResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow"
+}
+
+// Schema defines the schema for the resource.
+func (r *workflowResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a workflow in n8n. " +
+			"Updates are conditional: when the workflow was edited outside of Terraform, e.g. in the n8n editor, refreshing reports the edit " +
+			"and the apply fails instead of overwriting it, unless allow_overwrite_remote_changes is set.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the workflow.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the workflow.",
+				Required:    true,
+			},
+			"definition": schema.StringAttribute{
+				Description: "The workflow JSON document, e.g. as exported from the n8n editor or rendered with provider::n8n::render_workflow. " +
+					"Its nodes, connections and settings are managed; the name, ID, tags, active state and other fields in it are ignored. " +
+					"Settings set with attributes such as execution_order must not be set in it as well. " +
+					"Changes n8n makes to the document, such as key order, formatting or fields holding n8n's defaults, are not reported as drift. " +
+					"Test data pinned to nodes in the editor (pinData) is never sent to n8n and is not compared. " +
+					"Plans fail when Execute Workflow nodes call workflows that don't exist, and, with enable_internal_api set in the provider configuration, " +
+					"when the nodes use node types that are not installed on the instance.",
+				Required: true,
+				Validators: []validator.String{
+					workflowDefinitionValidator{},
+				},
+			},
+			"project_id": schema.StringAttribute{
+				Description: "The ID of the project the workflow belongs to. Defaults to the personal project of the API key owner. " +
+					"Changing this transfers the workflow to the new project.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"active": schema.BoolAttribute{
+				Description: "Whether the workflow is active, i.e. its triggers run and its webhooks accept requests. " +
+					"Activating waits until n8n reports the workflow as active, up to the activation_timeout of the provider configuration. " +
+					"With enable_internal_api set in the provider configuration, errors n8n records while registering triggers, e.g. for a missing credential, " +
+					"fail the activation, and refreshing warns about them. " +
+					"Leave unset to not manage the active state, e.g. when it is toggled in the editor.",
+				Optional: true,
+			},
+			"archived": schema.BoolAttribute{
+				Description: "Whether the workflow is archived. Archived workflows are deactivated and hidden in the editor " +
+					"but keep their definition and execution history; they cannot be changed until they are unarchived. " +
+					"Leave unset to not manage the archive state. Requires enable_internal_api in the provider configuration and n8n 1.94 or later.",
+				Optional: true,
+			},
+			"archive_on_destroy": schema.BoolAttribute{
+				Description: "Whether destroying the resource archives the workflow instead of deleting it, e.g. to keep it and its " +
+					"execution history for audits. Archived workflows can be restored or deleted in the n8n editor. " +
+					"Requires enable_internal_api in the provider configuration and n8n 1.94 or later. Defaults to false.",
+				Optional: true,
+			},
+			"force_destroy": forceDestroyAttribute(
+				"it is active or was executed within recent_execution_window",
+				"Its triggers and webhooks stop, so the automation no longer runs.",
+			),
+			"recent_execution_window": schema.StringAttribute{
+				Description: "How recently the workflow must have been executed for the execution to block destroying it as described for force_destroy, " +
+					"e.g. \"24h\". Defaults to \"0s\", i.e. only the active state is checked.",
+				Optional: true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"execution_order": schema.StringAttribute{
+				Description: "The execution order of the workflow's nodes: \"v1\", the order of the editor since n8n 1.0, or \"v0\", the legacy order. " +
+					"Leave unset to keep the executionOrder setting of the definition.",
+				Optional: true,
+				Validators: []validator.String{
+					stringOneOfValidator{values: []string{"v0", "v1"}},
+				},
+			},
+			"save_data_error_execution": schema.StringAttribute{
+				Description: "Whether the data of failed executions is saved: \"all\" or \"none\". " +
+					"Leave unset to keep the saveDataErrorExecution setting of the definition or the instance default.",
+				Optional: true,
+				Validators: []validator.String{
+					stringOneOfValidator{values: []string{"all", "none"}},
+				},
+			},
+			"save_data_success_execution": schema.StringAttribute{
+				Description: "Whether the data of successful executions is saved: \"all\" or \"none\". " +
+					"Leave unset to keep the saveDataSuccessExecution setting of the definition or the instance default.",
+				Optional: true,
+				Validators: []validator.String{
+					stringOneOfValidator{values: []string{"all", "none"}},
+				},
+			},
+			"save_manual_executions": schema.BoolAttribute{
+				Description: "Whether the data of executions started manually in the editor is saved. " +
+					"Leave unset to keep the saveManualExecutions setting of the definition or the instance default.",
+				Optional: true,
+			},
+			"save_execution_progress": schema.BoolAttribute{
+				Description: "Whether the data of each node is saved while the workflow runs, so failed executions can be resumed. " +
+					"Leave unset to keep the saveExecutionProgress setting of the definition or the instance default.",
+				Optional: true,
+			},
+			"caller_policy": schema.StringAttribute{
+				Description: "Which workflows may call this workflow as a sub-workflow: \"any\", \"none\", " +
+					"\"workflowsFromSameOwner\", i.e. workflows in the same project, or \"workflowsFromAList\", i.e. the workflows in caller_ids. " +
+					"Defaults to \"workflowsFromAList\" when caller_ids is set. Leave unset to keep the callerPolicy setting of the definition or n8n's default.",
+				Optional: true,
+				Validators: []validator.String{
+					stringOneOfValidator{values: []string{"any", "none", "workflowsFromSameOwner", "workflowsFromAList"}},
+				},
+			},
+			"caller_ids": schema.SetAttribute{
+				Description: "The IDs of the workflows allowed to call this workflow as a sub-workflow. " +
+					"Setting this restricts the callers to these workflows. Leave unset to keep the callerIds setting of the definition.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"version_id": schema.StringAttribute{
+				Description: "The version n8n assigned to the workflow when Terraform last changed or adopted it. " +
+					"Updates are only applied while the workflow still has this version. Edits made outside of Terraform are only adopted " +
+					"once the configuration matches them, so the version is kept until then.",
+				Computed: true,
+			},
+			"allow_overwrite_remote_changes": schema.BoolAttribute{
+				Description: "Whether to overwrite edits made to the workflow outside of Terraform, e.g. in the n8n editor, with the configured definition. " +
+					"When false, refreshing reports such edits and the apply fails until the configuration includes them. Defaults to false.",
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+// ConfigValidators returns validators that run against the configuration during
+// terraform validate, without requiring provider connectivity.
+func (r *workflowResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		// Archiving deactivates the workflow.
+		conflictingBoolsValidator{attributes: []string{"active", "archived"}},
+		workflowSettingsValidator{},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *workflowResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	n8nClient, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = n8nClient
+}
+
+// Create creates the resource and sets the initial Terraform state.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (r *workflowResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, warnRetries := withRetryWarning(ctx)
+	defer warnRetries(&resp.Diagnostics)
+
+	var plan workflowResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workflow, err := plan.workflow()
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("definition"), "Invalid Workflow Definition", err.Error())
+		return
+	}
+
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, timeoutCreate)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Creating workflow", map[string]interface{}{
+		"name": plan.Name.ValueString(),
+	})
+
+	createdWorkflow, err := r.client.CreateWorkflow(ctx, workflow)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating workflow",
+			fmt.Sprintf("Could not create workflow, unexpected error: %s", errorDetail(err)),
+		)
+		return
+	}
+
+	// The definition is kept as configured; n8n's rendering of it is only
+	// compared on refresh.
+	plan.ID = types.StringValue(createdWorkflow.ID)
+	plan.Name = types.StringValue(createdWorkflow.Name)
+	plan.VersionID = types.StringValue(createdWorkflow.VersionID)
+
+	plan.ProjectID, diags = r.transferCreatedWorkflow(ctx, createdWorkflow, plan.ProjectID)
+	resp.Diagnostics.Append(diags...)
+
+	if plan.Active.ValueBool() {
+		if err := r.setActive(ctx, createdWorkflow.ID, true); err != nil {
+			resp.Diagnostics.AddError(
+				"Error activating workflow",
+				fmt.Sprintf("Workflow ID %s was created but could not be activated: %s", createdWorkflow.ID, errorDetail(err)),
+			)
+			plan.Active = types.BoolValue(false)
+		}
+	}
+
+	if plan.Archived.ValueBool() {
+		archivedWorkflow, err := r.setArchived(ctx, createdWorkflow.ID, true)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error archiving workflow",
+				fmt.Sprintf("Workflow ID %s was created but could not be archived: %s", createdWorkflow.ID, errorDetail(err)),
+			)
+			plan.Archived = types.BoolValue(false)
+		} else {
+			plan.VersionID = types.StringValue(archivedWorkflow.VersionID)
+		}
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Created workflow", map[string]interface{}{
+		"id":   createdWorkflow.ID,
+		"name": createdWorkflow.Name,
+	})
+}
+
+// Read refreshes the Terraform state with the latest data. The definition in
+// state is only replaced when it differs semantically from the workflow in
+// n8n, so n8n's formatting of the document does not show up as drift.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (r *workflowResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, warnRetries := withRetryWarning(ctx)
+	defer warnRetries(&resp.Diagnostics)
+
+	var state workflowResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Reading workflow", map[string]interface{}{
+		"id": state.ID.ValueString(),
+	})
+
+	workflow, err := r.client.GetWorkflow(ctx, state.ID.ValueString())
+	var notFound *client.NotFoundError
+	if errors.As(err, &notFound) {
+		// The workflow was deleted outside of Terraform, so remove it from
+		// state and let Terraform plan its recreation.
+		tflog.Warn(ctx, "Workflow not found, removing from state", map[string]interface{}{
+			"id": state.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading workflow",
+			fmt.Sprintf("Could not read workflow ID %s: %s", state.ID.ValueString(), errorDetail(err)),
+		)
+		return
+	}
+
+	drifted, err := definitionDrifted(state.Definition, workflow)
+	// Imported workflows have no known version yet.
+	modified := !state.VersionID.IsNull() && state.VersionID.ValueString() != workflow.VersionID &&
+		(drifted || state.Name.ValueString() != workflow.Name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading workflow",
+			fmt.Sprintf("Could not compare the definition of workflow ID %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+	if drifted {
+		definition, err := json.Marshal(workflowDefinition{
+			Nodes:       workflow.Nodes,
+			Connections: workflow.Connections,
+			Settings:    workflow.Settings,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading workflow",
+				fmt.Sprintf("Could not encode the definition of workflow ID %s: %s", state.ID.ValueString(), err),
+			)
+			return
+		}
+		state.Definition = types.StringValue(string(definition))
+	}
+
+	// A new version whose name and definition are unchanged, e.g. from saving
+	// in the editor without changes, is adopted. Otherwise the known version is
+	// kept, so an update fails instead of overwriting the edit, until the
+	// configuration matches it again.
+	if modified {
+		detail := fmt.Sprintf("Workflow ID %s was edited outside of Terraform, e.g. in the n8n editor: its version changed from %s to %s. "+
+			"The plan shows the edit as a difference to the configuration. ",
+			state.ID.ValueString(), state.VersionID.ValueString(), workflow.VersionID)
+		if state.AllowOverwriteRemoteChanges.ValueBool() {
+			detail += "As allow_overwrite_remote_changes is set, applying overwrites the edit."
+		} else {
+			detail += "Copy the edit into the configuration to keep it, or set allow_overwrite_remote_changes to overwrite it; " +
+				"until then, applying changes to the workflow fails."
+		}
+		resp.Diagnostics.AddWarning("Workflow Modified Outside of Terraform", detail)
+	} else {
+		state.VersionID = types.StringValue(workflow.VersionID)
+	}
+
+	state.ID = types.StringValue(workflow.ID)
+	state.Name = types.StringValue(workflow.Name)
+	// Not every n8n version reports the owning project.
+	if workflow.HomeProject != nil {
+		state.ProjectID = types.StringValue(workflow.HomeProject.ID)
+	}
+	// The active state is only refreshed when managed.
+	if !state.Active.IsNull() {
+		state.Active = types.BoolValue(workflow.Active)
+		if workflow.Active {
+			resp.Diagnostics.Append(activationErrorDiagnostics(ctx, r.client, workflow.ID)...)
+		}
+	}
+	if !state.Archived.IsNull() {
+		state.Archived = types.BoolValue(workflow.IsArchived)
+	}
+	if err := state.refreshSettings(workflow); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading workflow",
+			fmt.Sprintf("Could not read the settings of workflow ID %s: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Read workflow", map[string]interface{}{
+		"id":   workflow.ID,
+		"name": workflow.Name,
+	})
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+// The update fails when the workflow no longer has the version in state.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (r *workflowResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, warnRetries := withRetryWarning(ctx)
+	defer warnRetries(&resp.Diagnostics)
+
+	var plan workflowResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state workflowResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workflow, err := plan.workflow()
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("definition"), "Invalid Workflow Definition", err.Error())
+		return
+	}
+
+	ctx, cancel, diags := withOperationTimeout(ctx, plan.Timeouts, timeoutUpdate)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	plan.VersionID = state.VersionID
+
+	// Archived workflows cannot be changed, so they are unarchived first and
+	// archived last. Both create a new version.
+	if !plan.Archived.IsNull() && !plan.Archived.ValueBool() && state.Archived.ValueBool() {
+		unarchivedWorkflow, err := r.setArchived(ctx, state.ID.ValueString(), false)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error unarchiving workflow",
+				fmt.Sprintf("Could not unarchive workflow ID %s: %s", state.ID.ValueString(), errorDetail(err)),
+			)
+			return
+		}
+		plan.VersionID = types.StringValue(unarchivedWorkflow.VersionID)
+	}
+
+	// Changes limited to the active state or provider-side settings such as
+	// timeouts don't create a new version of the workflow.
+	if !plan.Name.Equal(state.Name) || !plan.Definition.Equal(state.Definition) || plan.settingsChanged(&state) {
+		tflog.Info(ctx, "Updating workflow", map[string]interface{}{
+			"id":         state.ID.ValueString(),
+			"name":       plan.Name.ValueString(),
+			"version_id": plan.VersionID.ValueString(),
+		})
+
+		// An empty version makes the update unconditional. Archived
+		// workflows cannot be edited, so unarchiving doesn't hide edits.
+		versionID := plan.VersionID.ValueString()
+		if plan.AllowOverwriteRemoteChanges.ValueBool() {
+			versionID = ""
+		}
+
+		updatedWorkflow, err := r.updateWorkflow(ctx, &state, workflow, versionID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating workflow",
+				fmt.Sprintf("Could not update workflow ID %s: %s", state.ID.ValueString(), errorDetail(err)),
+			)
+			return
+		}
+
+		plan.Name = types.StringValue(updatedWorkflow.Name)
+		plan.VersionID = types.StringValue(updatedWorkflow.VersionID)
+	}
+
+	if plan.ProjectID.IsUnknown() {
+		plan.ProjectID = state.ProjectID
+	}
+	if !plan.ProjectID.IsNull() && !plan.ProjectID.Equal(state.ProjectID) {
+		tflog.Info(ctx, "Transferring workflow", map[string]interface{}{
+			"id":         state.ID.ValueString(),
+			"project_id": plan.ProjectID.ValueString(),
+		})
+
+		if err := r.client.TransferWorkflow(ctx, state.ID.ValueString(), plan.ProjectID.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error transferring workflow",
+				fmt.Sprintf("Could not transfer workflow ID %s to project %s: %s", state.ID.ValueString(), plan.ProjectID.ValueString(), errorDetail(err)),
+			)
+			plan.ProjectID = state.ProjectID
+		}
+	}
+
+	if !plan.Active.IsNull() && !plan.Active.Equal(state.Active) {
+		if err := r.setActive(ctx, state.ID.ValueString(), plan.Active.ValueBool()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error changing the active state of workflow",
+				fmt.Sprintf("Could not change the active state of workflow ID %s: %s", state.ID.ValueString(), errorDetail(err)),
+			)
+			plan.Active = state.Active
+		}
+	}
+
+	if plan.Archived.ValueBool() && !state.Archived.ValueBool() {
+		archivedWorkflow, err := r.setArchived(ctx, state.ID.ValueString(), true)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error archiving workflow",
+				fmt.Sprintf("Could not archive workflow ID %s: %s", state.ID.ValueString(), errorDetail(err)),
+			)
+			plan.Archived = state.Archived
+		} else {
+			plan.VersionID = types.StringValue(archivedWorkflow.VersionID)
+		}
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Updated workflow", map[string]interface{}{
+		"id":         plan.ID.ValueString(),
+		"version_id": plan.VersionID.ValueString(),
+	})
+}
+
+// updateWorkflow saves the planned workflow. When the internal API is enabled
+// and the node graph is unchanged, only the name and settings are sent, so the
+// nodes are not re-uploaded over concurrent edits in the editor. Otherwise,
+// e.g. after an import, the whole definition is replaced.
+func (r *workflowResource) updateWorkflow(ctx context.Context, state *workflowResourceModel, workflow *models.Workflow, versionID string) (*models.Workflow, error) {
+	if !r.client.InternalAPIEnabled() || state.Definition.IsNull() {
+		return r.client.UpdateWorkflow(ctx, state.ID.ValueString(), workflow, versionID)
+	}
+
+	stateWorkflow, err := state.workflow()
+	if err != nil {
+		return r.client.UpdateWorkflow(ctx, state.ID.ValueString(), workflow, versionID)
+	}
+	equal, err := models.NodeGraphEqual(workflow, stateWorkflow)
+	if err != nil || !equal {
+		return r.client.UpdateWorkflow(ctx, state.ID.ValueString(), workflow, versionID)
+	}
+
+	tflog.Debug(ctx, "Node graph unchanged, updating workflow metadata only", map[string]interface{}{
+		"id": state.ID.ValueString(),
+	})
+
+	settings := workflow.Settings
+	if len(settings) == 0 {
+		settings = json.RawMessage(`{}`)
+	}
+	return r.client.UpdateWorkflowMetadata(ctx, state.ID.ValueString(), &models.WorkflowMetadataUpdateRequest{
+		Name:      workflow.Name,
+		Settings:  settings,
+		VersionID: versionID,
+	})
+}
+
+// transferCreatedWorkflow moves a workflow that was just created into the
+// requested project and returns the project_id to save. When the transfer
+// fails, the workflow is saved all the same, so the failure is a warning and
+// the project n8n reports is saved instead: the next apply transfers it again.
+func (r *workflowResource) transferCreatedWorkflow(ctx context.Context, workflow *models.Workflow, projectID types.String) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	reported := types.StringNull()
+	if workflow.HomeProject != nil {
+		reported = types.StringValue(workflow.HomeProject.ID)
+	}
+
+	if projectID.IsNull() || projectID.IsUnknown() {
+		return reported, diags
+	}
+
+	tflog.Info(ctx, "Transferring workflow", map[string]interface{}{
+		"id":         workflow.ID,
+		"project_id": projectID.ValueString(),
+	})
+
+	if err := r.client.TransferWorkflow(ctx, workflow.ID, projectID.ValueString()); err != nil {
+		diags.AddWarning(
+			"Workflow not transferred",
+			fmt.Sprintf("Workflow ID %s was created but could not be transferred to project %s: %s. "+
+				"The next apply transfers it again.", workflow.ID, projectID.ValueString(), errorDetail(err)),
+		)
+		return reported, diags
+	}
+
+	return projectID, diags
+}
+
+// ModifyPlan checks that the instance supports the archive attributes and
+// the node types of the definition, and that the sub-workflows it calls
+// exist, so a missing internal API, community package or sub-workflow fails
+// the plan instead of the apply.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (r *workflowResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy, or when validating offline.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan workflowResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Archived.IsNull() {
+		resp.Diagnostics.Append(internalAPIDiagnostics(r.client, path.Root("archived"))...)
+	}
+	if plan.ArchiveOnDestroy.ValueBool() {
+		resp.Diagnostics.Append(internalAPIDiagnostics(r.client, path.Root("archive_on_destroy"))...)
+	}
+
+	creating := req.State.Raw.IsNull()
+	var state workflowResourceModel
+	if !creating {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// The definition is only checked when it changes.
+	if plan.Definition.IsUnknown() || (!creating && plan.Definition.Equal(state.Definition)) {
+		return
+	}
+
+	// Listing node types needs the internal API, so the check is skipped
+	// without it.
+	if r.client.InternalAPIEnabled() {
+		resp.Diagnostics.Append(missingNodeTypeDiagnostics(ctx, r.client, &plan)...)
+	}
+	resp.Diagnostics.Append(missingSubWorkflowDiagnostics(ctx, r.client, &plan)...)
+}
+
+// missingNodeTypeDiagnostics returns an error on the definition when it uses
+// node types that are not installed on the instance, naming the packages that
+// provide them.
+func missingNodeTypeDiagnostics(ctx context.Context, n8nClient *client.Client, plan *workflowResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Invalid definitions are reported by the validator.
+	workflow, err := plan.workflow()
+	if err != nil {
+		return diags
+	}
+
+	missing, err := n8nClient.MissingNodeTypes(ctx, workflow)
+	if err != nil {
+		diags.AddWarning(
+			"Node Types Not Checked",
+			fmt.Sprintf("Could not check the node types of the workflow definition against the instance: %s", errorDetail(err)),
+		)
+		return diags
+	}
+	if len(missing) == 0 {
+		return diags
+	}
+
+	var packages []string
+	for _, nodeType := range missing {
+		if name := models.NodeTypePackage(nodeType); !slices.Contains(packages, name) {
+			packages = append(packages, name)
+		}
+	}
+
+	diags.AddAttributeError(
+		path.Root("definition"),
+		"Missing Node Types",
+		fmt.Sprintf("The workflow definition uses node types that are not installed on the instance: %s. "+
+			"Install the packages providing them (%s), e.g. under Settings > Community nodes in the n8n editor, or remove the nodes.",
+			strings.Join(missing, ", "), strings.Join(packages, ", ")),
+	)
+	return diags
+}
+
+// activationErrorDiagnostics warns when n8n recorded an error activating the
+// workflow, which is then reported as active while its triggers don't run.
+// Checking needs the internal API and is best effort.
+func activationErrorDiagnostics(ctx context.Context, n8nClient *client.Client, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	err := n8nClient.CheckActivationError(ctx, id)
+	var activationErr *client.ActivationError
+	if errors.As(err, &activationErr) {
+		diags.AddWarning(
+			"Workflow Activation Failed",
+			fmt.Sprintf("Workflow ID %s is active, but n8n reported an error registering its triggers and webhooks, so it may not run: %s. "+
+				"Fix the cause, e.g. a missing credential, then deactivate and activate the workflow again.", id, activationErr.Message),
+		)
+	} else if err != nil {
+		tflog.Warn(ctx, "Could not check the activation of the workflow", map[string]interface{}{
+			"id":    id,
+			"error": err.Error(),
+		})
+	}
+	return diags
+}
+
+// missingSubWorkflowDiagnostics returns an error on the definition when its
+// Execute Workflow nodes call workflows that don't exist on the instance.
+// Sub-workflows managed in the same configuration are created first when the
+// definition references their IDs, e.g. n8n_workflow.child.id, as the
+// definition is unknown until then and not checked.
+func missingSubWorkflowDiagnostics(ctx context.Context, n8nClient *client.Client, plan *workflowResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Invalid definitions are reported by the validator.
+	workflow, err := plan.workflow()
+	if err != nil {
+		return diags
+	}
+
+	missing, err := n8nClient.MissingSubWorkflows(ctx, workflow)
+	if err != nil {
+		diags.AddWarning(
+			"Sub-Workflows Not Checked",
+			fmt.Sprintf("Could not check the sub-workflows called by the workflow definition: %s", errorDetail(err)),
+		)
+		return diags
+	}
+	if len(missing) == 0 {
+		return diags
+	}
+
+	descriptions := make([]string, 0, len(missing))
+	for _, reference := range missing {
+		description := fmt.Sprintf("  - node %q calls workflow ID %s", reference.NodeName, reference.WorkflowID)
+		if reference.WorkflowName != "" {
+			description += fmt.Sprintf(" (%q)", reference.WorkflowName)
+		}
+		descriptions = append(descriptions, description)
+	}
+
+	diags.AddAttributeError(
+		path.Root("definition"),
+		"Missing Sub-Workflows",
+		fmt.Sprintf("The workflow definition calls workflows that don't exist on the instance:\n%s\n\n"+
+			"Create them first. When they are managed in the same configuration, use their IDs in the definition, "+
+			"e.g. n8n_workflow.child.id, so Terraform creates them before this workflow.", strings.Join(descriptions, "\n")),
+	)
+	return diags
+}
+
+// setActive activates or deactivates a workflow. Activating waits until n8n
+// reports the workflow as active.
+func (r *workflowResource) setActive(ctx context.Context, id string, active bool) error {
+	tflog.Info(ctx, "Changing the active state of workflow", map[string]interface{}{
+		"id":     id,
+		"active": active,
+	})
+
+	var err error
+	if active {
+		_, err = r.client.ActivateWorkflow(ctx, id)
+	} else {
+		_, err = r.client.DeactivateWorkflow(ctx, id)
+	}
+	return err
+}
+
+// setArchived archives or unarchives a workflow.
+func (r *workflowResource) setArchived(ctx context.Context, id string, archived bool) (*models.Workflow, error) {
+	tflog.Info(ctx, "Changing the archive state of workflow", map[string]interface{}{
+		"id":       id,
+		"archived": archived,
+	})
+
+	if archived {
+		return r.client.ArchiveWorkflow(ctx, id)
+	}
+	return r.client.UnarchiveWorkflow(ctx, id)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (r *workflowResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, warnRetries := withRetryWarning(ctx)
+	defer warnRetries(&resp.Diagnostics)
+
+	var state workflowResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withOperationTimeout(ctx, state.Timeouts, timeoutDelete)
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(workflowDestroyDiagnostics(ctx, r.client, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.ArchiveOnDestroy.ValueBool() {
+		r.archiveOnDestroy(ctx, &state, resp)
+		return
+	}
+
+	tflog.Info(ctx, "Deleting workflow", map[string]interface{}{
+		"id": state.ID.ValueString(),
+	})
+
+	err := r.client.DeleteWorkflow(ctx, state.ID.ValueString())
+	var notFound *client.NotFoundError
+	if errors.As(err, &notFound) {
+		// The workflow was already deleted outside of Terraform.
+		tflog.Warn(ctx, "Workflow not found, removing from state", map[string]interface{}{
+			"id": state.ID.ValueString(),
+		})
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting workflow",
+			fmt.Sprintf("Could not delete workflow ID %s: %s", state.ID.ValueString(), errorDetail(err)),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "Deleted workflow", map[string]interface{}{
+		"id": state.ID.ValueString(),
+	})
+}
+
+// workflowDestroyDiagnostics reports that a workflow which is about to be
+// deleted or archived is still in use, i.e. active or executed within
+// recent_execution_window, as selected by force_destroy. Failing to check is
+// not fatal; the check is best effort.
+func workflowDestroyDiagnostics(ctx context.Context, n8nClient *client.Client, state *workflowResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if state.ForceDestroy.ValueBool() {
+		return diags
+	}
+
+	id := state.ID.ValueString()
+	workflow, err := n8nClient.GetWorkflow(ctx, id)
+	if err != nil {
+		tflog.Warn(ctx, "Could not check whether the workflow is in use", map[string]interface{}{
+			"id":    id,
+			"error": err.Error(),
+		})
+		return diags
+	}
+
+	var dependents []destroyDependent
+	if workflow.Active {
+		dependents = append(dependents, destroyDependent{
+			Description: "Its triggers and webhooks",
+			Detail:      "the workflow is active",
+		})
+	}
+
+	if window := durationValue(state.RecentExecutionWindow, 0); window > 0 {
+		execution, err := n8nClient.LastExecution(ctx, id)
+		if err != nil {
+			tflog.Warn(ctx, "Could not check the executions of the workflow", map[string]interface{}{
+				"id":    id,
+				"error": err.Error(),
+			})
+		} else if execution != nil {
+			startedAt, err := time.Parse(time.RFC3339, execution.StartedAt)
+			if err == nil && time.Since(startedAt) < window {
+				dependents = append(dependents, destroyDependent{
+					Description: "Execution " + execution.ID.String(),
+					Detail:      fmt.Sprintf("started at %s, within the recent_execution_window of %s", execution.StartedAt, window),
+				})
+			}
+		}
+	}
+
+	return forceDestroyDiagnostics(state.ForceDestroy, "workflow ID "+id,
+		"Deactivate the workflow first, e.g. by setting active = false, and wait until it is no longer executed.", dependents)
+}
+
+// archiveOnDestroy archives the workflow instead of deleting it. Workflows
+// that are already archived or gone are left as they are.
+func (r *workflowResource) archiveOnDestroy(ctx context.Context, state *workflowResourceModel, resp *resource.DeleteResponse) {
+	workflow, err := r.client.GetWorkflow(ctx, state.ID.ValueString())
+	var notFound *client.NotFoundError
+	if errors.As(err, &notFound) {
+		tflog.Warn(ctx, "Workflow not found, removing from state", map[string]interface{}{
+			"id": state.ID.ValueString(),
+		})
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error archiving workflow",
+			fmt.Sprintf("Could not read workflow ID %s: %s", state.ID.ValueString(), errorDetail(err)),
+		)
+		return
+	}
+	if workflow.IsArchived {
+		tflog.Info(ctx, "Workflow already archived", map[string]interface{}{
+			"id": state.ID.ValueString(),
+		})
+		return
+	}
+
+	if _, err := r.setArchived(ctx, state.ID.ValueString(), true); err != nil {
+		resp.Diagnostics.AddError(
+			"Error archiving workflow",
+			fmt.Sprintf("Could not archive workflow ID %s instead of deleting it: %s", state.ID.ValueString(), errorDetail(err)),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "Archived workflow instead of deleting it", map[string]interface{}{
+		"id": state.ID.ValueString(),
+	})
+}
+
+// ImportState imports the resource by workflow ID. The definition is read
+// from n8n on the following refresh.
+func (r *workflowResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// workflow returns the workflow described by the model.
+func (m *workflowResourceModel) workflow() (*models.Workflow, error) {
+	definition, err := parseWorkflowDefinition(m.Definition.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	workflow := &models.Workflow{
+		Name:        m.Name.ValueString(),
+		Nodes:       definition.Nodes,
+		Connections: definition.Connections,
+		Settings:    definition.Settings,
+	}
+	if err := workflow.ApplySettings(m.settings()); err != nil {
+		return nil, err
+	}
+	return workflow, nil
+}
+
+// settings returns the workflow settings set by the settings attributes.
+func (m *workflowResourceModel) settings() *models.WorkflowSettings {
+	settings := &models.WorkflowSettings{
+		ExecutionOrder:           m.ExecutionOrder.ValueString(),
+		SaveDataErrorExecution:   m.SaveDataErrorExecution.ValueString(),
+		SaveDataSuccessExecution: m.SaveDataSuccessExecution.ValueString(),
+		SaveManualExecutions:     m.SaveManualExecutions.ValueBoolPointer(),
+		SaveExecutionProgress:    m.SaveExecutionProgress.ValueBoolPointer(),
+	}
+
+	if !m.CallerIDs.IsNull() && !m.CallerIDs.IsUnknown() {
+		ids := make([]string, 0, len(m.CallerIDs.Elements()))
+		for _, element := range m.CallerIDs.Elements() {
+			if id, ok := element.(types.String); ok && !id.IsNull() && !id.IsUnknown() {
+				ids = append(ids, id.ValueString())
+			}
+		}
+		slices.Sort(ids)
+		settings.SetCallerIDList(ids)
+	}
+	if !m.CallerPolicy.IsNull() {
+		settings.CallerPolicy = m.CallerPolicy.ValueString()
+	}
+	return settings
+}
+
+// settingsChanged reports whether a settings attribute set in the model
+// differs from other. Unsetting an attribute only stops managing the setting.
+func (m *workflowResourceModel) settingsChanged(other *workflowResourceModel) bool {
+	return (!m.ExecutionOrder.IsNull() && !m.ExecutionOrder.Equal(other.ExecutionOrder)) ||
+		(!m.SaveDataErrorExecution.IsNull() && !m.SaveDataErrorExecution.Equal(other.SaveDataErrorExecution)) ||
+		(!m.SaveDataSuccessExecution.IsNull() && !m.SaveDataSuccessExecution.Equal(other.SaveDataSuccessExecution)) ||
+		(!m.SaveManualExecutions.IsNull() && !m.SaveManualExecutions.Equal(other.SaveManualExecutions)) ||
+		(!m.SaveExecutionProgress.IsNull() && !m.SaveExecutionProgress.Equal(other.SaveExecutionProgress)) ||
+		(!m.CallerPolicy.IsNull() && !m.CallerPolicy.Equal(other.CallerPolicy)) ||
+		(!m.CallerIDs.IsNull() && !m.CallerIDs.Equal(other.CallerIDs))
+}
+
+// refreshSettings sets the settings attributes that are managed, i.e. not
+// null, from the settings of the workflow in n8n.
+func (m *workflowResourceModel) refreshSettings(workflow *models.Workflow) error {
+	if m.ExecutionOrder.IsNull() && m.SaveDataErrorExecution.IsNull() && m.SaveDataSuccessExecution.IsNull() &&
+		m.SaveManualExecutions.IsNull() && m.SaveExecutionProgress.IsNull() && m.CallerPolicy.IsNull() && m.CallerIDs.IsNull() {
+		return nil
+	}
+
+	settings, err := workflow.ParseSettings()
+	if err != nil {
+		return err
+	}
+	if !m.ExecutionOrder.IsNull() {
+		m.ExecutionOrder = settingValue(settings.ExecutionOrder)
+	}
+	if !m.SaveDataErrorExecution.IsNull() {
+		m.SaveDataErrorExecution = settingValue(settings.SaveDataErrorExecution)
+	}
+	if !m.SaveDataSuccessExecution.IsNull() {
+		m.SaveDataSuccessExecution = settingValue(settings.SaveDataSuccessExecution)
+	}
+	if !m.SaveManualExecutions.IsNull() {
+		m.SaveManualExecutions = types.BoolPointerValue(settings.SaveManualExecutions)
+	}
+	if !m.SaveExecutionProgress.IsNull() {
+		m.SaveExecutionProgress = types.BoolPointerValue(settings.SaveExecutionProgress)
+	}
+	if !m.CallerPolicy.IsNull() {
+		m.CallerPolicy = settingValue(settings.CallerPolicy)
+	}
+	if !m.CallerIDs.IsNull() {
+		ids := make([]attr.Value, 0)
+		for _, id := range settings.CallerIDList() {
+			ids = append(ids, types.StringValue(id))
+		}
+		m.CallerIDs = types.SetValueMust(types.StringType, ids)
+	}
+	return nil
+}
+
+// settingValue returns the value of a string setting, null when it is not set.
+func settingValue(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// parseWorkflowDefinition extracts the managed part of a workflow document.
+// Missing connections default to none.
+func parseWorkflowDefinition(document string) (*workflowDefinition, error) {
+	var definition workflowDefinition
+	if err := json.Unmarshal([]byte(document), &definition); err != nil {
+		return nil, fmt.Errorf("error parsing workflow definition: %w", err)
+	}
+	if len(definition.Nodes) == 0 || string(definition.Nodes) == "null" {
+		return nil, fmt.Errorf("workflow definition has no nodes")
+	}
+	if len(definition.Connections) == 0 || string(definition.Connections) == "null" {
+		definition.Connections = json.RawMessage(`{}`)
+	}
+	return &definition, nil
+}
+
+// definitionDrifted reports whether the workflow in n8n differs semantically
+// from the definition in state. n8n adds settings with their defaults, so only
+// the settings the definition sets are compared. A null definition, e.g.
+// after an import, always counts as drifted.
+func definitionDrifted(stateDefinition types.String, workflow *models.Workflow) (bool, error) {
+	if stateDefinition.IsNull() || stateDefinition.IsUnknown() {
+		return true, nil
+	}
+
+	desired, err := parseWorkflowDefinition(stateDefinition.ValueString())
+	if err != nil {
+		// A definition n8n can't have produced is replaced by the remote one.
+		return true, nil
+	}
+
+	settings, err := settingsSubset(workflow.Settings, desired.Settings)
+	if err != nil {
+		return false, err
+	}
+
+	desiredDocument, err := json.Marshal(desired)
+	if err != nil {
+		return false, fmt.Errorf("error encoding workflow definition: %w", err)
+	}
+	remoteDocument, err := json.Marshal(workflowDefinition{
+		Nodes:       workflow.Nodes,
+		Connections: workflow.Connections,
+		Settings:    settings,
+	})
+	if err != nil {
+		return false, fmt.Errorf("error encoding workflow %s: %w", workflow.ID, err)
+	}
+
+	equal, err := models.WorkflowDefinitionsEqual(desiredDocument, remoteDocument, models.NormalizeOptions{})
+	if err != nil {
+		return false, err
+	}
+	return !equal, nil
+}
+
+// settingsSubset returns the settings of remote whose keys are set in desired.
+func settingsSubset(remote, desired json.RawMessage) (json.RawMessage, error) {
+	var desiredSettings map[string]json.RawMessage
+	if len(desired) > 0 {
+		if err := json.Unmarshal(desired, &desiredSettings); err != nil {
+			return nil, fmt.Errorf("error parsing workflow settings: %w", err)
+		}
+	}
+	var remoteSettings map[string]json.RawMessage
+	if len(remote) > 0 {
+		if err := json.Unmarshal(remote, &remoteSettings); err != nil {
+			return nil, fmt.Errorf("error parsing workflow settings: %w", err)
+		}
+	}
+
+	subset := make(map[string]json.RawMessage, len(desiredSettings))
+	for key := range desiredSettings {
+		if value, ok := remoteSettings[key]; ok {
+			subset[key] = value
+		}
+	}
+
+	encoded, err := json.Marshal(subset)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding workflow settings: %w", err)
+	}
+	return encoded, nil
+}
+
+// workflowDefinitionValidator validates that a string is a workflow JSON
+// document with nodes.
+type workflowDefinitionValidator struct{}
+
+// Description returns a plain text description of the validator's behavior.
+func (v workflowDefinitionValidator) Description(_ context.Context) string {
+	return "value must be a workflow JSON document with nodes"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v workflowDefinitionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (v workflowDefinitionValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	definition, err := parseWorkflowDefinition(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Workflow Definition", err.Error())
+		return
+	}
+
+	settings, err := (&models.Workflow{Settings: definition.Settings}).ParseSettings()
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Workflow Definition", err.Error())
+		return
+	}
+	for _, problem := range settings.Validate() {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Workflow Definition", problem.Error())
+	}
+}
+
+// workflowSettingsValidator is a resource config validator that ensures the
+// settings attributes agree with each other and don't set settings the
+// definition sets as well, which would be reported as drift of the definition
+// after every apply.
+type workflowSettingsValidator struct{}
+
+var _ resource.ConfigValidator = workflowSettingsValidator{}
+
+// Description returns a human-readable description of the validator.
+func (v workflowSettingsValidator) Description(_ context.Context) string {
+	return "settings attributes must agree and must not set settings the definition sets"
+}
+
+// MarkdownDescription returns a markdown formatted human-readable description of the validator.
+func (v workflowSettingsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource implements the validation logic.
+//
+//nolint:gocritic // req parameter signature required by terraform-plugin-framework interface
+func (v workflowSettingsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config workflowResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	if !config.CallerIDs.IsNull() && !config.CallerPolicy.IsNull() && !config.CallerPolicy.IsUnknown() &&
+		config.CallerPolicy.ValueString() != "workflowsFromAList" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("caller_ids"),
+			"Conflicting Workflow Setting",
+			fmt.Sprintf("caller_ids only applies when caller_policy is \"workflowsFromAList\", but it is %q.", config.CallerPolicy.ValueString()),
+		)
+	}
+
+	if config.Definition.IsNull() || config.Definition.IsUnknown() {
+		return
+	}
+
+	// Invalid definitions are reported by the definition's validator.
+	definition, err := parseWorkflowDefinition(config.Definition.ValueString())
+	if err != nil || len(definition.Settings) == 0 {
+		return
+	}
+	var defined map[string]json.RawMessage
+	if err := json.Unmarshal(definition.Settings, &defined); err != nil {
+		return
+	}
+
+	// The settings attributes that are set encode as the settings they set.
+	encoded, err := json.Marshal(config.settings())
+	if err != nil {
+		return
+	}
+	var managed map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &managed); err != nil {
+		return
+	}
+
+	settings := make([]string, 0, len(managed))
+	for setting := range managed {
+		settings = append(settings, setting)
+	}
+	slices.Sort(settings)
+
+	for _, setting := range settings {
+		if _, ok := defined[setting]; !ok {
+			continue
+		}
+		attribute := workflowSettingAttributes[setting]
+		// caller_ids sets the caller policy as well.
+		if setting == "callerPolicy" && config.CallerPolicy.IsNull() {
+			attribute = "caller_ids"
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root(attribute),
+			"Conflicting Workflow Setting",
+			fmt.Sprintf("The definition sets the %s setting as well. Remove it from the definition's settings or unset %s.", setting, attribute),
+		)
+	}
+}
@@ -0,0 +1,1012 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/artus-engineering/terraform-provider-n8n/internal/n8ntest"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const testWorkflowDefinition = `{
+  "name": "exported",
+  "nodes": [
+    {"name": "Schedule", "type": "n8n-nodes-base.scheduleTrigger", "position": [0, 0], "parameters": {}},
+    {"name": "Fetch", "type": "n8n-nodes-base.httpRequest", "position": [200, 0], "parameters": {"url": "https://example.com"}}
+  ],
+  "connections": {"Schedule": {"main": [[{"node": "Fetch", "type": "main", "index": 0}]]}},
+  "settings": {"executionOrder": "v1"}
+}`
+
+func TestDefinitionDrifted(t *testing.T) {
+	t.Parallel()
+
+	remote := &models.Workflow{
+		ID:          "1",
+		Nodes:       json.RawMessage(`[{"parameters":{"url":"https://example.com"},"name":"Fetch","type":"n8n-nodes-base.httpRequest","position":[200,0],"disabled":false},{"name":"Schedule","type":"n8n-nodes-base.scheduleTrigger","position":[0,0]}]`),
+		Connections: json.RawMessage(`{"Schedule":{"main":[[{"node":"Fetch","type":"main","index":0}]]}}`),
+		Settings:    json.RawMessage(`{"executionOrder":"v1","saveManualExecutions":true}`),
+	}
+
+	tests := []struct {
+		name       string
+		definition types.String
+		want       bool
+	}{
+		{"reformatted", types.StringValue(testWorkflowDefinition), false},
+		{"changed parameter", types.StringValue(strings.Replace(testWorkflowDefinition, "https://example.com", "https://example.org", 1)), true},
+		{"changed setting", types.StringValue(strings.Replace(testWorkflowDefinition, `"v1"`, `"v0"`, 1)), true},
+		{"imported", types.StringNull(), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			drifted, err := definitionDrifted(tt.definition, remote)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if drifted != tt.want {
+				t.Errorf("Expected drifted %t, got %t", tt.want, drifted)
+			}
+		})
+	}
+}
+
+func TestWorkflowDefinitionValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		definition string
+		wantError  bool
+	}{
+		{testWorkflowDefinition, false},
+		{`{"nodes": []}`, false},
+		{`{"connections": {}}`, true},
+		{`not json`, true},
+		{`{"nodes": [], "settings": {"saveManualExecutions": "DEFAULT"}}`, false},
+		{`{"nodes": [], "settings": {"executionOrder": "v2"}}`, true},
+	}
+	for _, tt := range tests {
+		req := validator.StringRequest{
+			Path:        path.Root("definition"),
+			ConfigValue: types.StringValue(tt.definition),
+		}
+		resp := &validator.StringResponse{}
+		workflowDefinitionValidator{}.ValidateString(context.Background(), req, resp)
+		if resp.Diagnostics.HasError() != tt.wantError {
+			t.Errorf("Definition %s: expected error %t, got diagnostics: %+v", tt.definition, tt.wantError, resp.Diagnostics)
+		}
+	}
+}
+
+func TestWorkflowSettingsValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		attributes map[string]tftypes.Value
+		wantError  bool
+	}{
+		{name: "definition only"},
+		{
+			name:       "different setting",
+			attributes: map[string]tftypes.Value{"save_manual_executions": tftypes.NewValue(tftypes.Bool, false)},
+		},
+		{
+			name:       "same setting",
+			attributes: map[string]tftypes.Value{"execution_order": tftypes.NewValue(tftypes.String, "v0")},
+			wantError:  true,
+		},
+		{
+			name:       "caller IDs",
+			attributes: map[string]tftypes.Value{"caller_ids": testCallerIDs("12")},
+		},
+		{
+			name: "caller IDs with caller policy",
+			attributes: map[string]tftypes.Value{
+				"caller_policy": tftypes.NewValue(tftypes.String, "any"),
+				"caller_ids":    testCallerIDs("12"),
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			attributes := map[string]tftypes.Value{
+				"definition": tftypes.NewValue(tftypes.String, testWorkflowDefinition),
+			}
+			for name, value := range tt.attributes {
+				attributes[name] = value
+			}
+			state := workflowTestState(t, attributes)
+			req := resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw},
+			}
+			resp := &resource.ValidateConfigResponse{}
+
+			workflowSettingsValidator{}.ValidateResource(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("Expected error: %v, got diagnostics: %+v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestWorkflowResourceLifecycle(t *testing.T) {
+	t.Parallel()
+
+	server := n8ntest.NewServer(t)
+	host, apiKey, insecure := server.URL, n8ntest.APIKey, false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	r := &workflowResource{client: n8nClient}
+
+	stateString := func(state tfsdk.State, name string) string {
+		t.Helper()
+		var value types.String
+		if diags := state.GetAttribute(ctx, path.Root(name), &value); diags.HasError() {
+			t.Fatalf("Unexpected diagnostics: %+v", diags)
+		}
+		return value.ValueString()
+	}
+
+	// Create
+	planState := workflowTestState(t, map[string]tftypes.Value{
+		"name":       tftypes.NewValue(tftypes.String, "sync"),
+		"definition": tftypes.NewValue(tftypes.String, testWorkflowDefinition),
+	})
+	createResp := &resource.CreateResponse{State: workflowTestState(t, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: unexpected diagnostics: %+v", createResp.Diagnostics)
+	}
+	id := stateString(createResp.State, "id")
+	if workflow := server.Workflow(id); workflow == nil || workflow.Name != "sync" {
+		t.Fatalf("Create: expected workflow %s named %q, got %+v", id, "sync", workflow)
+	}
+
+	// Read keeps the configured definition, which is semantically unchanged.
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: unexpected diagnostics: %+v", readResp.Diagnostics)
+	}
+	if definition := stateString(readResp.State, "definition"); definition != testWorkflowDefinition {
+		t.Errorf("Read: expected the configured definition to be kept, got %s", definition)
+	}
+
+	// The workflow is edited in the editor, so an update based on the old
+	// version fails instead of overwriting the edit.
+	server.EditWorkflow(id, func(workflow *models.Workflow) {
+		workflow.Nodes = json.RawMessage(strings.Replace(string(workflow.Nodes), "https://example.com", "https://edited.example.com", 1))
+	})
+	planState = workflowTestState(t, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, id),
+		"name":       tftypes.NewValue(tftypes.String, "sync renamed"),
+		"definition": tftypes.NewValue(tftypes.String, testWorkflowDefinition),
+		"version_id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	plan := tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}
+	updateResp := &resource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Update(ctx, resource.UpdateRequest{State: readResp.State, Plan: plan}, updateResp)
+	if !updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: expected the out-of-band edit to fail the update")
+	}
+	if detail := updateResp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "changed outside of Terraform") {
+		t.Errorf("Update: expected a remediation hint, got %s", detail)
+	}
+	if workflow := server.Workflow(id); workflow.Name != "sync" {
+		t.Errorf("Update: expected the edited workflow to be kept, got %q", workflow.Name)
+	}
+
+	// Refreshing reports the edit and keeps the known version, so applying
+	// still fails until the edit is overwritten or adopted.
+	editedVersion := server.Workflow(id).VersionID
+	readResp = &resource.ReadResponse{State: readResp.State}
+	r.Read(ctx, resource.ReadRequest{State: readResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read after edit: unexpected diagnostics: %+v", readResp.Diagnostics)
+	}
+	if readResp.Diagnostics.WarningsCount() != 1 || !strings.Contains(readResp.Diagnostics.Warnings()[0].Detail(), editedVersion) {
+		t.Errorf("Read after edit: expected a warning about the edit, got %+v", readResp.Diagnostics)
+	}
+	if definition := stateString(readResp.State, "definition"); !strings.Contains(definition, "edited.example.com") {
+		t.Errorf("Read after edit: expected the edited definition, got %s", definition)
+	}
+	if versionID := stateString(readResp.State, "version_id"); versionID == editedVersion {
+		t.Errorf("Read after edit: expected the known version to be kept, got %s", versionID)
+	}
+
+	updateResp = &resource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Update(ctx, resource.UpdateRequest{State: readResp.State, Plan: plan}, updateResp)
+	if !updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update after refresh: expected the out-of-band edit to fail the update")
+	}
+
+	// Update overwrites the edit when allowed.
+	planState = workflowTestState(t, map[string]tftypes.Value{
+		"id":                             tftypes.NewValue(tftypes.String, id),
+		"name":                           tftypes.NewValue(tftypes.String, "sync renamed"),
+		"definition":                     tftypes.NewValue(tftypes.String, testWorkflowDefinition),
+		"version_id":                     tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"allow_overwrite_remote_changes": tftypes.NewValue(tftypes.Bool, true),
+	})
+	plan = tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}
+	updateResp = &resource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Update(ctx, resource.UpdateRequest{State: readResp.State, Plan: plan}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: unexpected diagnostics: %+v", updateResp.Diagnostics)
+	}
+	workflow := server.Workflow(id)
+	if workflow.Name != "sync renamed" || strings.Contains(string(workflow.Nodes), "edited.example.com") {
+		t.Errorf("Update: expected the configured workflow, got %+v", workflow)
+	}
+	if versionID := stateString(updateResp.State, "version_id"); versionID != workflow.VersionID {
+		t.Errorf("Update: expected version %s, got %s", workflow.VersionID, versionID)
+	}
+
+	// A new version without changes, e.g. from saving in the editor, is
+	// adopted without a warning.
+	server.EditWorkflow(id, func(*models.Workflow) {})
+	readResp = &resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, readResp)
+	if readResp.Diagnostics.HasError() || readResp.Diagnostics.WarningsCount() != 0 {
+		t.Errorf("Read after save: unexpected diagnostics: %+v", readResp.Diagnostics)
+	}
+	if versionID := stateString(readResp.State, "version_id"); versionID != server.Workflow(id).VersionID {
+		t.Errorf("Read after save: expected version %s, got %s", server.Workflow(id).VersionID, versionID)
+	}
+
+	// Import
+	importResp := &resource.ImportStateResponse{State: workflowTestState(t, nil)}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: id}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("ImportState: unexpected diagnostics: %+v", importResp.Diagnostics)
+	}
+	readResp = &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read after import: unexpected diagnostics: %+v", readResp.Diagnostics)
+	}
+	drifted, err := definitionDrifted(types.StringValue(testWorkflowDefinition), server.Workflow(id))
+	if err != nil || drifted {
+		t.Errorf("Read after import: expected the configured definition to match, got drifted %t, error %v", drifted, err)
+	}
+	if definition := stateString(readResp.State, "definition"); definition == "" {
+		t.Errorf("Read after import: expected the definition to be read")
+	}
+
+	// Delete
+	deleteResp := &resource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete: unexpected diagnostics: %+v", deleteResp.Diagnostics)
+	}
+	if server.Workflow(id) != nil {
+		t.Errorf("Delete: expected workflow %s to be deleted", id)
+	}
+
+	// A deleted workflow is removed from state on refresh.
+	readResp = &resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read after delete: unexpected diagnostics: %+v", readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Errorf("Read after delete: expected the workflow to be removed from state, got %v", readResp.State.Raw)
+	}
+}
+
+func TestWorkflowResourceActive(t *testing.T) {
+	t.Parallel()
+
+	server := n8ntest.NewServer(t)
+	host, apiKey, insecure := server.URL, n8ntest.APIKey, false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	r := &workflowResource{client: n8nClient}
+
+	create := func(definition string) *resource.CreateResponse {
+		t.Helper()
+		planState := workflowTestState(t, map[string]tftypes.Value{
+			"name":       tftypes.NewValue(tftypes.String, "sync"),
+			"definition": tftypes.NewValue(tftypes.String, definition),
+			"active":     tftypes.NewValue(tftypes.Bool, true),
+		})
+		createResp := &resource.CreateResponse{State: workflowTestState(t, nil)}
+		r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}}, createResp)
+		return createResp
+	}
+	stateModel := func(state tfsdk.State) workflowResourceModel {
+		t.Helper()
+		var model workflowResourceModel
+		if diags := state.Get(ctx, &model); diags.HasError() {
+			t.Fatalf("Unexpected diagnostics: %+v", diags)
+		}
+		return model
+	}
+
+	// Create activates the workflow.
+	createResp := create(testWorkflowDefinition)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: unexpected diagnostics: %+v", createResp.Diagnostics)
+	}
+	created := stateModel(createResp.State)
+	if !server.Workflow(created.ID.ValueString()).Active || !created.Active.ValueBool() {
+		t.Errorf("Create: expected the workflow to be active")
+	}
+
+	// Deactivating doesn't create a new version.
+	planState := workflowTestState(t, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, created.ID.ValueString()),
+		"name":       tftypes.NewValue(tftypes.String, "sync"),
+		"definition": tftypes.NewValue(tftypes.String, testWorkflowDefinition),
+		"active":     tftypes.NewValue(tftypes.Bool, false),
+		"version_id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	plan := tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}
+	updateResp := &resource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Update(ctx, resource.UpdateRequest{State: createResp.State, Plan: plan}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: unexpected diagnostics: %+v", updateResp.Diagnostics)
+	}
+	updated := stateModel(updateResp.State)
+	if server.Workflow(created.ID.ValueString()).Active || updated.Active.ValueBool() {
+		t.Errorf("Update: expected the workflow to be inactive")
+	}
+	if !updated.VersionID.Equal(created.VersionID) {
+		t.Errorf("Update: expected version %s to be kept, got %s", created.VersionID, updated.VersionID)
+	}
+
+	// A workflow that cannot be activated is kept in state as inactive.
+	createResp = create(`{"nodes": [{"name": "Fetch", "type": "n8n-nodes-base.httpRequest"}]}`)
+	if !createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: expected the activation to fail")
+	}
+	failed := stateModel(createResp.State)
+	if failed.ID.IsNull() || failed.Active.ValueBool() {
+		t.Errorf("Create: expected the inactive workflow in state, got %+v", failed)
+	}
+}
+
+func TestWorkflowResourceMetadataUpdate(t *testing.T) {
+	t.Parallel()
+
+	server := n8ntest.NewServer(t)
+	host, apiKey, insecure := server.URL, n8ntest.APIKey, false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0), client.WithInternalAPI(n8ntest.Email, n8ntest.Password))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	r := &workflowResource{client: n8nClient}
+
+	planState := workflowTestState(t, map[string]tftypes.Value{
+		"name":       tftypes.NewValue(tftypes.String, "sync"),
+		"definition": tftypes.NewValue(tftypes.String, testWorkflowDefinition),
+	})
+	createResp := &resource.CreateResponse{State: workflowTestState(t, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: unexpected diagnostics: %+v", createResp.Diagnostics)
+	}
+	var created workflowResourceModel
+	createResp.State.Get(ctx, &created)
+	id := created.ID.ValueString()
+
+	server.EditWorkflow(id, func(workflow *models.Workflow) {
+		workflow.Nodes = json.RawMessage(strings.Replace(string(workflow.Nodes), "https://example.com", "https://example.org", 1))
+	})
+
+	renamePlan := func(allowOverwrite bool) tfsdk.Plan {
+		planState := workflowTestState(t, map[string]tftypes.Value{
+			"id":                             tftypes.NewValue(tftypes.String, id),
+			"name":                           tftypes.NewValue(tftypes.String, "sync renamed"),
+			"definition":                     tftypes.NewValue(tftypes.String, testWorkflowDefinition),
+			"allow_overwrite_remote_changes": tftypes.NewValue(tftypes.Bool, allowOverwrite),
+			"version_id":                     tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		})
+		return tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}
+	}
+
+	// Renaming a workflow edited since it was read fails.
+	plan := renamePlan(false)
+	updateResp := &resource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Update(ctx, resource.UpdateRequest{State: createResp.State, Plan: plan}, updateResp)
+	if !updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: expected the update of the edited workflow to fail")
+	}
+	if detail := updateResp.Diagnostics[0].Detail(); !strings.Contains(detail, "allow_overwrite_remote_changes") {
+		t.Errorf("Update: expected the overwrite hint in the diagnostics, got %q", detail)
+	}
+
+	// Renaming without the version check keeps the nodes edited in n8n,
+	// as the node graph is not re-uploaded.
+	plan = renamePlan(true)
+	updateResp = &resource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Update(ctx, resource.UpdateRequest{State: createResp.State, Plan: plan}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: unexpected diagnostics: %+v", updateResp.Diagnostics)
+	}
+	remote := server.Workflow(id)
+	if remote.Name != "sync renamed" {
+		t.Errorf("Update: expected the renamed workflow, got %q", remote.Name)
+	}
+	if !strings.Contains(string(remote.Nodes), "https://example.org") {
+		t.Errorf("Update: expected the nodes edited in n8n to be kept, got %s", remote.Nodes)
+	}
+	var updated workflowResourceModel
+	updateResp.State.Get(ctx, &updated)
+	if updated.VersionID.ValueString() != remote.VersionID {
+		t.Errorf("Update: expected version %s, got %s", remote.VersionID, updated.VersionID)
+	}
+}
+
+func TestWorkflowResourceSettings(t *testing.T) {
+	t.Parallel()
+
+	server := n8ntest.NewServer(t)
+	host, apiKey, insecure := server.URL, n8ntest.APIKey, false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	r := &workflowResource{client: n8nClient}
+	settingsPlan := func(attributes map[string]tftypes.Value) tfsdk.Plan {
+		attributes["name"] = tftypes.NewValue(tftypes.String, "sync")
+		attributes["definition"] = tftypes.NewValue(tftypes.String, testWorkflowDefinition)
+		planState := workflowTestState(t, attributes)
+		return tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}
+	}
+
+	// Create merges the settings attributes into the definition's settings.
+	plan := settingsPlan(map[string]tftypes.Value{
+		"save_data_success_execution": tftypes.NewValue(tftypes.String, "none"),
+		"save_manual_executions":      tftypes.NewValue(tftypes.Bool, false),
+	})
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: unexpected diagnostics: %+v", createResp.Diagnostics)
+	}
+	var created workflowResourceModel
+	createResp.State.Get(ctx, &created)
+	id := created.ID.ValueString()
+
+	settings, err := server.Workflow(id).ParseSettings()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if settings.ExecutionOrder != "v1" || settings.SaveDataSuccessExecution != "none" ||
+		settings.SaveManualExecutions == nil || *settings.SaveManualExecutions {
+		t.Errorf("Create: unexpected settings %s", server.Workflow(id).Settings)
+	}
+
+	// Refreshing reports settings changed in the editor.
+	server.EditWorkflow(id, func(workflow *models.Workflow) {
+		workflow.Settings = json.RawMessage(`{"executionOrder":"v1","saveDataSuccessExecution":"all","saveManualExecutions":false}`)
+	})
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: unexpected diagnostics: %+v", readResp.Diagnostics)
+	}
+	var read workflowResourceModel
+	readResp.State.Get(ctx, &read)
+	if read.SaveDataSuccessExecution.ValueString() != "all" || read.SaveManualExecutions.ValueBool() {
+		t.Errorf("Read: unexpected settings %s and %s", read.SaveDataSuccessExecution, read.SaveManualExecutions)
+	}
+	if !read.Definition.Equal(created.Definition) {
+		t.Errorf("Read: expected the definition to be unchanged, got %s", read.Definition)
+	}
+
+	// Changing only a settings attribute updates the workflow.
+	plan = settingsPlan(map[string]tftypes.Value{
+		"id":                          tftypes.NewValue(tftypes.String, id),
+		"save_data_success_execution": tftypes.NewValue(tftypes.String, "none"),
+		"save_manual_executions":      tftypes.NewValue(tftypes.Bool, false),
+		"version_id":                  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	updateResp := &resource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Update(ctx, resource.UpdateRequest{State: readResp.State, Plan: plan}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: unexpected diagnostics: %+v", updateResp.Diagnostics)
+	}
+	settings, err = server.Workflow(id).ParseSettings()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if settings.SaveDataSuccessExecution != "none" {
+		t.Errorf("Update: unexpected settings %s", server.Workflow(id).Settings)
+	}
+}
+
+func TestWorkflowResourceCallerPolicy(t *testing.T) {
+	t.Parallel()
+
+	server := n8ntest.NewServer(t)
+	host, apiKey, insecure := server.URL, n8ntest.APIKey, false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	r := &workflowResource{client: n8nClient}
+
+	// Setting caller_ids restricts the callers to the listed workflows.
+	planState := workflowTestState(t, map[string]tftypes.Value{
+		"name":       tftypes.NewValue(tftypes.String, "child"),
+		"definition": tftypes.NewValue(tftypes.String, testWorkflowDefinition),
+		"caller_ids": testCallerIDs("34", "12"),
+	})
+	createResp := &resource.CreateResponse{State: workflowTestState(t, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: unexpected diagnostics: %+v", createResp.Diagnostics)
+	}
+	var created workflowResourceModel
+	createResp.State.Get(ctx, &created)
+	id := created.ID.ValueString()
+
+	settings, err := server.Workflow(id).ParseSettings()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if settings.CallerPolicy != "workflowsFromAList" || settings.CallerIDs != "12,34" {
+		t.Errorf("Create: unexpected settings %s", server.Workflow(id).Settings)
+	}
+
+	// Refreshing reports callers added in the editor.
+	server.EditWorkflow(id, func(workflow *models.Workflow) {
+		workflow.Settings = json.RawMessage(`{"executionOrder":"v1","callerPolicy":"workflowsFromAList","callerIds":"12, 34, 56"}`)
+	})
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: unexpected diagnostics: %+v", readResp.Diagnostics)
+	}
+	var read workflowResourceModel
+	readResp.State.Get(ctx, &read)
+	if len(read.CallerIDs.Elements()) != 3 {
+		t.Errorf("Read: expected 3 caller IDs, got %s", read.CallerIDs)
+	}
+}
+
+// testCallerIDs returns a caller_ids value with the given workflow IDs.
+func testCallerIDs(ids ...string) tftypes.Value {
+	values := make([]tftypes.Value, len(ids))
+	for i, id := range ids {
+		values[i] = tftypes.NewValue(tftypes.String, id)
+	}
+	return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, values)
+}
+
+func TestWorkflowResourceActivationError(t *testing.T) {
+	t.Parallel()
+
+	server := n8ntest.NewServer(t)
+	host, apiKey, insecure := server.URL, n8ntest.APIKey, false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0), client.WithInternalAPI(n8ntest.Email, n8ntest.Password))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	r := &workflowResource{client: n8nClient}
+
+	planState := workflowTestState(t, map[string]tftypes.Value{
+		"name":       tftypes.NewValue(tftypes.String, "sync"),
+		"definition": tftypes.NewValue(tftypes.String, testWorkflowDefinition),
+		"active":     tftypes.NewValue(tftypes.Bool, false),
+	})
+	createResp := &resource.CreateResponse{State: workflowTestState(t, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: unexpected diagnostics: %+v", createResp.Diagnostics)
+	}
+	var created workflowResourceModel
+	createResp.State.Get(ctx, &created)
+	server.SetActivationError(created.ID.ValueString(), "Credential with ID \"42\" does not exist")
+
+	// Activating fails with the error n8n recorded.
+	planState = workflowTestState(t, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, created.ID.ValueString()),
+		"name":       tftypes.NewValue(tftypes.String, "sync"),
+		"definition": tftypes.NewValue(tftypes.String, testWorkflowDefinition),
+		"active":     tftypes.NewValue(tftypes.Bool, true),
+		"version_id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	plan := tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}
+	updateResp := &resource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Update(ctx, resource.UpdateRequest{State: createResp.State, Plan: plan}, updateResp)
+	if !updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: expected the activation to fail")
+	}
+	if detail := updateResp.Diagnostics[0].Detail(); !strings.Contains(detail, "does not exist") {
+		t.Errorf("Update: expected the activation error in the diagnostics, got %q", detail)
+	}
+
+	// Refreshing the workflow n8n still reports as active warns.
+	readResp := &resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, readResp)
+	if readResp.Diagnostics.HasError() || readResp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("Read: expected a warning, got diagnostics: %+v", readResp.Diagnostics)
+	}
+	if summary := readResp.Diagnostics[0].Summary(); summary != "Workflow Activation Failed" {
+		t.Errorf("Read: expected the activation warning, got %q", summary)
+	}
+}
+
+func TestWorkflowResourceProject(t *testing.T) {
+	t.Parallel()
+
+	server := n8ntest.NewServer(t)
+	host, apiKey, insecure := server.URL, n8ntest.APIKey, false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	r := &workflowResource{client: n8nClient}
+
+	// Create transfers the workflow into the configured project.
+	planState := workflowTestState(t, map[string]tftypes.Value{
+		"name":       tftypes.NewValue(tftypes.String, "sync"),
+		"definition": tftypes.NewValue(tftypes.String, testWorkflowDefinition),
+		"project_id": tftypes.NewValue(tftypes.String, "team-a"),
+	})
+	createResp := &resource.CreateResponse{State: workflowTestState(t, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: unexpected diagnostics: %+v", createResp.Diagnostics)
+	}
+	var created workflowResourceModel
+	createResp.State.Get(ctx, &created)
+	if project := server.Workflow(created.ID.ValueString()).HomeProject; project == nil || project.ID != "team-a" {
+		t.Errorf("Create: expected the workflow in project team-a, got %+v", project)
+	}
+	if created.ProjectID.ValueString() != "team-a" {
+		t.Errorf("Create: expected project_id team-a, got %s", created.ProjectID)
+	}
+
+	// Changing the project transfers the workflow without a new version.
+	planState = workflowTestState(t, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, created.ID.ValueString()),
+		"name":       tftypes.NewValue(tftypes.String, "sync"),
+		"definition": tftypes.NewValue(tftypes.String, testWorkflowDefinition),
+		"project_id": tftypes.NewValue(tftypes.String, "team-b"),
+		"version_id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	plan := tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}
+	updateResp := &resource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Update(ctx, resource.UpdateRequest{State: createResp.State, Plan: plan}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: unexpected diagnostics: %+v", updateResp.Diagnostics)
+	}
+	var updated workflowResourceModel
+	updateResp.State.Get(ctx, &updated)
+	if project := server.Workflow(created.ID.ValueString()).HomeProject; project == nil || project.ID != "team-b" {
+		t.Errorf("Update: expected the workflow in project team-b, got %+v", project)
+	}
+	if updated.ProjectID.ValueString() != "team-b" || !updated.VersionID.Equal(created.VersionID) {
+		t.Errorf("Update: expected project_id team-b and version %s, got %s and %s", created.VersionID, updated.ProjectID, updated.VersionID)
+	}
+
+	// Refreshing reads the project n8n reports.
+	server.EditWorkflow(created.ID.ValueString(), func(workflow *models.Workflow) {
+		workflow.HomeProject = &models.Project{ID: "team-c"}
+	})
+	readResp := &resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: unexpected diagnostics: %+v", readResp.Diagnostics)
+	}
+	var read workflowResourceModel
+	readResp.State.Get(ctx, &read)
+	if read.ProjectID.ValueString() != "team-c" {
+		t.Errorf("Read: expected project_id team-c, got %s", read.ProjectID)
+	}
+}
+
+func TestWorkflowResourceArchive(t *testing.T) {
+	t.Parallel()
+
+	server := n8ntest.NewServer(t)
+	host, apiKey, insecure := server.URL, n8ntest.APIKey, false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0), client.WithInternalAPI(n8ntest.Email, n8ntest.Password))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	r := &workflowResource{client: n8nClient}
+	stateModel := func(state tfsdk.State) workflowResourceModel {
+		t.Helper()
+		var model workflowResourceModel
+		if diags := state.Get(ctx, &model); diags.HasError() {
+			t.Fatalf("Unexpected diagnostics: %+v", diags)
+		}
+		return model
+	}
+
+	// Create archives the workflow.
+	planState := workflowTestState(t, map[string]tftypes.Value{
+		"name":               tftypes.NewValue(tftypes.String, "sync"),
+		"definition":         tftypes.NewValue(tftypes.String, testWorkflowDefinition),
+		"archived":           tftypes.NewValue(tftypes.Bool, true),
+		"archive_on_destroy": tftypes.NewValue(tftypes.Bool, true),
+	})
+	createResp := &resource.CreateResponse{State: workflowTestState(t, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: unexpected diagnostics: %+v", createResp.Diagnostics)
+	}
+	created := stateModel(createResp.State)
+	if !server.Workflow(created.ID.ValueString()).IsArchived || !created.Archived.ValueBool() {
+		t.Errorf("Create: expected the workflow to be archived")
+	}
+	if created.VersionID.ValueString() != server.Workflow(created.ID.ValueString()).VersionID {
+		t.Errorf("Create: expected the version of the archived workflow, got %s", created.VersionID)
+	}
+
+	// Changing an archived workflow unarchives it first.
+	planState = workflowTestState(t, map[string]tftypes.Value{
+		"id":                 tftypes.NewValue(tftypes.String, created.ID.ValueString()),
+		"name":               tftypes.NewValue(tftypes.String, "sync renamed"),
+		"definition":         tftypes.NewValue(tftypes.String, testWorkflowDefinition),
+		"archived":           tftypes.NewValue(tftypes.Bool, false),
+		"archive_on_destroy": tftypes.NewValue(tftypes.Bool, true),
+		"version_id":         tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	plan := tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}
+	updateResp := &resource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}}
+	r.Update(ctx, resource.UpdateRequest{State: createResp.State, Plan: plan}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: unexpected diagnostics: %+v", updateResp.Diagnostics)
+	}
+	remote := server.Workflow(created.ID.ValueString())
+	if remote.IsArchived || remote.Name != "sync renamed" {
+		t.Errorf("Update: expected the unarchived, renamed workflow, got %+v", remote)
+	}
+
+	// Refreshing reads the archive state.
+	server.EditWorkflow(created.ID.ValueString(), func(workflow *models.Workflow) {
+		workflow.IsArchived = true
+	})
+	readResp := &resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: unexpected diagnostics: %+v", readResp.Diagnostics)
+	}
+	if !stateModel(readResp.State).Archived.ValueBool() {
+		t.Errorf("Read: expected the workflow to be archived")
+	}
+
+	// Destroying archives the workflow instead of deleting it.
+	server.EditWorkflow(created.ID.ValueString(), func(workflow *models.Workflow) {
+		workflow.IsArchived = false
+	})
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("Delete: unexpected diagnostics: %+v", deleteResp.Diagnostics)
+	}
+	if remote := server.Workflow(created.ID.ValueString()); remote == nil || !remote.IsArchived {
+		t.Errorf("Delete: expected the workflow to be archived, got %+v", remote)
+	}
+
+	// Without the internal API, the plan fails.
+	publicClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	modifyResp := &resource.ModifyPlanResponse{Plan: plan}
+	(&workflowResource{client: publicClient}).ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: createResp.State}, modifyResp)
+	if !modifyResp.Diagnostics.HasError() {
+		t.Errorf("ModifyPlan: expected an error without the internal API")
+	}
+}
+
+func TestWorkflowResourceNodeTypes(t *testing.T) {
+	t.Parallel()
+
+	server := n8ntest.NewServer(t)
+	host, apiKey, insecure := server.URL, n8ntest.APIKey, false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0), client.WithInternalAPI(n8ntest.Email, n8ntest.Password))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	r := &workflowResource{client: n8nClient}
+	modifyPlan := func() *resource.ModifyPlanResponse {
+		t.Helper()
+		planState := workflowTestState(t, map[string]tftypes.Value{
+			"name": tftypes.NewValue(tftypes.String, "sync"),
+			"definition": tftypes.NewValue(tftypes.String, `{"nodes": [
+				{"name": "Schedule", "type": "n8n-nodes-base.scheduleTrigger"},
+				{"name": "Create contact", "type": "@acme/n8n-nodes-crm.contact"}
+			]}`),
+		})
+		plan := tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}
+		resp := &resource.ModifyPlanResponse{Plan: plan}
+		// Planning a new workflow has no prior state.
+		state := tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: state}, resp)
+		return resp
+	}
+
+	resp := modifyPlan()
+	if !resp.Diagnostics.HasError() {
+		t.Fatalf("Expected an error for the missing node type")
+	}
+	if detail := resp.Diagnostics[0].Detail(); !strings.Contains(detail, "@acme/n8n-nodes-crm.contact") || !strings.Contains(detail, "(@acme/n8n-nodes-crm)") {
+		t.Errorf("Expected the error to name the node type and its package, got %q", detail)
+	}
+
+	server.InstallNodeTypes("@acme/n8n-nodes-crm.contact")
+	if resp := modifyPlan(); resp.Diagnostics.HasError() {
+		t.Errorf("Unexpected diagnostics once the package is installed: %+v", resp.Diagnostics)
+	}
+}
+
+func TestWorkflowResourceSubWorkflows(t *testing.T) {
+	t.Parallel()
+
+	server := n8ntest.NewServer(t)
+	host, apiKey, insecure := server.URL, n8ntest.APIKey, false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	r := &workflowResource{client: n8nClient}
+	modifyPlan := func(subWorkflowID string) *resource.ModifyPlanResponse {
+		t.Helper()
+		planState := workflowTestState(t, map[string]tftypes.Value{
+			"name": tftypes.NewValue(tftypes.String, "parent"),
+			"definition": tftypes.NewValue(tftypes.String, fmt.Sprintf(`{"nodes": [
+				{"name": "Start", "type": "n8n-nodes-base.manualTrigger"},
+				{"name": "Run child", "type": "n8n-nodes-base.executeWorkflow", "parameters": {
+					"workflowId": {"__rl": true, "mode": "list", "value": %q, "cachedResultName": "child"}
+				}}
+			]}`, subWorkflowID)),
+		})
+		plan := tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}
+		resp := &resource.ModifyPlanResponse{Plan: plan}
+		state := tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: state}, resp)
+		return resp
+	}
+
+	resp := modifyPlan("999")
+	if !resp.Diagnostics.HasError() {
+		t.Fatalf("Expected an error for the missing sub-workflow")
+	}
+	if detail := resp.Diagnostics[0].Detail(); !strings.Contains(detail, `node "Run child" calls workflow ID 999 ("child")`) {
+		t.Errorf("Expected the error to name the node and the sub-workflow, got %q", detail)
+	}
+
+	childID := server.AddWorkflow(models.Workflow{Name: "child", Nodes: json.RawMessage(`[]`)})
+	if resp := modifyPlan(childID); resp.Diagnostics.HasError() {
+		t.Errorf("Unexpected diagnostics for an existing sub-workflow: %+v", resp.Diagnostics)
+	}
+}
+
+func TestWorkflowResourceForceDestroy(t *testing.T) {
+	t.Parallel()
+
+	server := n8ntest.NewServer(t)
+	host, apiKey, insecure := server.URL, n8ntest.APIKey, false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	r := &workflowResource{client: n8nClient}
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	testCases := []struct {
+		name         string
+		active       bool
+		executedAt   string
+		window       string
+		forceDestroy tftypes.Value
+		wantError    bool
+		wantWarning  bool
+	}{
+		{name: "active", active: true, forceDestroy: tftypes.NewValue(tftypes.Bool, false), wantError: true},
+		{name: "active unset", active: true, forceDestroy: tftypes.NewValue(tftypes.Bool, nil), wantWarning: true},
+		{name: "active forced", active: true, forceDestroy: tftypes.NewValue(tftypes.Bool, true)},
+		{name: "inactive", forceDestroy: tftypes.NewValue(tftypes.Bool, false)},
+		{name: "executed within window", executedAt: recent, window: "24h", forceDestroy: tftypes.NewValue(tftypes.Bool, false), wantError: true},
+		{name: "executed before window", executedAt: recent, window: "30m", forceDestroy: tftypes.NewValue(tftypes.Bool, false)},
+		{name: "executed without window", executedAt: recent, forceDestroy: tftypes.NewValue(tftypes.Bool, false)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			id := server.AddWorkflow(models.Workflow{Name: tc.name, Nodes: json.RawMessage(`[]`), Active: tc.active})
+			if tc.executedAt != "" {
+				server.AddExecution(models.Execution{WorkflowID: id, Status: "success", StartedAt: tc.executedAt})
+			}
+
+			attributes := map[string]tftypes.Value{
+				"id":            tftypes.NewValue(tftypes.String, id),
+				"force_destroy": tc.forceDestroy,
+			}
+			if tc.window != "" {
+				attributes["recent_execution_window"] = tftypes.NewValue(tftypes.String, tc.window)
+			}
+			state := workflowTestState(t, attributes)
+			deleteResp := &resource.DeleteResponse{}
+			r.Delete(ctx, resource.DeleteRequest{State: state}, deleteResp)
+
+			if deleteResp.Diagnostics.HasError() != tc.wantError {
+				t.Errorf("Expected error: %v, got diagnostics: %+v", tc.wantError, deleteResp.Diagnostics)
+			}
+			if warned := deleteResp.Diagnostics.WarningsCount() > 0; warned != tc.wantWarning {
+				t.Errorf("Expected warning: %v, got diagnostics: %+v", tc.wantWarning, deleteResp.Diagnostics)
+			}
+			if deleted := server.Workflow(id) == nil; deleted == tc.wantError {
+				t.Errorf("Expected the workflow to be deleted: %v", !tc.wantError)
+			}
+		})
+	}
+}
+
+// workflowTestState builds a workflow resource state with the given attribute
+// values and every other attribute null.
+func workflowTestState(t *testing.T, attributes map[string]tftypes.Value) tfsdk.State {
+	t.Helper()
+
+	ctx := context.Background()
+	schemaResponse := &resource.SchemaResponse{}
+	NewWorkflowResource().Schema(ctx, resource.SchemaRequest{}, schemaResponse)
+
+	objectType, ok := schemaResponse.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("Expected schema to be an object type")
+	}
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range attributes {
+		values[name] = value
+	}
+
+	return tfsdk.State{Schema: schemaResponse.Schema, Raw: tftypes.NewValue(objectType, values)}
+}
@@ -0,0 +1,108 @@
+// Package sweep deletes resources left behind by interrupted acceptance test
+// runs.
+//
+// Acceptance tests name every resource they create with a common prefix, so
+// leftovers on a shared test instance can be found by name and deleted
+// without touching anything else on the instance.
+package sweep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+)
+
+// DefaultPrefix is the name prefix of resources created by acceptance tests.
+const DefaultPrefix = "tf-acc-"
+
+// Sweeper deletes the resources of one type whose name starts with a prefix
+// and returns the names of the deleted resources.
+type Sweeper struct {
+	// Name is the resource type the sweeper deletes, e.g. "n8n_credential".
+	Name  string
+	Sweep func(ctx context.Context, n8nClient *client.Client, prefix string) ([]string, error)
+}
+
+// Sweepers lists the sweepers in the order they run. Workflows are swept
+// first, since they may reference the credentials.
+var Sweepers = []Sweeper{
+	{Name: "n8n_workflow", Sweep: Workflows},
+	{Name: "n8n_credential", Sweep: Credentials},
+}
+
+// All runs every sweeper, continuing past failures, and returns the deleted
+// resource names by sweeper name.
+func All(ctx context.Context, n8nClient *client.Client, prefix string) (map[string][]string, error) {
+	if prefix == "" {
+		return nil, fmt.Errorf("refusing to sweep without a name prefix")
+	}
+
+	swept := make(map[string][]string, len(Sweepers))
+	var errs []error
+	for _, sweeper := range Sweepers {
+		names, err := sweeper.Sweep(ctx, n8nClient, prefix)
+		swept[sweeper.Name] = names
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error sweeping %s: %w", sweeper.Name, err))
+		}
+	}
+	return swept, errors.Join(errs...)
+}
+
+// Credentials deletes the credentials whose name starts with prefix.
+func Credentials(ctx context.Context, n8nClient *client.Client, prefix string) ([]string, error) {
+	credentials, err := n8nClient.ListCredentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []string
+	var errs []error
+	for _, credential := range credentials {
+		if !strings.HasPrefix(credential.Name, prefix) {
+			continue
+		}
+		if err := deleteIgnoringNotFound(n8nClient.DeleteCredential(ctx, credential.ID)); err != nil {
+			errs = append(errs, fmt.Errorf("error deleting credential %s (%s): %w", credential.Name, credential.ID, err))
+			continue
+		}
+		deleted = append(deleted, credential.Name)
+	}
+	return deleted, errors.Join(errs...)
+}
+
+// Workflows deletes the workflows whose name starts with prefix. Active
+// workflows are deleted as well; n8n deactivates them first.
+func Workflows(ctx context.Context, n8nClient *client.Client, prefix string) ([]string, error) {
+	workflows, err := n8nClient.ListWorkflows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []string
+	var errs []error
+	for _, workflow := range workflows {
+		if !strings.HasPrefix(workflow.Name, prefix) {
+			continue
+		}
+		if err := deleteIgnoringNotFound(n8nClient.DeleteWorkflow(ctx, workflow.ID)); err != nil {
+			errs = append(errs, fmt.Errorf("error deleting workflow %s (%s): %w", workflow.Name, workflow.ID, err))
+			continue
+		}
+		deleted = append(deleted, workflow.Name)
+	}
+	return deleted, errors.Join(errs...)
+}
+
+// deleteIgnoringNotFound treats resources deleted concurrently, e.g. by a
+// test's own destroy step, as swept.
+func deleteIgnoringNotFound(err error) error {
+	var notFound *client.NotFoundError
+	if errors.As(err, &notFound) {
+		return nil
+	}
+	return err
+}
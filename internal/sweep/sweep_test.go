@@ -0,0 +1,81 @@
+package sweep
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/artus-engineering/terraform-provider-n8n/internal/client"
+	"github.com/artus-engineering/terraform-provider-n8n/internal/models"
+	"github.com/artus-engineering/terraform-provider-n8n/internal/n8ntest"
+)
+
+// sweepPrefix selects the resources TestSweep deletes from the instance
+// configured with N8N_HOST and N8N_API_KEY, e.g.
+//
+//	go test ./internal/sweep -run TestSweep -v -sweep=tf-acc-
+var sweepPrefix = flag.String("sweep", "", "delete resources whose name starts with this prefix")
+
+func TestSweep(t *testing.T) {
+	if *sweepPrefix == "" {
+		t.Skip("Sweeping runs only with -sweep=<prefix>")
+	}
+
+	host, apiKey, insecure := os.Getenv("N8N_HOST"), os.Getenv("N8N_API_KEY"), os.Getenv("N8N_INSECURE") == "true"
+	if host == "" || apiKey == "" {
+		t.Fatal("N8N_HOST and N8N_API_KEY must be set to sweep")
+	}
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	swept, err := All(context.Background(), n8nClient, *sweepPrefix)
+	for name, names := range swept {
+		t.Logf("Swept %d %s resources: %v", len(names), name, names)
+	}
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestAll(t *testing.T) {
+	server := n8ntest.NewServer(t)
+	host, apiKey, insecure := server.URL, n8ntest.APIKey, false
+	n8nClient, err := client.NewClient(&host, &apiKey, &insecure, client.WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	leftover := server.AddCredential(models.Credential{Name: DefaultPrefix + "api", Type: "httpHeaderAuth"})
+	kept := server.AddCredential(models.Credential{Name: "production api", Type: "httpHeaderAuth"})
+	leftoverWorkflow := server.AddWorkflow(models.Workflow{Name: DefaultPrefix + "flow", Nodes: json.RawMessage(`[]`), Connections: json.RawMessage(`{}`)})
+	keptWorkflow := server.AddWorkflow(models.Workflow{Name: "flow " + DefaultPrefix, Nodes: json.RawMessage(`[]`), Connections: json.RawMessage(`{}`)})
+
+	swept, err := All(context.Background(), n8nClient, DefaultPrefix)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !slices.Equal(swept["n8n_credential"], []string{DefaultPrefix + "api"}) {
+		t.Errorf("Expected the prefixed credential to be swept, got %v", swept["n8n_credential"])
+	}
+	if !slices.Equal(swept["n8n_workflow"], []string{DefaultPrefix + "flow"}) {
+		t.Errorf("Expected the prefixed workflow to be swept, got %v", swept["n8n_workflow"])
+	}
+	if server.Credential(leftover) != nil || server.Workflow(leftoverWorkflow) != nil {
+		t.Error("Expected prefixed resources to be deleted")
+	}
+	if server.Credential(kept) == nil || server.Workflow(keptWorkflow) == nil {
+		t.Error("Expected other resources to be kept")
+	}
+}
+
+func TestAllRequiresPrefix(t *testing.T) {
+	if _, err := All(context.Background(), nil, ""); err == nil {
+		t.Error("Expected an error when sweeping without a prefix")
+	}
+}